@@ -0,0 +1,640 @@
+package cl12
+
+import "unsafe"
+
+// API mirrors the functions of this package that call into the OpenCL library.
+//
+// Code that takes an API instead of calling the package functions directly can be tested without an OpenCL
+// device. LibraryAPI is the implementation that forwards all calls to the package functions; the package cltest
+// provides a recording fake.
+type API interface {
+	PlatformIDs() ([]PlatformID, error)
+	PlatformInfo(id PlatformID, paramName PlatformInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	PlatformInfoString(id PlatformID, paramName PlatformInfoName) (string, error)
+	ExtensionFunctionAddressForPlatform(id PlatformID, functionName string) unsafe.Pointer
+	UnloadPlatformCompiler(id PlatformID) error
+	LoadExtensionTerminateContextKhr(id PlatformID) (ContextTerminator, error)
+
+	DeviceIDs(platformID PlatformID, deviceType DeviceTypeFlags) ([]DeviceID, error)
+	DeviceInfo(id DeviceID, paramName DeviceInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	DeviceInfoString(id DeviceID, paramName DeviceInfoName) (string, error)
+	CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]DeviceID, error)
+	RetainDevice(id DeviceID) error
+	ReleaseDevice(id DeviceID) error
+	QueryDevice(id DeviceID) (DeviceProperties, error)
+
+	CreateContext(deviceIds []DeviceID, callback *ContextErrorCallback, properties ...ContextProperty) (Context, error)
+	CreateContextFromType(deviceType DeviceTypeFlags, callback *ContextErrorCallback,
+		properties ...ContextProperty) (Context, error)
+	RetainContext(context Context) error
+	ReleaseContext(context Context) error
+	ContextInfo(context Context, paramName ContextInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	ContextInfoString(context Context, paramName ContextInfoName) (string, error)
+
+	CreateCommandQueue(context Context, deviceID DeviceID, properties CommandQueuePropertiesFlags) (CommandQueue, error)
+	RetainCommandQueue(commandQueue CommandQueue) error
+	ReleaseCommandQueue(commandQueue CommandQueue) error
+	CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName, paramSize uintptr,
+		paramValue unsafe.Pointer) (uintptr, error)
+	Flush(commandQueue CommandQueue) error
+	Finish(commandQueue CommandQueue) error
+
+	RetainMemObject(mem MemObject) error
+	ReleaseMemObject(mem MemObject) error
+	SetMemObjectDestructorCallback(mem MemObject, callback func()) error
+	MemObjectInfo(mem MemObject, paramName MemObjectInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	EnqueueUnmapMemObject(commandQueue CommandQueue, mem MemObject, mappedPtr unsafe.Pointer,
+		waitList []Event, event *Event) error
+	EnqueueMigrateMemObjects(commandQueue CommandQueue, memObjects []MemObject,
+		migrationFlags MemMigrationFlags, waitList []Event, event *Event) error
+
+	CreateBuffer(context Context, flags MemFlags, size int, hostPtr unsafe.Pointer) (MemObject, error)
+	CreateSubBuffer(buffer MemObject, flags MemFlags, createType BufferCreateType, createInfo unsafe.Pointer) (MemObject, error)
+	EnqueueMapBuffer(commandQueue CommandQueue, buffer MemObject, blocking bool, flags MapFlags,
+		offset, size uintptr, waitList []Event, event *Event) (unsafe.Pointer, error)
+	EnqueueReadBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr,
+		data unsafe.Pointer, waitList []Event, event *Event) error
+	EnqueueReadBufferRect(commandQueue CommandQueue, mem MemObject, blockingRead bool,
+		bufferOrigin, hostOrigin, region [3]uintptr, bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr,
+		data unsafe.Pointer, waitList []Event, event *Event) error
+	EnqueueWriteBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr,
+		data unsafe.Pointer, waitList []Event, event *Event) error
+	EnqueueWriteBufferRect(commandQueue CommandQueue, mem MemObject, blockingRead bool,
+		bufferOrigin, hostOrigin, region [3]uintptr, bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr,
+		data unsafe.Pointer, waitList []Event, event *Event) error
+	EnqueueFillBuffer(commandQueue CommandQueue, mem MemObject, pattern unsafe.Pointer,
+		patternSize, offset, size uintptr, waitList []Event, event *Event) error
+	EnqueueCopyBuffer(commandQueue CommandQueue, src, dst MemObject, srcOffset, dstOffset, size uintptr,
+		waitList []Event, event *Event) error
+	EnqueueCopyBufferRect(commandQueue CommandQueue, src, dst MemObject,
+		srcOrigin, dstOrigin, region [3]uintptr, srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch uintptr, waitList []Event,
+		event *Event) error
+
+	CreateImage(context Context, flags MemFlags, format ImageFormat, desc ImageDesc, hostPtr unsafe.Pointer) (MemObject, error)
+	SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectType) ([]ImageFormat, error)
+	EnqueueMapImage(commandQueue CommandQueue, image MemObject, blocking bool, flags MapFlags,
+		origin, region [3]uintptr, waitList []Event, event *Event) (MappedImage, error)
+	ImageInfo(image MemObject, paramName ImageInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	EnqueueReadImage(commandQueue CommandQueue, image MemObject, blocking bool, origin, region [3]uintptr,
+		rowPitch, slicePitch uintptr, ptr unsafe.Pointer, waitList []Event, event *Event) error
+	EnqueueWriteImage(commandQueue CommandQueue, image MemObject, blocking bool, origin, region [3]uintptr,
+		rowPitch, slicePitch uintptr, ptr unsafe.Pointer, waitList []Event, event *Event) error
+	EnqueueFillImage(commandQueue CommandQueue, image MemObject, fillColor unsafe.Pointer,
+		origin, region [3]uintptr, waitList []Event, event *Event) error
+	EnqueueCopyImage(commandQueue CommandQueue, srcImage, dstImage MemObject,
+		srcOrigin, dstOrigin, region [3]uintptr, waitList []Event, event *Event) error
+	EnqueueCopyImageToBuffer(commandQueue CommandQueue, srcImage, dstBuffer MemObject,
+		srcOrigin, region [3]uintptr, dstOffset uintptr, waitList []Event, event *Event) error
+	EnqueueCopyBufferToImage(commandQueue CommandQueue, srcBuffer, dstImage MemObject, srcOffset uintptr,
+		srcOrigin, region [3]uintptr, waitList []Event, event *Event) error
+
+	CreateSampler(context Context, normalizedCoords bool, addressingMode SamplerAddressingMode,
+		filterMode SamplerFilterMode) (Sampler, error)
+	RetainSampler(sampler Sampler) error
+	ReleaseSampler(sampler Sampler) error
+	SamplerInfo(sampler Sampler, paramName SamplerInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+
+	CreateProgramWithSource(context Context, sources []string) (Program, error)
+	CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]byte) (Program, []error, error)
+	CreateProgramWithBuiltInKernels(context Context, devices []DeviceID, kernelNames string) (Program, error)
+	RetainProgram(program Program) error
+	ReleaseProgram(program Program) error
+	BuildProgram(program Program, devices []DeviceID, options string, callback func()) error
+	CompileProgram(program Program, devices []DeviceID, options string, headers []IncludeHeader, callback func()) error
+	LinkProgram(context Context, devices []DeviceID, options string, programs []Program, callback func(Program)) (Program, error)
+	ProgramBuildInfo(program Program, device DeviceID, paramName ProgramBuildInfoName, paramSize uintptr,
+		paramValue unsafe.Pointer) (uintptr, error)
+	ProgramBuildInfoString(program Program, device DeviceID, paramName ProgramBuildInfoName) (string, error)
+	ProgramInfo(program Program, paramName ProgramInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	ProgramInfoString(program Program, paramName ProgramInfoName) (string, error)
+	ProgramBinaries(program Program) (map[DeviceID][]byte, error)
+	CreateProgramWithBinaries(context Context, binaries map[DeviceID][]byte) (Program, map[DeviceID]error, error)
+
+	CreateKernel(program Program, name string) (Kernel, error)
+	CreateKernelsInProgram(program Program) ([]Kernel, error)
+	RetainKernel(kernel Kernel) error
+	ReleaseKernel(kernel Kernel) error
+	SetKernelArg(kernel Kernel, index uint32, size uintptr, value unsafe.Pointer) error
+	KernelInfo(kernel Kernel, paramName KernelInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	KernelInfoString(kernel Kernel, paramName KernelInfoName) (string, error)
+	KernelWorkGroupInfo(kernel Kernel, device DeviceID, paramName KernelWorkGroupInfoName, paramSize uintptr,
+		paramValue unsafe.Pointer) (uintptr, error)
+	KernelArgInfo(kernel Kernel, index uint32, paramName KernelArgInfoName, paramSize uintptr,
+		paramValue unsafe.Pointer) (uintptr, error)
+	KernelArgInfoString(kernel Kernel, index uint32, paramName KernelArgInfoName) (string, error)
+	EnqueueNDRangeKernel(commandQueue CommandQueue, kernel Kernel, workDimensions []WorkDimension,
+		waitList []Event, event *Event) error
+	EnqueueTask(commandQueue CommandQueue, kernel Kernel, waitList []Event, event *Event) error
+	EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Pointer), memObjects []MemObject,
+		waitList []Event, event *Event) error
+
+	CreateUserEvent(context Context) (Event, error)
+	SetUserEventStatus(event Event, executionStatus int) error
+	WaitForEvents(events []Event) error
+	EventInfo(event Event, paramName EventInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+	RetainEvent(event Event) error
+	ReleaseEvent(event Event) error
+	EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize uintptr,
+		paramValue unsafe.Pointer) (uintptr, error)
+	SetEventCallback(event Event, callbackType EventCommandExecutionStatus, callback func(error)) error
+	EnqueueMarkerWithWaitList(commandQueue CommandQueue, waitList []Event, event *Event) error
+	EnqueueBarrierWithWaitList(commandQueue CommandQueue, waitList []Event, event *Event) error
+}
+
+// LibraryAPI implements API by calling the functions of this package, which use the loaded OpenCL library.
+type LibraryAPI struct{}
+
+var _ API = LibraryAPI{}
+
+// PlatformIDs calls PlatformIDs().
+func (LibraryAPI) PlatformIDs() ([]PlatformID, error) {
+	return PlatformIDs()
+}
+
+// PlatformInfo calls PlatformInfo().
+func (LibraryAPI) PlatformInfo(id PlatformID, paramName PlatformInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return PlatformInfo(id, paramName, paramSize, paramValue)
+}
+
+// PlatformInfoString calls PlatformInfoString().
+func (LibraryAPI) PlatformInfoString(id PlatformID, paramName PlatformInfoName) (string, error) {
+	return PlatformInfoString(id, paramName)
+}
+
+// ExtensionFunctionAddressForPlatform calls ExtensionFunctionAddressForPlatform().
+func (LibraryAPI) ExtensionFunctionAddressForPlatform(id PlatformID, functionName string) unsafe.Pointer {
+	return ExtensionFunctionAddressForPlatform(id, functionName)
+}
+
+// UnloadPlatformCompiler calls UnloadPlatformCompiler().
+func (LibraryAPI) UnloadPlatformCompiler(id PlatformID) error {
+	return UnloadPlatformCompiler(id)
+}
+
+// LoadExtensionTerminateContextKhr calls LoadExtensionTerminateContextKhr().
+// The extension is returned as ContextTerminator, so that other implementations of API can provide their own.
+func (LibraryAPI) LoadExtensionTerminateContextKhr(id PlatformID) (ContextTerminator, error) {
+	ext, err := LoadExtensionTerminateContextKhr(id)
+	if err != nil {
+		return nil, err
+	}
+	return ext, nil
+}
+
+// DeviceIDs calls DeviceIDs().
+func (LibraryAPI) DeviceIDs(platformID PlatformID, deviceType DeviceTypeFlags) ([]DeviceID, error) {
+	return DeviceIDs(platformID, deviceType)
+}
+
+// DeviceInfo calls DeviceInfo().
+func (LibraryAPI) DeviceInfo(id DeviceID, paramName DeviceInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return DeviceInfo(id, paramName, paramSize, paramValue)
+}
+
+// DeviceInfoString calls DeviceInfoString().
+func (LibraryAPI) DeviceInfoString(id DeviceID, paramName DeviceInfoName) (string, error) {
+	return DeviceInfoString(id, paramName)
+}
+
+// CreateSubDevices calls CreateSubDevices().
+func (LibraryAPI) CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]DeviceID, error) {
+	return CreateSubDevices(id, properties...)
+}
+
+// RetainDevice calls RetainDevice().
+func (LibraryAPI) RetainDevice(id DeviceID) error {
+	return RetainDevice(id)
+}
+
+// ReleaseDevice calls ReleaseDevice().
+func (LibraryAPI) ReleaseDevice(id DeviceID) error {
+	return ReleaseDevice(id)
+}
+
+// QueryDevice calls QueryDevice().
+func (LibraryAPI) QueryDevice(id DeviceID) (DeviceProperties, error) {
+	return QueryDevice(id)
+}
+
+// CreateContext calls CreateContext().
+func (LibraryAPI) CreateContext(deviceIds []DeviceID, callback *ContextErrorCallback, properties ...ContextProperty) (Context, error) {
+	return CreateContext(deviceIds, callback, properties...)
+}
+
+// CreateContextFromType calls CreateContextFromType().
+func (LibraryAPI) CreateContextFromType(deviceType DeviceTypeFlags, callback *ContextErrorCallback, properties ...ContextProperty) (Context, error) {
+	return CreateContextFromType(deviceType, callback, properties...)
+}
+
+// RetainContext calls RetainContext().
+func (LibraryAPI) RetainContext(context Context) error {
+	return RetainContext(context)
+}
+
+// ReleaseContext calls ReleaseContext().
+func (LibraryAPI) ReleaseContext(context Context) error {
+	return ReleaseContext(context)
+}
+
+// ContextInfo calls ContextInfo().
+func (LibraryAPI) ContextInfo(context Context, paramName ContextInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return ContextInfo(context, paramName, paramSize, paramValue)
+}
+
+// ContextInfoString calls ContextInfoString().
+func (LibraryAPI) ContextInfoString(context Context, paramName ContextInfoName) (string, error) {
+	return ContextInfoString(context, paramName)
+}
+
+// CreateCommandQueue calls CreateCommandQueue().
+func (LibraryAPI) CreateCommandQueue(context Context, deviceID DeviceID, properties CommandQueuePropertiesFlags) (CommandQueue, error) {
+	return CreateCommandQueue(context, deviceID, properties)
+}
+
+// RetainCommandQueue calls RetainCommandQueue().
+func (LibraryAPI) RetainCommandQueue(commandQueue CommandQueue) error {
+	return RetainCommandQueue(commandQueue)
+}
+
+// ReleaseCommandQueue calls ReleaseCommandQueue().
+func (LibraryAPI) ReleaseCommandQueue(commandQueue CommandQueue) error {
+	return ReleaseCommandQueue(commandQueue)
+}
+
+// CommandQueueInfo calls CommandQueueInfo().
+func (LibraryAPI) CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	return CommandQueueInfo(commandQueue, paramName, paramSize, paramValue)
+}
+
+// Flush calls Flush().
+func (LibraryAPI) Flush(commandQueue CommandQueue) error {
+	return Flush(commandQueue)
+}
+
+// Finish calls Finish().
+func (LibraryAPI) Finish(commandQueue CommandQueue) error {
+	return Finish(commandQueue)
+}
+
+// RetainMemObject calls RetainMemObject().
+func (LibraryAPI) RetainMemObject(mem MemObject) error {
+	return RetainMemObject(mem)
+}
+
+// ReleaseMemObject calls ReleaseMemObject().
+func (LibraryAPI) ReleaseMemObject(mem MemObject) error {
+	return ReleaseMemObject(mem)
+}
+
+// SetMemObjectDestructorCallback calls SetMemObjectDestructorCallback().
+func (LibraryAPI) SetMemObjectDestructorCallback(mem MemObject, callback func()) error {
+	return SetMemObjectDestructorCallback(mem, callback)
+}
+
+// MemObjectInfo calls MemObjectInfo().
+func (LibraryAPI) MemObjectInfo(mem MemObject, paramName MemObjectInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return MemObjectInfo(mem, paramName, paramSize, paramValue)
+}
+
+// EnqueueUnmapMemObject calls EnqueueUnmapMemObject().
+func (LibraryAPI) EnqueueUnmapMemObject(commandQueue CommandQueue, mem MemObject, mappedPtr unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueUnmapMemObject(commandQueue, mem, mappedPtr, waitList, event)
+}
+
+// EnqueueMigrateMemObjects calls EnqueueMigrateMemObjects().
+func (LibraryAPI) EnqueueMigrateMemObjects(commandQueue CommandQueue, memObjects []MemObject, migrationFlags MemMigrationFlags,
+	waitList []Event, event *Event) error {
+	return EnqueueMigrateMemObjects(commandQueue, memObjects, migrationFlags, waitList, event)
+}
+
+// CreateBuffer calls CreateBuffer().
+func (LibraryAPI) CreateBuffer(context Context, flags MemFlags, size int, hostPtr unsafe.Pointer) (MemObject, error) {
+	return CreateBuffer(context, flags, size, hostPtr)
+}
+
+// CreateSubBuffer calls CreateSubBuffer().
+func (LibraryAPI) CreateSubBuffer(buffer MemObject, flags MemFlags, createType BufferCreateType, createInfo unsafe.Pointer) (MemObject, error) {
+	return CreateSubBuffer(buffer, flags, createType, createInfo)
+}
+
+// EnqueueMapBuffer calls EnqueueMapBuffer().
+func (LibraryAPI) EnqueueMapBuffer(commandQueue CommandQueue, buffer MemObject, blocking bool, flags MapFlags,
+	offset, size uintptr, waitList []Event, event *Event) (unsafe.Pointer, error) {
+	return EnqueueMapBuffer(commandQueue, buffer, blocking, flags, offset, size, waitList, event)
+}
+
+// EnqueueReadBuffer calls EnqueueReadBuffer().
+func (LibraryAPI) EnqueueReadBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr,
+	data unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueReadBuffer(commandQueue, mem, blockingRead, offset, size, data, waitList, event)
+}
+
+// EnqueueReadBufferRect calls EnqueueReadBufferRect().
+func (LibraryAPI) EnqueueReadBufferRect(commandQueue CommandQueue, mem MemObject, blockingRead bool,
+	bufferOrigin, hostOrigin, region [3]uintptr, bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr,
+	data unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueReadBufferRect(commandQueue, mem, blockingRead, bufferOrigin, hostOrigin, region, bufferRowPitch,
+		bufferSlicePitch, hostRowPitch, hostSlicePitch, data, waitList, event)
+}
+
+// EnqueueWriteBuffer calls EnqueueWriteBuffer().
+func (LibraryAPI) EnqueueWriteBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bool, offset, size uintptr,
+	data unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueWriteBuffer(commandQueue, mem, blockingRead, offset, size, data, waitList, event)
+}
+
+// EnqueueWriteBufferRect calls EnqueueWriteBufferRect().
+func (LibraryAPI) EnqueueWriteBufferRect(commandQueue CommandQueue, mem MemObject, blockingRead bool,
+	bufferOrigin, hostOrigin, region [3]uintptr, bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr,
+	data unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueWriteBufferRect(commandQueue, mem, blockingRead, bufferOrigin, hostOrigin, region, bufferRowPitch,
+		bufferSlicePitch, hostRowPitch, hostSlicePitch, data, waitList, event)
+}
+
+// EnqueueFillBuffer calls EnqueueFillBuffer().
+func (LibraryAPI) EnqueueFillBuffer(commandQueue CommandQueue, mem MemObject, pattern unsafe.Pointer,
+	patternSize, offset, size uintptr, waitList []Event, event *Event) error {
+	return EnqueueFillBuffer(commandQueue, mem, pattern, patternSize, offset, size, waitList, event)
+}
+
+// EnqueueCopyBuffer calls EnqueueCopyBuffer().
+func (LibraryAPI) EnqueueCopyBuffer(commandQueue CommandQueue, src, dst MemObject, srcOffset, dstOffset, size uintptr,
+	waitList []Event, event *Event) error {
+	return EnqueueCopyBuffer(commandQueue, src, dst, srcOffset, dstOffset, size, waitList, event)
+}
+
+// EnqueueCopyBufferRect calls EnqueueCopyBufferRect().
+func (LibraryAPI) EnqueueCopyBufferRect(commandQueue CommandQueue, src, dst MemObject, srcOrigin, dstOrigin, region [3]uintptr,
+	srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch uintptr, waitList []Event, event *Event) error {
+	return EnqueueCopyBufferRect(commandQueue, src, dst, srcOrigin, dstOrigin, region, srcRowPitch, srcSlicePitch,
+		dstRowPitch, dstSlicePitch, waitList, event)
+}
+
+// CreateImage calls CreateImage().
+func (LibraryAPI) CreateImage(context Context, flags MemFlags, format ImageFormat, desc ImageDesc, hostPtr unsafe.Pointer) (MemObject, error) {
+	return CreateImage(context, flags, format, desc, hostPtr)
+}
+
+// SupportedImageFormats calls SupportedImageFormats().
+func (LibraryAPI) SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectType) ([]ImageFormat, error) {
+	return SupportedImageFormats(context, flags, imageType)
+}
+
+// EnqueueMapImage calls EnqueueMapImage().
+func (LibraryAPI) EnqueueMapImage(commandQueue CommandQueue, image MemObject, blocking bool, flags MapFlags,
+	origin, region [3]uintptr, waitList []Event, event *Event) (MappedImage, error) {
+	return EnqueueMapImage(commandQueue, image, blocking, flags, origin, region, waitList, event)
+}
+
+// ImageInfo calls ImageInfo().
+func (LibraryAPI) ImageInfo(image MemObject, paramName ImageInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return ImageInfo(image, paramName, paramSize, paramValue)
+}
+
+// EnqueueReadImage calls EnqueueReadImage().
+func (LibraryAPI) EnqueueReadImage(commandQueue CommandQueue, image MemObject, blocking bool, origin, region [3]uintptr,
+	rowPitch, slicePitch uintptr, ptr unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueReadImage(commandQueue, image, blocking, origin, region, rowPitch, slicePitch, ptr, waitList, event)
+}
+
+// EnqueueWriteImage calls EnqueueWriteImage().
+func (LibraryAPI) EnqueueWriteImage(commandQueue CommandQueue, image MemObject, blocking bool, origin, region [3]uintptr,
+	rowPitch, slicePitch uintptr, ptr unsafe.Pointer, waitList []Event, event *Event) error {
+	return EnqueueWriteImage(commandQueue, image, blocking, origin, region, rowPitch, slicePitch, ptr, waitList, event)
+}
+
+// EnqueueFillImage calls EnqueueFillImage().
+func (LibraryAPI) EnqueueFillImage(commandQueue CommandQueue, image MemObject, fillColor unsafe.Pointer,
+	origin, region [3]uintptr, waitList []Event, event *Event) error {
+	return EnqueueFillImage(commandQueue, image, fillColor, origin, region, waitList, event)
+}
+
+// EnqueueCopyImage calls EnqueueCopyImage().
+func (LibraryAPI) EnqueueCopyImage(commandQueue CommandQueue, srcImage, dstImage MemObject,
+	srcOrigin, dstOrigin, region [3]uintptr, waitList []Event, event *Event) error {
+	return EnqueueCopyImage(commandQueue, srcImage, dstImage, srcOrigin, dstOrigin, region, waitList, event)
+}
+
+// EnqueueCopyImageToBuffer calls EnqueueCopyImageToBuffer().
+func (LibraryAPI) EnqueueCopyImageToBuffer(commandQueue CommandQueue, srcImage, dstBuffer MemObject,
+	srcOrigin, region [3]uintptr, dstOffset uintptr, waitList []Event, event *Event) error {
+	return EnqueueCopyImageToBuffer(commandQueue, srcImage, dstBuffer, srcOrigin, region, dstOffset, waitList, event)
+}
+
+// EnqueueCopyBufferToImage calls EnqueueCopyBufferToImage().
+func (LibraryAPI) EnqueueCopyBufferToImage(commandQueue CommandQueue, srcBuffer, dstImage MemObject, srcOffset uintptr,
+	srcOrigin, region [3]uintptr, waitList []Event, event *Event) error {
+	return EnqueueCopyBufferToImage(commandQueue, srcBuffer, dstImage, srcOffset, srcOrigin, region, waitList, event)
+}
+
+// CreateSampler calls CreateSampler().
+func (LibraryAPI) CreateSampler(context Context, normalizedCoords bool, addressingMode SamplerAddressingMode,
+	filterMode SamplerFilterMode) (Sampler, error) {
+	return CreateSampler(context, normalizedCoords, addressingMode, filterMode)
+}
+
+// RetainSampler calls RetainSampler().
+func (LibraryAPI) RetainSampler(sampler Sampler) error {
+	return RetainSampler(sampler)
+}
+
+// ReleaseSampler calls ReleaseSampler().
+func (LibraryAPI) ReleaseSampler(sampler Sampler) error {
+	return ReleaseSampler(sampler)
+}
+
+// SamplerInfo calls SamplerInfo().
+func (LibraryAPI) SamplerInfo(sampler Sampler, paramName SamplerInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return SamplerInfo(sampler, paramName, paramSize, paramValue)
+}
+
+// CreateProgramWithSource calls CreateProgramWithSource().
+func (LibraryAPI) CreateProgramWithSource(context Context, sources []string) (Program, error) {
+	return CreateProgramWithSource(context, sources)
+}
+
+// CreateProgramWithBinary calls CreateProgramWithBinary().
+func (LibraryAPI) CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]byte) (Program, []error, error) {
+	return CreateProgramWithBinary(context, devices, binaries)
+}
+
+// CreateProgramWithBuiltInKernels calls CreateProgramWithBuiltInKernels().
+func (LibraryAPI) CreateProgramWithBuiltInKernels(context Context, devices []DeviceID, kernelNames string) (Program, error) {
+	return CreateProgramWithBuiltInKernels(context, devices, kernelNames)
+}
+
+// RetainProgram calls RetainProgram().
+func (LibraryAPI) RetainProgram(program Program) error {
+	return RetainProgram(program)
+}
+
+// ReleaseProgram calls ReleaseProgram().
+func (LibraryAPI) ReleaseProgram(program Program) error {
+	return ReleaseProgram(program)
+}
+
+// BuildProgram calls BuildProgram().
+func (LibraryAPI) BuildProgram(program Program, devices []DeviceID, options string, callback func()) error {
+	return BuildProgram(program, devices, options, callback)
+}
+
+// CompileProgram calls CompileProgram().
+func (LibraryAPI) CompileProgram(program Program, devices []DeviceID, options string, headers []IncludeHeader, callback func()) error {
+	return CompileProgram(program, devices, options, headers, callback)
+}
+
+// LinkProgram calls LinkProgram().
+func (LibraryAPI) LinkProgram(context Context, devices []DeviceID, options string, programs []Program, callback func(Program)) (Program, error) {
+	return LinkProgram(context, devices, options, programs, callback)
+}
+
+// ProgramBuildInfo calls ProgramBuildInfo().
+func (LibraryAPI) ProgramBuildInfo(program Program, device DeviceID, paramName ProgramBuildInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	return ProgramBuildInfo(program, device, paramName, paramSize, paramValue)
+}
+
+// ProgramBuildInfoString calls ProgramBuildInfoString().
+func (LibraryAPI) ProgramBuildInfoString(program Program, device DeviceID, paramName ProgramBuildInfoName) (string, error) {
+	return ProgramBuildInfoString(program, device, paramName)
+}
+
+// ProgramInfo calls ProgramInfo().
+func (LibraryAPI) ProgramInfo(program Program, paramName ProgramInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return ProgramInfo(program, paramName, paramSize, paramValue)
+}
+
+// ProgramInfoString calls ProgramInfoString().
+func (LibraryAPI) ProgramInfoString(program Program, paramName ProgramInfoName) (string, error) {
+	return ProgramInfoString(program, paramName)
+}
+
+// ProgramBinaries calls ProgramBinaries().
+func (LibraryAPI) ProgramBinaries(program Program) (map[DeviceID][]byte, error) {
+	return ProgramBinaries(program)
+}
+
+// CreateProgramWithBinaries calls CreateProgramWithBinaries().
+func (LibraryAPI) CreateProgramWithBinaries(context Context, binaries map[DeviceID][]byte) (Program, map[DeviceID]error, error) {
+	return CreateProgramWithBinaries(context, binaries)
+}
+
+// CreateKernel calls CreateKernel().
+func (LibraryAPI) CreateKernel(program Program, name string) (Kernel, error) {
+	return CreateKernel(program, name)
+}
+
+// CreateKernelsInProgram calls CreateKernelsInProgram().
+func (LibraryAPI) CreateKernelsInProgram(program Program) ([]Kernel, error) {
+	return CreateKernelsInProgram(program)
+}
+
+// RetainKernel calls RetainKernel().
+func (LibraryAPI) RetainKernel(kernel Kernel) error {
+	return RetainKernel(kernel)
+}
+
+// ReleaseKernel calls ReleaseKernel().
+func (LibraryAPI) ReleaseKernel(kernel Kernel) error {
+	return ReleaseKernel(kernel)
+}
+
+// SetKernelArg calls SetKernelArg().
+func (LibraryAPI) SetKernelArg(kernel Kernel, index uint32, size uintptr, value unsafe.Pointer) error {
+	return SetKernelArg(kernel, index, size, value)
+}
+
+// KernelInfo calls KernelInfo().
+func (LibraryAPI) KernelInfo(kernel Kernel, paramName KernelInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return KernelInfo(kernel, paramName, paramSize, paramValue)
+}
+
+// KernelInfoString calls KernelInfoString().
+func (LibraryAPI) KernelInfoString(kernel Kernel, paramName KernelInfoName) (string, error) {
+	return KernelInfoString(kernel, paramName)
+}
+
+// KernelWorkGroupInfo calls KernelWorkGroupInfo().
+func (LibraryAPI) KernelWorkGroupInfo(kernel Kernel, device DeviceID, paramName KernelWorkGroupInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	return KernelWorkGroupInfo(kernel, device, paramName, paramSize, paramValue)
+}
+
+// KernelArgInfo calls KernelArgInfo().
+func (LibraryAPI) KernelArgInfo(kernel Kernel, index uint32, paramName KernelArgInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	return KernelArgInfo(kernel, index, paramName, paramSize, paramValue)
+}
+
+// KernelArgInfoString calls KernelArgInfoString().
+func (LibraryAPI) KernelArgInfoString(kernel Kernel, index uint32, paramName KernelArgInfoName) (string, error) {
+	return KernelArgInfoString(kernel, index, paramName)
+}
+
+// EnqueueNDRangeKernel calls EnqueueNDRangeKernel().
+func (LibraryAPI) EnqueueNDRangeKernel(commandQueue CommandQueue, kernel Kernel, workDimensions []WorkDimension,
+	waitList []Event, event *Event) error {
+	return EnqueueNDRangeKernel(commandQueue, kernel, workDimensions, waitList, event)
+}
+
+// EnqueueTask calls EnqueueTask().
+func (LibraryAPI) EnqueueTask(commandQueue CommandQueue, kernel Kernel, waitList []Event, event *Event) error {
+	return EnqueueTask(commandQueue, kernel, waitList, event)
+}
+
+// EnqueueNativeKernel calls EnqueueNativeKernel().
+func (LibraryAPI) EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Pointer), memObjects []MemObject,
+	waitList []Event, event *Event) error {
+	return EnqueueNativeKernel(commandQueue, callback, memObjects, waitList, event)
+}
+
+// CreateUserEvent calls CreateUserEvent().
+func (LibraryAPI) CreateUserEvent(context Context) (Event, error) {
+	return CreateUserEvent(context)
+}
+
+// SetUserEventStatus calls SetUserEventStatus().
+func (LibraryAPI) SetUserEventStatus(event Event, executionStatus int) error {
+	return SetUserEventStatus(event, executionStatus)
+}
+
+// WaitForEvents calls WaitForEvents().
+func (LibraryAPI) WaitForEvents(events []Event) error {
+	return WaitForEvents(events)
+}
+
+// EventInfo calls EventInfo().
+func (LibraryAPI) EventInfo(event Event, paramName EventInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return EventInfo(event, paramName, paramSize, paramValue)
+}
+
+// RetainEvent calls RetainEvent().
+func (LibraryAPI) RetainEvent(event Event) error {
+	return RetainEvent(event)
+}
+
+// ReleaseEvent calls ReleaseEvent().
+func (LibraryAPI) ReleaseEvent(event Event) error {
+	return ReleaseEvent(event)
+}
+
+// EventProfilingInfo calls EventProfilingInfo().
+func (LibraryAPI) EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	return EventProfilingInfo(event, paramName, paramSize, paramValue)
+}
+
+// SetEventCallback calls SetEventCallback().
+func (LibraryAPI) SetEventCallback(event Event, callbackType EventCommandExecutionStatus, callback func(error)) error {
+	return SetEventCallback(event, callbackType, callback)
+}
+
+// EnqueueMarkerWithWaitList calls EnqueueMarkerWithWaitList().
+func (LibraryAPI) EnqueueMarkerWithWaitList(commandQueue CommandQueue, waitList []Event, event *Event) error {
+	return EnqueueMarkerWithWaitList(commandQueue, waitList, event)
+}
+
+// EnqueueBarrierWithWaitList calls EnqueueBarrierWithWaitList().
+func (LibraryAPI) EnqueueBarrierWithWaitList(commandQueue CommandQueue, waitList []Event, event *Event) error {
+	return EnqueueBarrierWithWaitList(commandQueue, waitList, event)
+}
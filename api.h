@@ -8,3 +8,5 @@
 #include <CL/cl.h>
 #include <CL/cl_ext.h>
 #endif
+
+#include "loader.h"
@@ -0,0 +1,24 @@
+package cl12_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestLibraryAPI(t *testing.T) {
+	t.Parallel()
+	requireStub(t)
+	var api cl.API = cl.LibraryAPI{}
+	platforms, err := api.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs() failed: %v", err)
+	}
+	name, err := api.PlatformInfoString(platforms[0], cl.PlatformNameInfo)
+	if err != nil {
+		t.Fatalf("PlatformInfoString() failed: %v", err)
+	}
+	if name != "cl12 stub platform" {
+		t.Errorf("unexpected platform name: %q", name)
+	}
+}
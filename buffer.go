@@ -18,9 +18,11 @@ func CreateBuffer(context Context, flags MemFlags, size int, hostPtr unsafe.Poin
 		hostPtr,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateBuffer", StatusError(status), context)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	created := MemObject(*((*uintptr)(unsafe.Pointer(&mem))))
+	trackCreated("clCreateBuffer", created)
+	return created, nil
 }
 
 // BufferCreateType determines the kind of sub-buffer object.
@@ -60,9 +62,11 @@ func CreateSubBuffer(buffer MemObject, flags MemFlags, createType BufferCreateTy
 		createInfo,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateSubBuffer", StatusError(status), buffer)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	created := MemObject(*((*uintptr)(unsafe.Pointer(&mem))))
+	trackCreated("clCreateSubBuffer", created)
+	return created, nil
 }
 
 // EnqueueMapBuffer enqueues a command to map a region of a buffer object into the host address space and
@@ -89,8 +93,9 @@ func EnqueueMapBuffer(commandQueue CommandQueue,
 		(*C.cl_event)(unsafe.Pointer(event)),
 		&status)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clEnqueueMapBuffer", StatusError(status), commandQueue, buffer)
 	}
+	trackEnqueuedEvent("clEnqueueMapBuffer", event)
 	return ptr, nil
 }
 
@@ -114,8 +119,9 @@ func EnqueueReadBuffer(commandQueue CommandQueue, mem MemObject, blockingRead bo
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueReadBuffer", StatusError(status), commandQueue, mem)
 	}
+	trackEnqueuedEvent("clEnqueueReadBuffer", event)
 	return nil
 }
 
@@ -146,8 +152,9 @@ func EnqueueReadBufferRect(commandQueue CommandQueue, mem MemObject, blockingRea
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueReadBufferRect", StatusError(status), commandQueue, mem)
 	}
+	trackEnqueuedEvent("clEnqueueReadBufferRect", event)
 	return nil
 }
 
@@ -171,8 +178,9 @@ func EnqueueWriteBuffer(commandQueue CommandQueue, mem MemObject, blockingRead b
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueWriteBuffer", StatusError(status), commandQueue, mem)
 	}
+	trackEnqueuedEvent("clEnqueueWriteBuffer", event)
 	return nil
 }
 
@@ -203,8 +211,9 @@ func EnqueueWriteBufferRect(commandQueue CommandQueue, mem MemObject, blockingRe
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueWriteBufferRect", StatusError(status), commandQueue, mem)
 	}
+	trackEnqueuedEvent("clEnqueueWriteBufferRect", event)
 	return nil
 }
 
@@ -229,8 +238,9 @@ func EnqueueFillBuffer(commandQueue CommandQueue, mem MemObject, pattern unsafe.
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueFillBuffer", StatusError(status), commandQueue, mem)
 	}
+	trackEnqueuedEvent("clEnqueueFillBuffer", event)
 	return nil
 }
 
@@ -253,8 +263,9 @@ func EnqueueCopyBuffer(commandQueue CommandQueue, src, dst MemObject, srcOffset,
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueCopyBuffer", StatusError(status), commandQueue, src, dst)
 	}
+	trackEnqueuedEvent("clEnqueueCopyBuffer", event)
 	return nil
 }
 
@@ -284,7 +295,8 @@ func EnqueueCopyBufferRect(commandQueue CommandQueue, src, dst MemObject, srcOri
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueCopyBufferRect", StatusError(status), commandQueue, src, dst)
 	}
+	trackEnqueuedEvent("clEnqueueCopyBufferRect", event)
 	return nil
 }
@@ -0,0 +1,170 @@
+package cl12
+
+import (
+	"context"
+	"errors"
+)
+
+// DeviceBuildResult is the build status and log of a program for one device.
+type DeviceBuildResult struct {
+	Device DeviceID
+	Status BuildStatus
+	Log    string
+}
+
+// BuildResult is the outcome of BuildProgramAsync(), CompileProgramAsync(), and LinkProgramAsync().
+type BuildResult struct {
+	// Program is the program that was built. For LinkProgramAsync(), it is the linked program, which the caller must
+	// release; it is zero if the link failed, or if the context was done first, as LinkProgram() returns no program
+	// together with an error.
+	Program Program
+	// Devices has the status and the log of each device, in the order of the devices of the call, or of the
+	// devices of the program if the call was for all devices. It is empty if Err is the error of the context, or if
+	// the operation could not begin.
+	Devices []DeviceBuildResult
+	// Err is nil if the operation succeeded for all devices. If it failed for any device, Err is
+	// a *ProgramBuildError, see CollectBuildErrors(). If the context was done before the operation completed,
+	// Err is the error of the context. Otherwise, it is the error of the call.
+	Err error
+}
+
+// BuildProgramAsync starts BuildProgram() with a callback and returns a channel that receives the result once
+// the build completes, or once ctx is done. The channel receives exactly one value.
+//
+// The build itself can not be canceled. If ctx is done first, the program stays referenced until the build
+// completes, so that the caller may release it right away.
+func BuildProgramAsync(ctx context.Context, program Program, devices []DeviceID, options string) <-chan BuildResult {
+	return startBuildAsync(ctx, program, devices, func(callback func(Program)) (Program, error) {
+		return program, BuildProgram(program, devices, options, func() { callback(program) })
+	}, "clBuildProgram", ErrBuildProgramFailure)
+}
+
+// CompileProgramAsync starts CompileProgram() with a callback and returns a channel that receives the result once
+// the compilation completes, or once ctx is done. See BuildProgramAsync() for details.
+//
+// Since: 1.2
+func CompileProgramAsync(ctx context.Context, program Program, devices []DeviceID, options string, headers []IncludeHeader) <-chan BuildResult {
+	return startBuildAsync(ctx, program, devices, func(callback func(Program)) (Program, error) {
+		return program, CompileProgram(program, devices, options, headers, func() { callback(program) })
+	}, "clCompileProgram", ErrCompileProgramFailure)
+}
+
+// LinkProgramAsync starts LinkProgram() with a callback and returns a channel that receives the result once
+// the link completes, or once ctx is done. See BuildProgramAsync() for details.
+// If ctx is done first, the linked program is released once the link completes.
+//
+// Since: 1.2
+func LinkProgramAsync(ctx context.Context, context Context, devices []DeviceID, options string, programs []Program) <-chan BuildResult {
+	return startBuildAsync(ctx, 0, devices, func(callback func(Program)) (Program, error) {
+		return LinkProgram(context, devices, options, programs, callback)
+	}, "clLinkProgram", ErrLinkProgramFailure)
+}
+
+// BuildProgramContext calls BuildProgramAsync() and waits for the result.
+func BuildProgramContext(ctx context.Context, program Program, devices []DeviceID, options string) (BuildResult, error) {
+	result := <-BuildProgramAsync(ctx, program, devices, options)
+	return result, result.Err
+}
+
+// startBuildAsync runs the start function in a goroutine and delivers the result of the first of the callback
+// and ctx. The program of an existing object is retained until the callback was called.
+func startBuildAsync(ctx context.Context, program Program, devices []DeviceID, start func(callback func(Program)) (Program, error),
+	function string, failure StatusError) <-chan BuildResult {
+	results := make(chan BuildResult, 1)
+	if program != 0 {
+		if err := RetainProgram(program); err != nil {
+			results <- BuildResult{Program: program, Err: err}
+			return results
+		}
+	}
+	release := func() {
+		if program != 0 {
+			_ = ReleaseProgram(program)
+		}
+	}
+	go func() {
+		completed := make(chan Program, 1)
+		started, err := start(func(built Program) { completed <- built })
+		if (err != nil) && !errors.Is(err, failure) {
+			// The operation did not begin, so the callback is not called.
+			release()
+			results <- buildResult(started, devices, err)
+			return
+		}
+		var built Program
+		select {
+		case built = <-completed:
+		case <-ctx.Done():
+			select {
+			case built = <-completed:
+				// The operation completed as well; its result is more useful than the error of the context.
+			default:
+				results <- BuildResult{Program: program, Err: ctx.Err()}
+				if built = <-completed; (program == 0) && (built != 0) {
+					_ = ReleaseProgram(built)
+				}
+				release()
+				return
+			}
+		}
+		cause := err
+		if cause == nil {
+			cause = newOpError(function, failure, built)
+		}
+		result := buildResultAfterCallback(built, devices, cause)
+		if (result.Err == nil) && (err != nil) {
+			result.Err = CollectBuildErrors(built, devices, err)
+		}
+		if (program == 0) && (built != 0) && (result.Err != nil) {
+			// The failed link passed its program to the callback; it is released like LinkProgram() does.
+			_ = ReleaseProgram(built)
+			result.Program = 0
+		}
+		release()
+		results <- result
+	}()
+	return results
+}
+
+// buildResultAfterCallback determines the result of an operation that completed with a callback, which does not
+// carry an error. The operation failed if any device has BuildErrorStatus.
+func buildResultAfterCallback(program Program, devices []DeviceID, failure error) BuildResult {
+	result := buildResult(program, devices, nil)
+	if result.Err != nil {
+		return result
+	}
+	for _, device := range result.Devices {
+		if device.Status == BuildErrorStatus {
+			result.Err = CollectBuildErrors(program, devices, failure)
+			break
+		}
+	}
+	return result
+}
+
+// buildResult queries the status and the log of each device of the program.
+func buildResult(program Program, devices []DeviceID, err error) BuildResult {
+	result := BuildResult{Program: program, Err: CollectBuildErrors(program, devices, err)}
+	if program == 0 {
+		return result
+	}
+	if len(devices) == 0 {
+		var queryErr error
+		devices, queryErr = InfoSlice[DeviceID](ProgramInfoQuery(program, ProgramDevicesInfo))
+		if (queryErr != nil) && (result.Err == nil) {
+			result.Err = queryErr
+		}
+	}
+	for _, device := range devices {
+		status, queryErr := InfoValue[BuildStatus](ProgramBuildInfoQuery(program, device, ProgramBuildStatusInfo))
+		if queryErr == nil {
+			var log string
+			log, queryErr = ProgramBuildInfoString(program, device, ProgramBuildLogInfo)
+			result.Devices = append(result.Devices, DeviceBuildResult{Device: device, Status: status, Log: log})
+		}
+		if (queryErr != nil) && (result.Err == nil) {
+			result.Err = queryErr
+		}
+	}
+	return result
+}
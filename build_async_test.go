@@ -0,0 +1,116 @@
+package cl12_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestBuildProgramAsync(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(clContext, []string{`
+// stub:build-log warning: unused variable 'x'
+__kernel void add(__global float *a) {}
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result := <-cl.BuildProgramAsync(ctx, program, nil, "-Werror")
+	if result.Err != nil {
+		t.Fatalf("BuildProgramAsync() failed: %v", result.Err)
+	}
+	if (result.Program != program) || (len(result.Devices) != 1) {
+		t.Fatalf("unexpected result: %#v", result)
+	}
+	deviceResult := result.Devices[0]
+	if (deviceResult.Device != device) || (deviceResult.Status != cl.BuildSuccessStatus) ||
+		!strings.Contains(deviceResult.Log, "unused variable 'x'") {
+		t.Errorf("unexpected device result: %#v", deviceResult)
+	}
+	count, err := cl.InfoValue[uint32](cl.ProgramInfoQuery(program, cl.ProgramReferenceCountInfo))
+	if err != nil {
+		t.Fatalf("InfoValue() failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("unexpected reference count after the build: %d", count)
+	}
+}
+
+func TestBuildProgramAsyncFailure(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(clContext, []string{`
+// stub:build-fail
+// stub:build-log <kernel>:4:1: error: expected ';'
+__kernel void broken() {}
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	result, err := cl.BuildProgramContext(context.Background(), program, []cl.DeviceID{device}, "")
+	var buildErr *cl.BuildError
+	if !errors.Is(err, cl.ErrBuildProgramFailure) || !errors.As(err, &buildErr) {
+		t.Fatalf("expected a build error, got: %v", err)
+	}
+	if (len(result.Devices) != 1) || (result.Devices[0].Status != cl.BuildErrorStatus) ||
+		!strings.Contains(result.Devices[0].Log, "expected ';'") {
+		t.Errorf("unexpected result: %#v", result)
+	}
+	_, err = cl.BuildProgramContext(context.Background(), program, nil, "-invalid-option")
+	if !errors.Is(err, cl.ErrInvalidBuildOptions) {
+		t.Errorf("expected ErrInvalidBuildOptions, got: %v", err)
+	}
+}
+
+func TestCompileAndLinkProgramAsync(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	program, err := cl.CreateProgramWithSource(clContext, []string{`__kernel void run() {}`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	ctx := context.Background()
+	if result := <-cl.CompileProgramAsync(ctx, program, nil, "", nil); result.Err != nil {
+		t.Fatalf("CompileProgramAsync() failed: %v", result.Err)
+	}
+	result := <-cl.LinkProgramAsync(ctx, clContext, nil, "", []cl.Program{program})
+	if result.Err != nil {
+		t.Fatalf("LinkProgramAsync() failed: %v", result.Err)
+	}
+	if (result.Program == 0) || (result.Program == program) || (result.Devices[0].Status != cl.BuildSuccessStatus) {
+		t.Errorf("unexpected result: %#v", result)
+	}
+	_ = cl.ReleaseProgram(result.Program)
+}
+
+func TestLinkProgramAsyncFailure(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	program, err := cl.CreateProgramWithSource(clContext, []string{"// stub:link-fail\n// stub:notify-thread\n__kernel void run() {}\n"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	ctx := context.Background()
+	if result := <-cl.CompileProgramAsync(ctx, program, nil, "", nil); result.Err != nil {
+		t.Fatalf("CompileProgramAsync() failed: %v", result.Err)
+	}
+	result := <-cl.LinkProgramAsync(ctx, clContext, nil, "", []cl.Program{program})
+	var buildErr *cl.BuildError
+	if !errors.Is(result.Err, cl.ErrLinkProgramFailure) || !errors.As(result.Err, &buildErr) {
+		t.Fatalf("expected a build error, got: %v", result.Err)
+	}
+	if (result.Program != 0) || (len(result.Devices) != 1) || (result.Devices[0].Status != cl.BuildErrorStatus) {
+		t.Errorf("unexpected result: %#v", result)
+	}
+}
@@ -0,0 +1,195 @@
+package cl12
+
+import (
+	"fmt"
+	"strings"
+)
+
+// String returns the name of the status, such as "CL_BUILD_ERROR".
+func (status BuildStatus) String() string {
+	switch status {
+	case BuildNoneStatus:
+		return "CL_BUILD_NONE"
+	case BuildSuccessStatus:
+		return "CL_BUILD_SUCCESS"
+	case BuildErrorStatus:
+		return "CL_BUILD_ERROR"
+	case BuildInProgressStatus:
+		return "CL_BUILD_IN_PROGRESS"
+	default:
+		return fmt.Sprintf("BuildStatus(%d)", int(status))
+	}
+}
+
+// BuildError describes the result of a failed build, compile, or link of a program for one device.
+// It bundles the information that the program provides for the device, so that the failure can be reported
+// after the program has been released.
+type BuildError struct {
+	// Device is the device the program was built for.
+	Device DeviceID
+	// Status is the build status of the program for the device.
+	Status BuildStatus
+	// Options are the options of the last build, compile, or link.
+	Options string
+	// Log is the unprocessed build log.
+	Log string
+	// Diagnostics are the messages that ParseBuildLog() extracted from the log.
+	Diagnostics []Diagnostic
+	// Err is the error of the failed call, such as an *OpError that wraps ErrBuildProgramFailure.
+	// It may be nil.
+	Err error
+}
+
+// NewBuildError queries the build status, options, and log of the program for the device, and returns them
+// together with the error of the failed call, such as the one of BuildProgram().
+// The returned error is set if any of the queries fails.
+func NewBuildError(program Program, device DeviceID, cause error) (*BuildError, error) {
+	status, err := InfoValue[BuildStatus](ProgramBuildInfoQuery(program, device, ProgramBuildStatusInfo))
+	if err != nil {
+		return nil, err
+	}
+	options, err := ProgramBuildInfoString(program, device, ProgramBuildOptionsInfo)
+	if err != nil {
+		return nil, err
+	}
+	log, err := ProgramBuildInfoString(program, device, ProgramBuildLogInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &BuildError{
+		Device:      device,
+		Status:      status,
+		Options:     options,
+		Log:         log,
+		Diagnostics: ParseBuildLog(log),
+		Err:         cause,
+	}, nil
+}
+
+// Errors returns the diagnostics with SeverityError.
+func (err *BuildError) Errors() []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, diag := range err.Diagnostics {
+		if diag.Severity == SeverityError {
+			diagnostics = append(diagnostics, diag)
+		}
+	}
+	return diagnostics
+}
+
+// Error returns the device, the status, and the first error of the log, such as
+// "build for device 0x7F3A10 (options \"-Werror\"): CL_BUILD_ERROR: <kernel>:3:5: error: use of undeclared identifier 'x'
+// (and 2 more errors)".
+// If the log has no errors, the text of the underlying error is used instead.
+func (err *BuildError) Error() string {
+	text := err.summary()
+	if (len(err.Errors()) == 0) && (err.Err != nil) {
+		text += ": " + err.Err.Error()
+	}
+	return text
+}
+
+// summary returns the text of Error() without the underlying error.
+func (err *BuildError) summary() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "build for device %v", err.Device)
+	if err.Options != "" {
+		fmt.Fprintf(&text, " (options %q)", err.Options)
+	}
+	text.WriteString(": " + err.Status.String())
+	if diagnostics := err.Errors(); len(diagnostics) > 0 {
+		text.WriteString(": " + diagnostics[0].String())
+		if len(diagnostics) > 1 {
+			fmt.Fprintf(&text, " (and %d more errors)", len(diagnostics)-1)
+		}
+	}
+	return text.String()
+}
+
+// Unwrap returns the underlying error.
+func (err *BuildError) Unwrap() error {
+	return err.Err
+}
+
+// ProgramBuildError describes a failed build, compile, or link of a program for one or more devices.
+// It is returned by CollectBuildErrors().
+//
+// errors.As() can retrieve the *BuildError of the first failing device from it, and errors.Is() tests the underlying
+// error, such as ErrBuildProgramFailure.
+type ProgramBuildError struct {
+	// Program is the program that failed to build. Functions that create the program release it if they fail,
+	// see LinkProgram(); the handle is then no longer valid.
+	Program Program
+	// Failures has one entry for each device with BuildErrorStatus, in the order of the devices.
+	Failures []*BuildError
+	// Err is the error of the failed call.
+	Err error
+}
+
+// CollectBuildErrors returns a *ProgramBuildError for the devices of the program that have BuildErrorStatus,
+// if err is the error of a failed BuildProgram() or CompileProgram() for the program.
+// Functions that create the program, such as LinkProgram(), apply the function themselves, as they release the
+// program if they fail.
+// If devices is empty, all devices of the program are checked.
+//
+// The function returns nil if err is nil. It returns err unchanged if no device has BuildErrorStatus, or if the
+// information of the program can not be queried, such as for errors that occur before the build begins.
+//
+// Typical use is to wrap the call:
+//
+//	err := cl.CollectBuildErrors(program, devices, cl.BuildProgram(program, devices, options, nil))
+func CollectBuildErrors(program Program, devices []DeviceID, err error) error {
+	if (err == nil) || (program == 0) {
+		return err
+	}
+	if len(devices) == 0 {
+		var queryErr error
+		devices, queryErr = InfoSlice[DeviceID](ProgramInfoQuery(program, ProgramDevicesInfo))
+		if queryErr != nil {
+			return err
+		}
+	}
+	var failures []*BuildError
+	for _, device := range devices {
+		failure, queryErr := NewBuildError(program, device, err)
+		if queryErr != nil {
+			return err
+		}
+		if failure.Status == BuildErrorStatus {
+			failures = append(failures, failure)
+		}
+	}
+	if len(failures) == 0 {
+		return err
+	}
+	return &ProgramBuildError{Program: program, Failures: failures, Err: err}
+}
+
+// Error returns the underlying error, followed by the summary of each failing device, separated by semicolons.
+func (err *ProgramBuildError) Error() string {
+	var text strings.Builder
+	if err.Err != nil {
+		text.WriteString(err.Err.Error())
+	} else {
+		fmt.Fprintf(&text, "build of program %v failed", err.Program)
+	}
+	for _, failure := range err.Failures {
+		text.WriteString("; " + failure.summary())
+	}
+	return text.String()
+}
+
+// Unwrap returns the underlying error.
+func (err *ProgramBuildError) Unwrap() error {
+	return err.Err
+}
+
+// As sets target to the first failure if target is of type **BuildError.
+func (err *ProgramBuildError) As(target any) bool {
+	failure, isBuildError := target.(**BuildError)
+	if !isBuildError || (len(err.Failures) == 0) {
+		return false
+	}
+	*failure = err.Failures[0]
+	return true
+}
@@ -0,0 +1,156 @@
+package cl12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestNewBuildError(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{`
+// stub:build-fail
+// stub:build-log <kernel>:4:26: error: use of undeclared identifier 'y'
+// stub:build-log <kernel>:4:5: warning: expression result unused
+__kernel void broken() { y; }
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	buildErr := cl.BuildProgram(program, []cl.DeviceID{device}, "-DN=4", nil)
+	if buildErr == nil {
+		t.Fatalf("BuildProgram() succeeded")
+	}
+	result, err := cl.NewBuildError(program, device, buildErr)
+	if err != nil {
+		t.Fatalf("NewBuildError() failed: %v", err)
+	}
+	if (result.Device != device) || (result.Status != cl.BuildErrorStatus) || (result.Options != "-DN=4") {
+		t.Errorf("unexpected build error: %#v", result)
+	}
+	if (len(result.Diagnostics) != 2) || (len(result.Errors()) != 1) {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+	if !errors.Is(result, cl.ErrBuildProgramFailure) {
+		t.Errorf("build error does not wrap ErrBuildProgramFailure")
+	}
+	if text := result.Error(); !strings.Contains(text, "CL_BUILD_ERROR: <kernel>:4:26: error: use of undeclared identifier 'y'") ||
+		!strings.Contains(text, `"-DN=4"`) {
+		t.Errorf("unexpected error text: %q", text)
+	}
+}
+
+func TestCollectBuildErrors(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{`
+// stub:build-fail
+// stub:build-log <kernel>:2:1: error: unknown type name 'vec3'
+__kernel void broken(vec3 v) {}
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.CollectBuildErrors(program, nil, cl.BuildProgram(program, nil, "-Werror", nil))
+	var programErr *cl.ProgramBuildError
+	if !errors.As(err, &programErr) {
+		t.Fatalf("expected ProgramBuildError, got: %v", err)
+	}
+	if (programErr.Program != program) || (len(programErr.Failures) != 1) {
+		t.Fatalf("unexpected program build error: %#v", programErr)
+	}
+	var buildErr *cl.BuildError
+	if !errors.As(err, &buildErr) || (buildErr != programErr.Failures[0]) {
+		t.Fatalf("errors.As() did not return the failure of the device")
+	}
+	if (buildErr.Device != device) || (buildErr.Status != cl.BuildErrorStatus) || (buildErr.Options != "-Werror") ||
+		!strings.Contains(buildErr.Log, "unknown type name 'vec3'") {
+		t.Errorf("unexpected failure: %#v", buildErr)
+	}
+	if !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Errorf("error does not wrap ErrBuildProgramFailure")
+	}
+	if text := err.Error(); !strings.HasPrefix(text, "clBuildProgram(") ||
+		!strings.Contains(text, "; build for device "+device.String()+` (options "-Werror"): CL_BUILD_ERROR: <kernel>:2:1: error:`) {
+		t.Errorf("unexpected error text: %q", text)
+	}
+}
+
+func TestCollectBuildErrorsPassesOtherErrors(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{`__kernel void fine() {}`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.CollectBuildErrors(program, nil, nil); err != nil {
+		t.Errorf("expected nil, got: %v", err)
+	}
+	cause := cl.BuildProgram(program, nil, "-invalid-option", nil)
+	err = cl.CollectBuildErrors(program, nil, cause)
+	var programErr *cl.ProgramBuildError
+	if errors.As(err, &programErr) || !errors.Is(err, cl.ErrInvalidBuildOptions) {
+		t.Errorf("expected the cause unchanged, got: %v", err)
+	}
+}
+
+func TestCollectBuildErrorsOfLink(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	compiled, err := cl.CreateProgramWithSource(context, []string{`
+// stub:link-fail
+__kernel void main_kernel() {}
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(compiled) }()
+	if err = cl.CompileProgram(compiled, nil, "", nil, nil); err != nil {
+		t.Fatalf("CompileProgram() failed: %v", err)
+	}
+	linked, err := cl.LinkProgram(context, nil, "", []cl.Program{compiled}, nil)
+	if linked != 0 {
+		_ = cl.ReleaseProgram(linked)
+		t.Fatalf("LinkProgram() returned a program for a failed link")
+	}
+	if !errors.Is(err, cl.ErrLinkProgramFailure) {
+		t.Fatalf("expected ErrLinkProgramFailure, got: %v", err)
+	}
+	var buildErr *cl.BuildError
+	if !errors.As(err, &buildErr) || (buildErr.Device != device) || (len(buildErr.Errors()) != 1) {
+		t.Errorf("unexpected build error: %#v", buildErr)
+	}
+}
+
+func TestLinkProgramFailureWithCallback(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	compiled, err := cl.CreateProgramWithSource(context, []string{"// stub:link-fail\n// stub:notify-thread\n__kernel void main_kernel() {}\n"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(compiled) }()
+	if err = cl.CompileProgram(compiled, nil, "", nil, nil); err != nil {
+		t.Fatalf("CompileProgram() failed: %v", err)
+	}
+	notified := make(chan cl.Program, 1)
+	linked, err := cl.LinkProgram(context, nil, "", []cl.Program{compiled}, func(program cl.Program) { notified <- program })
+	if (linked != 0) || !errors.Is(err, cl.ErrLinkProgramFailure) {
+		t.Fatalf("unexpected result of LinkProgram(): %v, %v", linked, err)
+	}
+	program := <-notified
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.CollectBuildErrors(program, nil, err); !errors.Is(err, cl.ErrLinkProgramFailure) {
+		t.Errorf("expected ErrLinkProgramFailure, got: %v", err)
+	}
+	var buildErr *cl.BuildError
+	if !errors.As(err, &buildErr) {
+		t.Errorf("program of the callback has no build error: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package cl12
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiagnosticSeverity classifies a Diagnostic.
+type DiagnosticSeverity string
+
+// These constants are the severities of diagnostics. Fatal errors are reported as errors, remarks as notes.
+const (
+	SeverityError   DiagnosticSeverity = "error"
+	SeverityWarning DiagnosticSeverity = "warning"
+	SeverityNote    DiagnosticSeverity = "note"
+)
+
+// Diagnostic is a single message of a compiler, as found in a build log.
+type Diagnostic struct {
+	// File is the name of the source as reported by the compiler, such as "<kernel>" or a temporary file.
+	// It is empty for messages without a location.
+	File string
+	// Line is the line in the source, starting at 1. It is zero for messages without a location.
+	Line int
+	// Column is the column in the line, starting at 1. It is zero if the compiler did not report it.
+	Column int
+	// Severity classifies the message.
+	Severity DiagnosticSeverity
+	// Message is the text of the message, without location and severity.
+	Message string
+}
+
+// String returns the diagnostic in the form "file:line:column: severity: message", leaving out unknown parts
+// of the location.
+func (diag Diagnostic) String() string {
+	var location string
+	switch {
+	case diag.Column > 0:
+		location = fmt.Sprintf("%s:%d:%d: ", diag.File, diag.Line, diag.Column)
+	case diag.Line > 0:
+		location = fmt.Sprintf("%s:%d: ", diag.File, diag.Line)
+	}
+	return location + string(diag.Severity) + ": " + diag.Message
+}
+
+var (
+	// clangDiagnostic matches the format of Clang based compilers, which most implementations use:
+	// "file:line:column: error: message". NVIDIA and some Intel runtimes report "<kernel>" or a number as file.
+	clangDiagnostic = regexp.MustCompile(`^(.*?):(\d+):(?:(\d+):)?\s*(?i:(fatal error|error|warning|note|remark))\s*:\s*(.*)$`)
+	// edgDiagnostic matches the format of compilers based on the EDG front end, such as the one of the legacy
+	// AMD runtime: `"file", line 3: error: message`.
+	edgDiagnostic = regexp.MustCompile(
+		`^"(.*)", line (\d+)(?:: col(?:umn)? (\d+))?: (?i:(catastrophic error|fatal error|error|warning|remark))(?: #[\w-]+)?\s*:\s*(.*)$`)
+	// ptxasDiagnostic matches the format of the NVIDIA assembler, which reports lines of the generated PTX code:
+	// "ptxas application ptx input, line 48; error   : message".
+	ptxasDiagnostic = regexp.MustCompile(`^ptxas (.*), line (\d+); (?i:(fatal|error|warning|info))\s*:\s*(.*)$`)
+	// plainDiagnostic matches messages without a location, such as those of compiler drivers and of the NVIDIA
+	// assembler: "error: message", "ptxas fatal   : message".
+	plainDiagnostic = regexp.MustCompile(`^(?:ptxas\s+)?(?i:(fatal error|fatal|error|warning|note|remark))\s*:\s*(.*)$`)
+	caretLine       = regexp.MustCompile(`^\s*[\^~]+[\s~^]*$`)
+)
+
+// ParseBuildLog extracts the diagnostics from a build log, such as the one queried with ProgramBuildLogInfo.
+//
+// The parser understands the formats of Clang based compilers, used by the implementations of Intel, AMD (ROCm),
+// NVIDIA, POCL, and others; of the EDG based compiler of the legacy AMD runtime, including messages that continue
+// on the following lines; and messages without a location, such as those of the NVIDIA assembler.
+// Other lines, such as excerpts of the source, are ignored.
+func ParseBuildLog(log string) []Diagnostic {
+	lines := strings.Split(strings.ReplaceAll(log, "\r\n", "\n"), "\n")
+	var diagnostics []Diagnostic
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		if match := edgDiagnostic.FindStringSubmatch(line); match != nil {
+			diag := newDiagnostic(match[1], match[2], match[3], match[4], match[5])
+			var continued []string
+			continued, i = edgContinuation(lines, i+1)
+			if len(continued) > 0 {
+				diag.Message = strings.Join(append([]string{diag.Message}, continued...), " ")
+			}
+			diagnostics = append(diagnostics, diag)
+		} else if match := clangDiagnostic.FindStringSubmatch(line); match != nil {
+			diagnostics = append(diagnostics, newDiagnostic(match[1], match[2], match[3], match[4], match[5]))
+		} else if match := ptxasDiagnostic.FindStringSubmatch(line); match != nil {
+			diagnostics = append(diagnostics, newDiagnostic(match[1], match[2], "", match[3], match[4]))
+		} else if match := plainDiagnostic.FindStringSubmatch(line); match != nil {
+			diagnostics = append(diagnostics, newDiagnostic("", "", "", match[1], match[2]))
+		}
+	}
+	return diagnostics
+}
+
+// edgContinuation collects the indented lines that continue a message, starting at the given line. The excerpt of
+// the source, which is the line before a caret line, is not part of the message. It returns the index of the last
+// line that belongs to the diagnostic.
+func edgContinuation(lines []string, start int) ([]string, int) {
+	end := start
+	for (end < len(lines)) && (strings.TrimSpace(lines[end]) != "") &&
+		((lines[end][0] == ' ') || (lines[end][0] == '\t')) {
+		end++
+	}
+	var continued []string
+	for i := start; i < end; i++ {
+		if caretLine.MatchString(lines[i]) || ((i+1 < end) && caretLine.MatchString(lines[i+1])) {
+			continue
+		}
+		continued = append(continued, strings.TrimSpace(lines[i]))
+	}
+	return continued, end - 1
+}
+
+func newDiagnostic(file, line, column, severity, message string) Diagnostic {
+	diag := Diagnostic{File: file, Message: strings.TrimSpace(message)}
+	diag.Line, _ = strconv.Atoi(line)
+	diag.Column, _ = strconv.Atoi(column)
+	switch strings.ToLower(severity) {
+	case "warning":
+		diag.Severity = SeverityWarning
+	case "note", "remark", "info":
+		diag.Severity = SeverityNote
+	default:
+		diag.Severity = SeverityError
+	}
+	return diag
+}
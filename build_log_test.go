@@ -0,0 +1,102 @@
+package cl12_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestParseBuildLog(t *testing.T) {
+	t.Parallel()
+	const (
+		errorSeverity   = cl.SeverityError
+		warningSeverity = cl.SeverityWarning
+		noteSeverity    = cl.SeverityNote
+	)
+	rocmSource := "/tmp/comgr-9a1f02/input/CompileSource"
+	tests := []struct {
+		file     string
+		expected []cl.Diagnostic
+	}{
+		{file: "intel_cpu.log", expected: []cl.Diagnostic{
+			{File: "1", Line: 3, Column: 15, Severity: errorSeverity, Message: "use of undeclared identifier 'scale'"},
+			{File: "1", Line: 5, Column: 9, Severity: warningSeverity, Message: "unused variable 'tmp'"},
+		}},
+		{file: "intel_gpu.log", expected: []cl.Diagnostic{
+			{File: "<kernel>", Line: 7, Column: 20, Severity: errorSeverity, Message: "call to 'sqrt' is ambiguous"},
+			{File: "cl_kernel.h", Line: 102, Column: 20, Severity: noteSeverity, Message: "candidate function"},
+			{File: "cl_kernel.h", Line: 103, Column: 21, Severity: noteSeverity, Message: "candidate function"},
+			{Severity: errorSeverity, Message: "backend compiler failed build."},
+		}},
+		{file: "amd_rocm.log", expected: []cl.Diagnostic{
+			{File: rocmSource, Line: 12, Column: 5, Severity: errorSeverity,
+				Message: "implicit declaration of function 'barrier_all' is invalid in OpenCL"},
+			{File: rocmSource, Line: 18, Column: 33, Severity: warningSeverity,
+				Message: "incompatible pointer types passing '__global int *' to parameter of type '__global float *'"},
+			{File: rocmSource, Line: 4, Column: 6, Severity: noteSeverity, Message: "passing argument to parameter 'values' here"},
+			{Severity: errorSeverity, Message: "Failed to compile source (from CL or HIP source to LLVM IR)."},
+		}},
+		{file: "amd_legacy.log", expected: []cl.Diagnostic{
+			{File: "/tmp/OCL2868T1.cl", Line: 9, Severity: errorSeverity, Message: `identifier "gid" is undefined`},
+			{File: "/tmp/OCL2868T1.cl", Line: 14, Severity: warningSeverity, Message: `variable "unused" was declared but never referenced`},
+			{File: "/tmp/OCL2868T1.cl", Line: 21, Severity: errorSeverity,
+				Message: `a value of type "float4" cannot be used to initialize an entity of type "int4"`},
+		}},
+		{file: "nvidia.log", expected: []cl.Diagnostic{
+			{File: "<kernel>", Line: 4, Column: 26, Severity: errorSeverity, Message: "expected ';' after expression"},
+			{File: "<kernel>", Line: 9, Column: 13, Severity: warningSeverity,
+				Message: "double precision constant requires cl_khr_fp64, casting to single precision"},
+		}},
+		{file: "nvidia_ptxas.log", expected: []cl.Diagnostic{
+			{File: "application ptx input", Line: 48, Severity: errorSeverity,
+				Message: "Entry function 'histogram' uses too much shared data (0x10020 bytes, 0xc000 max)"},
+			{Severity: errorSeverity, Message: "Ptx assembly aborted due to errors"},
+		}},
+		{file: "pocl.log", expected: []cl.Diagnostic{
+			{File: "/home/user/project/kernels/common.h", Line: 11, Column: 1, Severity: errorSeverity, Message: "unknown type name 'vec3'"},
+			{File: "/home/user/project/kernels/common.h", Line: 11, Column: 13, Severity: errorSeverity, Message: "unknown type name 'vec3'"},
+			{File: "<command line>", Line: 1, Column: 9, Severity: warningSeverity, Message: "'DEBUG' macro redefined"},
+			{File: "<built-in>", Line: 37, Column: 9, Severity: noteSeverity, Message: "previous definition is here"},
+			{File: "/home/user/project/kernels/main.cl", Line: 30, Column: 12, Severity: errorSeverity, Message: "'missing.h' file not found"},
+		}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			log, err := os.ReadFile(filepath.Join("testdata", "buildlogs", tc.file))
+			if err != nil {
+				t.Fatalf("ReadFile() failed: %v", err)
+			}
+			diagnostics := cl.ParseBuildLog(string(log))
+			if !reflect.DeepEqual(diagnostics, tc.expected) {
+				t.Errorf("unexpected diagnostics:\n%v\nexpected:\n%v", diagnostics, tc.expected)
+			}
+			crlf := cl.ParseBuildLog(strings.ReplaceAll(string(log), "\n", "\r\n"))
+			if !reflect.DeepEqual(crlf, tc.expected) {
+				t.Errorf("unexpected diagnostics with CRLF line endings:\n%v", crlf)
+			}
+		})
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		diag     cl.Diagnostic
+		expected string
+	}{
+		{cl.Diagnostic{File: "<kernel>", Line: 3, Column: 5, Severity: cl.SeverityError, Message: "oops"}, "<kernel>:3:5: error: oops"},
+		{cl.Diagnostic{File: "a.cl", Line: 3, Severity: cl.SeverityWarning, Message: "hmm"}, "a.cl:3: warning: hmm"},
+		{cl.Diagnostic{Severity: cl.SeverityNote, Message: "info"}, "note: info"},
+	}
+	for _, tc := range tests {
+		if text := tc.diag.String(); text != tc.expected {
+			t.Errorf("unexpected text %q, expected %q", text, tc.expected)
+		}
+	}
+}
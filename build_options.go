@@ -0,0 +1,346 @@
+package cl12
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BuildOption is a single option of BuildOptions.
+type BuildOption struct {
+	// Name is the option, such as "-D", "-cl-std", or "-cl-mad-enable". Options that are unknown to this library,
+	// such as vendor specific ones, are kept verbatim as a name without value.
+	Name string
+	// Value is the argument of the options that have one: "NAME" or "NAME=definition" for "-D", the directory
+	// for "-I", and the language version, such as "CL1.2", for "-cl-std".
+	Value string
+}
+
+// String returns the option as it is written in an options string. Values are quoted if necessary.
+func (option BuildOption) String() string {
+	switch option.Name {
+	case "-D", "-I":
+		return option.Name + " " + quoteBuildOption(option.Value)
+	case "-cl-std":
+		return quoteBuildOption(option.Name + "=" + option.Value)
+	default:
+		return quoteBuildOption(option.Name)
+	}
+}
+
+// BuildOptions is a list of options for BuildProgram(), CompileProgram(), and LinkProgram(). Use String() to get
+// the options string, and ParseBuildOptions() to get the list of an existing options string.
+//
+// The methods append an option and return the list, so that calls can be chained:
+//
+//	var options cl.BuildOptions
+//	options.Define("WIDTH", "64").IncludeDir("/opt/my kernels").Std(1, 2).FastRelaxedMath()
+//	err := cl.BuildProgram(program, devices, options.String(), nil)
+//
+// Options without a value are only added once.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clBuildProgram.html
+type BuildOptions []BuildOption
+
+// buildOptionUsage is a set of operations an option may be used with.
+type buildOptionUsage int
+
+const (
+	// buildOptionCompile marks options of the compiler, which BuildProgram() and CompileProgram() accept.
+	buildOptionCompile buildOptionUsage = 1 << iota
+	// buildOptionLink marks options of the linker, which BuildProgram() and LinkProgram() accept.
+	buildOptionLink
+)
+
+type buildOptionSpec struct {
+	// since is the first OpenCL C version that supports the option, as major*100 + minor.
+	since int
+	usage buildOptionUsage
+}
+
+var buildOptionSpecs = map[string]buildOptionSpec{
+	"-D":                                     {since: 100, usage: buildOptionCompile},
+	"-I":                                     {since: 100, usage: buildOptionCompile},
+	"-cl-std":                                {since: 101, usage: buildOptionCompile},
+	"-cl-single-precision-constant":          {since: 100, usage: buildOptionCompile},
+	"-cl-denorms-are-zero":                   {since: 100, usage: buildOptionCompile | buildOptionLink},
+	"-cl-fp32-correctly-rounded-divide-sqrt": {since: 102, usage: buildOptionCompile},
+	"-cl-opt-disable":                        {since: 100, usage: buildOptionCompile},
+	"-cl-mad-enable":                         {since: 100, usage: buildOptionCompile},
+	"-cl-no-signed-zeros":                    {since: 100, usage: buildOptionCompile | buildOptionLink},
+	"-cl-unsafe-math-optimizations":          {since: 100, usage: buildOptionCompile | buildOptionLink},
+	"-cl-finite-math-only":                   {since: 100, usage: buildOptionCompile | buildOptionLink},
+	"-cl-fast-relaxed-math":                  {since: 100, usage: buildOptionCompile | buildOptionLink},
+	"-w":                                     {since: 100, usage: buildOptionCompile},
+	"-Werror":                                {since: 100, usage: buildOptionCompile},
+	"-cl-kernel-arg-info":                    {since: 102, usage: buildOptionCompile},
+	"-create-library":                        {since: 102, usage: buildOptionLink},
+	"-enable-link-options":                   {since: 102, usage: buildOptionLink},
+}
+
+// ParseBuildOptions splits an options string into its options.
+//
+// Options are separated by whitespace. Double quotes group characters, including whitespace, and a backslash
+// takes the following character literally. The values of "-D" and "-I" may follow the option directly or
+// as separate argument. Unknown options are kept verbatim.
+// The function returns an error that wraps ErrInvalidBuildOptions for unterminated quotes, and for "-D" and "-I"
+// without value.
+func ParseBuildOptions(options string) (BuildOptions, error) {
+	args, err := splitBuildOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	var parsed BuildOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case (arg == "-D") || (arg == "-I"):
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%w: missing value of %s", ErrInvalidBuildOptions, arg)
+			}
+			i++
+			parsed = append(parsed, BuildOption{Name: arg, Value: args[i]})
+		case strings.HasPrefix(arg, "-D") || strings.HasPrefix(arg, "-I"):
+			parsed = append(parsed, BuildOption{Name: arg[:2], Value: arg[2:]})
+		case strings.HasPrefix(arg, "-cl-std="):
+			parsed = append(parsed, BuildOption{Name: "-cl-std", Value: strings.TrimPrefix(arg, "-cl-std=")})
+		default:
+			parsed = append(parsed, BuildOption{Name: arg})
+		}
+	}
+	return parsed, nil
+}
+
+func splitBuildOptions(options string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	quoted := false
+	escaped := false
+	for _, r := range options {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, inArg = true, true
+		case r == '"':
+			quoted, inArg = !quoted, true
+		case !quoted && strings.ContainsRune(" \t\r\n\v\f", r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quoted || escaped {
+		return nil, fmt.Errorf("%w: unterminated quote or escape", ErrInvalidBuildOptions)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// quoteBuildOption returns the argument in double quotes if it is empty, or contains whitespace, quotes,
+// or backslashes.
+func quoteBuildOption(arg string) string {
+	if (arg != "") && !strings.ContainsAny(arg, " \t\r\n\v\f\"'\\") {
+		return arg
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+// String returns the options string.
+func (options BuildOptions) String() string {
+	parts := make([]string, len(options))
+	for i, option := range options {
+		parts[i] = option.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// Has returns true if the list contains an option with the given name.
+func (options BuildOptions) Has(name string) bool {
+	for _, option := range options {
+		if option.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Add appends an option. Options without a value are not added if the list has them already.
+func (options *BuildOptions) Add(option BuildOption) *BuildOptions {
+	if (option.Value == "") && options.Has(option.Name) {
+		return options
+	}
+	*options = append(*options, option)
+	return options
+}
+
+// Define adds the option "-D name=definition", or "-D name" if the definition is empty.
+func (options *BuildOptions) Define(name, definition string) *BuildOptions {
+	if definition != "" {
+		name += "=" + definition
+	}
+	return options.Add(BuildOption{Name: "-D", Value: name})
+}
+
+// IncludeDir adds the option "-I dir".
+func (options *BuildOptions) IncludeDir(dir string) *BuildOptions {
+	return options.Add(BuildOption{Name: "-I", Value: dir})
+}
+
+// Std sets the option "-cl-std=CLmajor.minor", replacing a previous one.
+func (options *BuildOptions) Std(major, minor int) *BuildOptions {
+	version := fmt.Sprintf("CL%d.%d", major, minor)
+	for i, option := range *options {
+		if option.Name == "-cl-std" {
+			(*options)[i].Value = version
+			return options
+		}
+	}
+	return options.Add(BuildOption{Name: "-cl-std", Value: version})
+}
+
+// SinglePrecisionConstant adds the option "-cl-single-precision-constant".
+func (options *BuildOptions) SinglePrecisionConstant() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-single-precision-constant"})
+}
+
+// DenormsAreZero adds the option "-cl-denorms-are-zero".
+func (options *BuildOptions) DenormsAreZero() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-denorms-are-zero"})
+}
+
+// Fp32CorrectlyRoundedDivideSqrt adds the option "-cl-fp32-correctly-rounded-divide-sqrt".
+//
+// Since: 1.2
+func (options *BuildOptions) Fp32CorrectlyRoundedDivideSqrt() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-fp32-correctly-rounded-divide-sqrt"})
+}
+
+// OptDisable adds the option "-cl-opt-disable".
+func (options *BuildOptions) OptDisable() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-opt-disable"})
+}
+
+// MadEnable adds the option "-cl-mad-enable".
+func (options *BuildOptions) MadEnable() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-mad-enable"})
+}
+
+// NoSignedZeros adds the option "-cl-no-signed-zeros".
+func (options *BuildOptions) NoSignedZeros() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-no-signed-zeros"})
+}
+
+// UnsafeMathOptimizations adds the option "-cl-unsafe-math-optimizations".
+func (options *BuildOptions) UnsafeMathOptimizations() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-unsafe-math-optimizations"})
+}
+
+// FiniteMathOnly adds the option "-cl-finite-math-only".
+func (options *BuildOptions) FiniteMathOnly() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-finite-math-only"})
+}
+
+// FastRelaxedMath adds the option "-cl-fast-relaxed-math".
+func (options *BuildOptions) FastRelaxedMath() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-fast-relaxed-math"})
+}
+
+// InhibitWarnings adds the option "-w".
+func (options *BuildOptions) InhibitWarnings() *BuildOptions {
+	return options.Add(BuildOption{Name: "-w"})
+}
+
+// WarningsAsErrors adds the option "-Werror".
+func (options *BuildOptions) WarningsAsErrors() *BuildOptions {
+	return options.Add(BuildOption{Name: "-Werror"})
+}
+
+// KernelArgInfo adds the option "-cl-kernel-arg-info", which makes KernelArgInfo() available.
+//
+// Since: 1.2
+func (options *BuildOptions) KernelArgInfo() *BuildOptions {
+	return options.Add(BuildOption{Name: "-cl-kernel-arg-info"})
+}
+
+// CreateLibrary adds the linker option "-create-library".
+//
+// Since: 1.2
+func (options *BuildOptions) CreateLibrary() *BuildOptions {
+	return options.Add(BuildOption{Name: "-create-library"})
+}
+
+// EnableLinkOptions adds the linker option "-enable-link-options", which requires "-create-library".
+//
+// Since: 1.2
+func (options *BuildOptions) EnableLinkOptions() *BuildOptions {
+	return options.Add(BuildOption{Name: "-enable-link-options"})
+}
+
+// CheckBuild verifies that the options can be used with BuildProgram() for the devices.
+// See CheckCompile() for the details.
+func (options BuildOptions) CheckBuild(devices ...DeviceID) error {
+	return options.check(buildOptionCompile, "BuildProgram()", devices)
+}
+
+// CheckCompile verifies that the options can be used with CompileProgram() for the devices.
+//
+// The function returns an error that wraps ErrBuildOptionUnsupported if an option is not a compiler option,
+// or if an option or the version of "-cl-std" requires a higher OpenCL C version than DeviceOpenClCVersionInfo
+// reports for a device. Unknown options are not verified.
+func (options BuildOptions) CheckCompile(devices ...DeviceID) error {
+	return options.check(buildOptionCompile, "CompileProgram()", devices)
+}
+
+// CheckLink verifies that the options can be used with LinkProgram() for the devices.
+// Only linker options are allowed, and "-enable-link-options" requires "-create-library".
+// See CheckCompile() for the details.
+func (options BuildOptions) CheckLink(devices ...DeviceID) error {
+	if options.Has("-enable-link-options") && !options.Has("-create-library") {
+		return fmt.Errorf("%w: -enable-link-options requires -create-library", ErrBuildOptionUnsupported)
+	}
+	return options.check(buildOptionLink, "LinkProgram()", devices)
+}
+
+func (options BuildOptions) check(usage buildOptionUsage, function string, devices []DeviceID) error {
+	for _, option := range options {
+		spec, known := buildOptionSpecs[option.Name]
+		if known && (spec.usage&usage == 0) {
+			return fmt.Errorf("%w: %v can not be used with %s", ErrBuildOptionUnsupported, option, function)
+		}
+	}
+	for _, device := range devices {
+		versionText, err := DeviceInfoString(device, DeviceOpenClCVersionInfo)
+		if err != nil {
+			return err
+		}
+		var major, minor int
+		if _, err := fmt.Sscanf(versionText, "OpenCL C %d.%d", &major, &minor); err != nil {
+			continue
+		}
+		version := major*100 + minor
+		for _, option := range options {
+			required := buildOptionSpecs[option.Name].since
+			if option.Name == "-cl-std" {
+				var stdMajor, stdMinor int
+				if _, err := fmt.Sscanf(option.Value, "CL%d.%d", &stdMajor, &stdMinor); err != nil {
+					return fmt.Errorf("%w: invalid language version %q", ErrBuildOptionUnsupported, option.Value)
+				}
+				required = stdMajor*100 + stdMinor
+			}
+			if required > version {
+				return fmt.Errorf("%w: %v requires OpenCL C %d.%d, device %v supports %q",
+					ErrBuildOptionUnsupported, option, required/100, required%100, device, versionText)
+			}
+		}
+	}
+	return nil
+}
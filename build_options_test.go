@@ -0,0 +1,96 @@
+package cl12_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestBuildOptionsString(t *testing.T) {
+	t.Parallel()
+	var options cl.BuildOptions
+	options.Define("WIDTH", "64").Define("DEBUG", "").Define("GREETING", `"hello world"`).
+		IncludeDir("/opt/my kernels").IncludeDir(`C:\kernels`).Std(1, 1).Std(1, 2).
+		FastRelaxedMath().MadEnable().MadEnable().KernelArgInfo().WarningsAsErrors()
+	expected := `-D WIDTH=64 -D DEBUG -D "GREETING=\"hello world\"" -I "/opt/my kernels" -I "C:\\kernels" -cl-std=CL1.2 ` +
+		`-cl-fast-relaxed-math -cl-mad-enable -cl-kernel-arg-info -Werror`
+	if text := options.String(); text != expected {
+		t.Errorf("unexpected options:\n%s\nexpected:\n%s", text, expected)
+	}
+	parsed, err := cl.ParseBuildOptions(options.String())
+	if err != nil {
+		t.Fatalf("ParseBuildOptions() failed: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, options) {
+		t.Errorf("parsed options differ:\n%v\nexpected:\n%v", parsed, options)
+	}
+}
+
+func TestParseBuildOptions(t *testing.T) {
+	t.Parallel()
+	parsed, err := cl.ParseBuildOptions(`  -DN=4 -D "M=a b" -I"dir with space" -Iinclude -cl-std=CL1.1 -cl-nv-verbose	-w `)
+	if err != nil {
+		t.Fatalf("ParseBuildOptions() failed: %v", err)
+	}
+	expected := cl.BuildOptions{
+		{Name: "-D", Value: "N=4"},
+		{Name: "-D", Value: "M=a b"},
+		{Name: "-I", Value: "dir with space"},
+		{Name: "-I", Value: "include"},
+		{Name: "-cl-std", Value: "CL1.1"},
+		{Name: "-cl-nv-verbose"},
+		{Name: "-w"},
+	}
+	if !reflect.DeepEqual(parsed, expected) {
+		t.Errorf("unexpected options: %v", parsed)
+	}
+	for _, invalid := range []string{`-D "unterminated`, `-I`, `-D N\`} {
+		if _, err := cl.ParseBuildOptions(invalid); !errors.Is(err, cl.ErrInvalidBuildOptions) {
+			t.Errorf("expected ErrInvalidBuildOptions for %q, got: %v", invalid, err)
+		}
+	}
+}
+
+func TestBuildOptionsCheck(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	var options cl.BuildOptions
+	options.Define("N", "4").IncludeDir("my dir").Std(1, 2).KernelArgInfo().Fp32CorrectlyRoundedDivideSqrt().FastRelaxedMath()
+	if err := options.CheckBuild(device); err != nil {
+		t.Fatalf("CheckBuild() failed: %v", err)
+	}
+	program, err := cl.CreateProgramWithSource(context, []string{`__kernel void run(int n) {}`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	if err = cl.BuildProgram(program, nil, options.String(), nil); err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+
+	var newer cl.BuildOptions
+	newer.Std(2, 0)
+	if err := newer.CheckCompile(device); !errors.Is(err, cl.ErrBuildOptionUnsupported) {
+		t.Errorf("expected ErrBuildOptionUnsupported for -cl-std=CL2.0, got: %v", err)
+	}
+	var library cl.BuildOptions
+	library.CreateLibrary()
+	if err := library.CheckCompile(device); !errors.Is(err, cl.ErrBuildOptionUnsupported) {
+		t.Errorf("expected ErrBuildOptionUnsupported for -create-library, got: %v", err)
+	}
+	library.EnableLinkOptions().FastRelaxedMath()
+	if err := library.CheckLink(device); err != nil {
+		t.Errorf("CheckLink() failed: %v", err)
+	}
+	var linkOnly cl.BuildOptions
+	linkOnly.EnableLinkOptions()
+	if err := linkOnly.CheckLink(device); !errors.Is(err, cl.ErrBuildOptionUnsupported) {
+		t.Errorf("expected ErrBuildOptionUnsupported without -create-library, got: %v", err)
+	}
+	linkOnly.CreateLibrary().MadEnable()
+	if err := linkOnly.CheckLink(device); !errors.Is(err, cl.ErrBuildOptionUnsupported) {
+		t.Errorf("expected ErrBuildOptionUnsupported for -cl-mad-enable, got: %v", err)
+	}
+}
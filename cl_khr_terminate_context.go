@@ -19,14 +19,18 @@ type ExtensionTerminateContextKhr struct {
 	clTerminateContextKhr unsafe.Pointer
 }
 
+var _ ContextTerminator = (*ExtensionTerminateContextKhr)(nil)
+
 // LoadExtensionTerminateContextKhr loads the required functions for the extension and returns an instance
 // to ExtensionTerminateContextKhr if possible.
+// The status values of the extension, such as ErrContextTerminatedKhr, are registered with RegisterStatusError().
 //
 // Extension: KhrTerminateContextExtensionName
 func LoadExtensionTerminateContextKhr(id PlatformID) (*ExtensionTerminateContextKhr, error) {
+	RegisterStatusError(ErrContextTerminatedKhr, "CL_CONTEXT_TERMINATED_KHR", "context has been terminated")
 	clTerminateContextKhr := ExtensionFunctionAddressForPlatform(id, "clTerminateContextKHR")
 	if clTerminateContextKhr == nil {
-		return nil, ErrExtensionNotAvailable
+		return nil, newOpError("clGetExtensionFunctionAddressForPlatform", ErrExtensionNotAvailable, id, "clTerminateContextKHR")
 	}
 	return &ExtensionTerminateContextKhr{clTerminateContextKhr: clTerminateContextKhr}, nil
 }
@@ -39,11 +43,11 @@ func LoadExtensionTerminateContextKhr(id PlatformID) (*ExtensionTerminateContext
 // Extension: KhrTerminateContextExtensionName
 func (ext *ExtensionTerminateContextKhr) TerminateContext(context Context) error {
 	if (ext == nil) || (ext.clTerminateContextKhr == nil) {
-		return ErrExtensionNotLoaded
+		return newOpError("clTerminateContextKHR", ErrExtensionNotLoaded, context)
 	}
 	status := C.cl12ExtTerminateContextKHR(ext.clTerminateContextKhr, context.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clTerminateContextKHR", StatusError(status), context)
 	}
 	return nil
 }
@@ -0,0 +1,841 @@
+// Package cltest provides utilities for testing code that uses the cl12 package without an OpenCL device.
+package cltest
+
+import (
+	"sync"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// Call is a recorded call of a Fake.
+type Call struct {
+	// Function is the name of the called API function, such as "CreateCommandQueue".
+	Function string
+	// Args are the arguments as they were passed to the function.
+	Args []any
+	// Err is the scripted error that the call returned.
+	Err error
+}
+
+// Fake is an implementation of cl.API that records all calls and returns scripted results.
+//
+// Without a script, all calls succeed. Functions that create objects return new, unique handles;
+// event pointers passed to enqueue functions receive new event handles as well.
+// Information queries return zero values. LoadExtensionTerminateContextKhr() returns a terminator that is
+// recorded as well. Callbacks for BuildProgram(), CompileProgram(), LinkProgram(),
+// and SetEventCallback() are called before the function returns, if the call succeeds.
+//
+// The zero value is ready to use. A Fake is safe for concurrent use.
+type Fake struct {
+	// Platforms is the list returned by PlatformIDs().
+	Platforms []cl.PlatformID
+	// Devices is the list returned by DeviceIDs().
+	Devices []cl.DeviceID
+
+	mutex      sync.Mutex
+	calls      []Call
+	scripts    map[string][]error
+	lastHandle uintptr
+}
+
+var _ cl.API = (*Fake)(nil)
+
+// Script queues errors to be returned by the next calls of the named function, one per call.
+// A nil entry lets the respective call succeed. Once the queue is exhausted, calls succeed again.
+//
+// Typical values are cl.StatusError constants, such as cl.ErrOutOfResources.
+func (fake *Fake) Script(function string, results ...error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	if fake.scripts == nil {
+		fake.scripts = make(map[string][]error)
+	}
+	fake.scripts[function] = append(fake.scripts[function], results...)
+}
+
+// Calls returns all recorded calls in the order they were made.
+func (fake *Fake) Calls() []Call {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]Call{}, fake.calls...)
+}
+
+// CallsTo returns the recorded calls of the named function in the order they were made.
+func (fake *Fake) CallsTo(function string) []Call {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	var calls []Call
+	for _, call := range fake.calls {
+		if call.Function == function {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (fake *Fake) record(function string, args ...any) error {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	var err error
+	if script := fake.scripts[function]; len(script) > 0 {
+		err = script[0]
+		fake.scripts[function] = script[1:]
+	}
+	fake.calls = append(fake.calls, Call{Function: function, Args: args, Err: err})
+	return err
+}
+
+func (fake *Fake) newHandle() uintptr {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.lastHandle++
+	return fake.lastHandle
+}
+
+func (fake *Fake) assignEvent(event *cl.Event) {
+	if event != nil {
+		*event = cl.Event(fake.newHandle())
+	}
+}
+
+func (fake *Fake) platforms() []cl.PlatformID {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]cl.PlatformID{}, fake.Platforms...)
+}
+
+func (fake *Fake) devices() []cl.DeviceID {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	return append([]cl.DeviceID{}, fake.Devices...)
+}
+
+// PlatformIDs records the call and returns the next scripted result.
+func (fake *Fake) PlatformIDs() ([]cl.PlatformID, error) {
+	err := fake.record("PlatformIDs")
+	if err != nil {
+		return nil, err
+	}
+	return fake.platforms(), nil
+}
+
+// PlatformInfo records the call and returns the next scripted result.
+func (fake *Fake) PlatformInfo(id cl.PlatformID, paramName cl.PlatformInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("PlatformInfo", id, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// PlatformInfoString records the call and returns the next scripted result.
+func (fake *Fake) PlatformInfoString(id cl.PlatformID, paramName cl.PlatformInfoName) (string, error) {
+	err := fake.record("PlatformInfoString", id, paramName)
+	return "", err
+}
+
+// ExtensionFunctionAddressForPlatform records the call and returns the next scripted result.
+// The returned address is always nil.
+func (fake *Fake) ExtensionFunctionAddressForPlatform(id cl.PlatformID, functionName string) unsafe.Pointer {
+	_ = fake.record("ExtensionFunctionAddressForPlatform", id, functionName)
+	return nil
+}
+
+// UnloadPlatformCompiler records the call and returns the next scripted result.
+func (fake *Fake) UnloadPlatformCompiler(id cl.PlatformID) error {
+	err := fake.record("UnloadPlatformCompiler", id)
+	return err
+}
+
+// LoadExtensionTerminateContextKhr records the call and returns the next scripted result.
+// The returned terminator records its calls of TerminateContext() with the fake, under the name "TerminateContext".
+func (fake *Fake) LoadExtensionTerminateContextKhr(id cl.PlatformID) (cl.ContextTerminator, error) {
+	err := fake.record("LoadExtensionTerminateContextKhr", id)
+	if err != nil {
+		return nil, err
+	}
+	return fakeTerminator{fake: fake}, nil
+}
+
+// fakeTerminator is the cl.ContextTerminator of Fake.LoadExtensionTerminateContextKhr().
+type fakeTerminator struct {
+	fake *Fake
+}
+
+// TerminateContext records the call and returns the next scripted result.
+func (terminator fakeTerminator) TerminateContext(context cl.Context) error {
+	return terminator.fake.record("TerminateContext", context)
+}
+
+// DeviceIDs records the call and returns the next scripted result.
+func (fake *Fake) DeviceIDs(platformID cl.PlatformID, deviceType cl.DeviceTypeFlags) ([]cl.DeviceID, error) {
+	err := fake.record("DeviceIDs", platformID, deviceType)
+	if err != nil {
+		return nil, err
+	}
+	return fake.devices(), nil
+}
+
+// DeviceInfo records the call and returns the next scripted result.
+func (fake *Fake) DeviceInfo(id cl.DeviceID, paramName cl.DeviceInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("DeviceInfo", id, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// DeviceInfoString records the call and returns the next scripted result.
+func (fake *Fake) DeviceInfoString(id cl.DeviceID, paramName cl.DeviceInfoName) (string, error) {
+	err := fake.record("DeviceInfoString", id, paramName)
+	return "", err
+}
+
+// CreateSubDevices records the call and returns the next scripted result.
+func (fake *Fake) CreateSubDevices(id cl.DeviceID, properties ...cl.DevicePartitionProperty) ([]cl.DeviceID, error) {
+	err := fake.record("CreateSubDevices", id, properties)
+	return nil, err
+}
+
+// RetainDevice records the call and returns the next scripted result.
+func (fake *Fake) RetainDevice(id cl.DeviceID) error {
+	err := fake.record("RetainDevice", id)
+	return err
+}
+
+// ReleaseDevice records the call and returns the next scripted result.
+func (fake *Fake) ReleaseDevice(id cl.DeviceID) error {
+	err := fake.record("ReleaseDevice", id)
+	return err
+}
+
+// QueryDevice records the call and returns the next scripted result.
+func (fake *Fake) QueryDevice(id cl.DeviceID) (cl.DeviceProperties, error) {
+	err := fake.record("QueryDevice", id)
+	return cl.DeviceProperties{}, err
+}
+
+// CreateContext records the call and returns the next scripted result.
+func (fake *Fake) CreateContext(deviceIds []cl.DeviceID, callback *cl.ContextErrorCallback, properties ...cl.ContextProperty) (cl.Context, error) {
+	err := fake.record("CreateContext", deviceIds, callback, properties)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Context(fake.newHandle()), nil
+}
+
+// CreateContextFromType records the call and returns the next scripted result.
+func (fake *Fake) CreateContextFromType(deviceType cl.DeviceTypeFlags, callback *cl.ContextErrorCallback,
+	properties ...cl.ContextProperty) (cl.Context, error) {
+	err := fake.record("CreateContextFromType", deviceType, callback, properties)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Context(fake.newHandle()), nil
+}
+
+// RetainContext records the call and returns the next scripted result.
+func (fake *Fake) RetainContext(context cl.Context) error {
+	err := fake.record("RetainContext", context)
+	return err
+}
+
+// ReleaseContext records the call and returns the next scripted result.
+func (fake *Fake) ReleaseContext(context cl.Context) error {
+	err := fake.record("ReleaseContext", context)
+	return err
+}
+
+// ContextInfo records the call and returns the next scripted result.
+func (fake *Fake) ContextInfo(context cl.Context, paramName cl.ContextInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("ContextInfo", context, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// ContextInfoString records the call and returns the next scripted result.
+func (fake *Fake) ContextInfoString(context cl.Context, paramName cl.ContextInfoName) (string, error) {
+	err := fake.record("ContextInfoString", context, paramName)
+	return "", err
+}
+
+// CreateCommandQueue records the call and returns the next scripted result.
+func (fake *Fake) CreateCommandQueue(context cl.Context, deviceID cl.DeviceID, properties cl.CommandQueuePropertiesFlags) (cl.CommandQueue, error) {
+	err := fake.record("CreateCommandQueue", context, deviceID, properties)
+	if err != nil {
+		return 0, err
+	}
+	return cl.CommandQueue(fake.newHandle()), nil
+}
+
+// RetainCommandQueue records the call and returns the next scripted result.
+func (fake *Fake) RetainCommandQueue(commandQueue cl.CommandQueue) error {
+	err := fake.record("RetainCommandQueue", commandQueue)
+	return err
+}
+
+// ReleaseCommandQueue records the call and returns the next scripted result.
+func (fake *Fake) ReleaseCommandQueue(commandQueue cl.CommandQueue) error {
+	err := fake.record("ReleaseCommandQueue", commandQueue)
+	return err
+}
+
+// CommandQueueInfo records the call and returns the next scripted result.
+func (fake *Fake) CommandQueueInfo(commandQueue cl.CommandQueue, paramName cl.CommandQueueInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("CommandQueueInfo", commandQueue, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// Flush records the call and returns the next scripted result.
+func (fake *Fake) Flush(commandQueue cl.CommandQueue) error {
+	err := fake.record("Flush", commandQueue)
+	return err
+}
+
+// Finish records the call and returns the next scripted result.
+func (fake *Fake) Finish(commandQueue cl.CommandQueue) error {
+	err := fake.record("Finish", commandQueue)
+	return err
+}
+
+// RetainMemObject records the call and returns the next scripted result.
+func (fake *Fake) RetainMemObject(mem cl.MemObject) error {
+	err := fake.record("RetainMemObject", mem)
+	return err
+}
+
+// ReleaseMemObject records the call and returns the next scripted result.
+func (fake *Fake) ReleaseMemObject(mem cl.MemObject) error {
+	err := fake.record("ReleaseMemObject", mem)
+	return err
+}
+
+// SetMemObjectDestructorCallback records the call and returns the next scripted result.
+func (fake *Fake) SetMemObjectDestructorCallback(mem cl.MemObject, callback func()) error {
+	err := fake.record("SetMemObjectDestructorCallback", mem, callback)
+	return err
+}
+
+// MemObjectInfo records the call and returns the next scripted result.
+func (fake *Fake) MemObjectInfo(mem cl.MemObject, paramName cl.MemObjectInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("MemObjectInfo", mem, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// EnqueueUnmapMemObject records the call and returns the next scripted result.
+func (fake *Fake) EnqueueUnmapMemObject(commandQueue cl.CommandQueue, mem cl.MemObject, mappedPtr unsafe.Pointer,
+	waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueUnmapMemObject", commandQueue, mem, mappedPtr, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueMigrateMemObjects records the call and returns the next scripted result.
+func (fake *Fake) EnqueueMigrateMemObjects(commandQueue cl.CommandQueue, memObjects []cl.MemObject,
+	migrationFlags cl.MemMigrationFlags, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueMigrateMemObjects", commandQueue, memObjects, migrationFlags, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// CreateBuffer records the call and returns the next scripted result.
+func (fake *Fake) CreateBuffer(context cl.Context, flags cl.MemFlags, size int, hostPtr unsafe.Pointer) (cl.MemObject, error) {
+	err := fake.record("CreateBuffer", context, flags, size, hostPtr)
+	if err != nil {
+		return 0, err
+	}
+	return cl.MemObject(fake.newHandle()), nil
+}
+
+// CreateSubBuffer records the call and returns the next scripted result.
+func (fake *Fake) CreateSubBuffer(buffer cl.MemObject, flags cl.MemFlags, createType cl.BufferCreateType,
+	createInfo unsafe.Pointer) (cl.MemObject, error) {
+	err := fake.record("CreateSubBuffer", buffer, flags, createType, createInfo)
+	if err != nil {
+		return 0, err
+	}
+	return cl.MemObject(fake.newHandle()), nil
+}
+
+// EnqueueMapBuffer records the call and returns the next scripted result.
+func (fake *Fake) EnqueueMapBuffer(commandQueue cl.CommandQueue, buffer cl.MemObject, blocking bool, flags cl.MapFlags,
+	offset, size uintptr, waitList []cl.Event, event *cl.Event) (unsafe.Pointer, error) {
+	err := fake.record("EnqueueMapBuffer", commandQueue, buffer, blocking, flags, offset, size, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return nil, err
+}
+
+// EnqueueReadBuffer records the call and returns the next scripted result.
+func (fake *Fake) EnqueueReadBuffer(commandQueue cl.CommandQueue, mem cl.MemObject, blockingRead bool, offset, size uintptr,
+	data unsafe.Pointer, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueReadBuffer", commandQueue, mem, blockingRead, offset, size, data, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueReadBufferRect records the call and returns the next scripted result.
+func (fake *Fake) EnqueueReadBufferRect(commandQueue cl.CommandQueue, mem cl.MemObject, blockingRead bool,
+	bufferOrigin, hostOrigin, region [3]uintptr, bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr,
+	data unsafe.Pointer, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueReadBufferRect", commandQueue, mem, blockingRead, bufferOrigin, hostOrigin, region,
+		bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, data, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueWriteBuffer records the call and returns the next scripted result.
+func (fake *Fake) EnqueueWriteBuffer(commandQueue cl.CommandQueue, mem cl.MemObject, blockingRead bool, offset, size uintptr,
+	data unsafe.Pointer, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueWriteBuffer", commandQueue, mem, blockingRead, offset, size, data, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueWriteBufferRect records the call and returns the next scripted result.
+func (fake *Fake) EnqueueWriteBufferRect(commandQueue cl.CommandQueue, mem cl.MemObject, blockingRead bool,
+	bufferOrigin, hostOrigin, region [3]uintptr, bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch uintptr,
+	data unsafe.Pointer, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueWriteBufferRect", commandQueue, mem, blockingRead, bufferOrigin, hostOrigin, region,
+		bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, data, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueFillBuffer records the call and returns the next scripted result.
+func (fake *Fake) EnqueueFillBuffer(commandQueue cl.CommandQueue, mem cl.MemObject, pattern unsafe.Pointer,
+	patternSize, offset, size uintptr, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueFillBuffer", commandQueue, mem, pattern, patternSize, offset, size, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueCopyBuffer records the call and returns the next scripted result.
+func (fake *Fake) EnqueueCopyBuffer(commandQueue cl.CommandQueue, src, dst cl.MemObject, srcOffset, dstOffset, size uintptr,
+	waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueCopyBuffer", commandQueue, src, dst, srcOffset, dstOffset, size, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueCopyBufferRect records the call and returns the next scripted result.
+func (fake *Fake) EnqueueCopyBufferRect(commandQueue cl.CommandQueue, src, dst cl.MemObject,
+	srcOrigin, dstOrigin, region [3]uintptr, srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch uintptr, waitList []cl.Event,
+	event *cl.Event) error {
+	err := fake.record("EnqueueCopyBufferRect", commandQueue, src, dst, srcOrigin, dstOrigin, region, srcRowPitch,
+		srcSlicePitch, dstRowPitch, dstSlicePitch, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// CreateImage records the call and returns the next scripted result.
+func (fake *Fake) CreateImage(context cl.Context, flags cl.MemFlags, format cl.ImageFormat, desc cl.ImageDesc,
+	hostPtr unsafe.Pointer) (cl.MemObject, error) {
+	err := fake.record("CreateImage", context, flags, format, desc, hostPtr)
+	if err != nil {
+		return 0, err
+	}
+	return cl.MemObject(fake.newHandle()), nil
+}
+
+// SupportedImageFormats records the call and returns the next scripted result.
+func (fake *Fake) SupportedImageFormats(context cl.Context, flags cl.MemFlags, imageType cl.MemObjectType) ([]cl.ImageFormat, error) {
+	err := fake.record("SupportedImageFormats", context, flags, imageType)
+	return nil, err
+}
+
+// EnqueueMapImage records the call and returns the next scripted result.
+func (fake *Fake) EnqueueMapImage(commandQueue cl.CommandQueue, image cl.MemObject, blocking bool, flags cl.MapFlags,
+	origin, region [3]uintptr, waitList []cl.Event, event *cl.Event) (cl.MappedImage, error) {
+	err := fake.record("EnqueueMapImage", commandQueue, image, blocking, flags, origin, region, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return cl.MappedImage{}, err
+}
+
+// ImageInfo records the call and returns the next scripted result.
+func (fake *Fake) ImageInfo(image cl.MemObject, paramName cl.ImageInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("ImageInfo", image, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// EnqueueReadImage records the call and returns the next scripted result.
+func (fake *Fake) EnqueueReadImage(commandQueue cl.CommandQueue, image cl.MemObject, blocking bool, origin, region [3]uintptr,
+	rowPitch, slicePitch uintptr, ptr unsafe.Pointer, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueReadImage", commandQueue, image, blocking, origin, region, rowPitch, slicePitch, ptr, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueWriteImage records the call and returns the next scripted result.
+func (fake *Fake) EnqueueWriteImage(commandQueue cl.CommandQueue, image cl.MemObject, blocking bool, origin, region [3]uintptr,
+	rowPitch, slicePitch uintptr, ptr unsafe.Pointer, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueWriteImage", commandQueue, image, blocking, origin, region, rowPitch, slicePitch, ptr, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueFillImage records the call and returns the next scripted result.
+func (fake *Fake) EnqueueFillImage(commandQueue cl.CommandQueue, image cl.MemObject, fillColor unsafe.Pointer,
+	origin, region [3]uintptr, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueFillImage", commandQueue, image, fillColor, origin, region, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueCopyImage records the call and returns the next scripted result.
+func (fake *Fake) EnqueueCopyImage(commandQueue cl.CommandQueue, srcImage, dstImage cl.MemObject,
+	srcOrigin, dstOrigin, region [3]uintptr, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueCopyImage", commandQueue, srcImage, dstImage, srcOrigin, dstOrigin, region, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueCopyImageToBuffer records the call and returns the next scripted result.
+func (fake *Fake) EnqueueCopyImageToBuffer(commandQueue cl.CommandQueue, srcImage, dstBuffer cl.MemObject,
+	srcOrigin, region [3]uintptr, dstOffset uintptr, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueCopyImageToBuffer", commandQueue, srcImage, dstBuffer, srcOrigin, region, dstOffset, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueCopyBufferToImage records the call and returns the next scripted result.
+func (fake *Fake) EnqueueCopyBufferToImage(commandQueue cl.CommandQueue, srcBuffer, dstImage cl.MemObject, srcOffset uintptr,
+	srcOrigin, region [3]uintptr, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueCopyBufferToImage", commandQueue, srcBuffer, dstImage, srcOffset, srcOrigin, region, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// CreateSampler records the call and returns the next scripted result.
+func (fake *Fake) CreateSampler(context cl.Context, normalizedCoords bool, addressingMode cl.SamplerAddressingMode,
+	filterMode cl.SamplerFilterMode) (cl.Sampler, error) {
+	err := fake.record("CreateSampler", context, normalizedCoords, addressingMode, filterMode)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Sampler(fake.newHandle()), nil
+}
+
+// RetainSampler records the call and returns the next scripted result.
+func (fake *Fake) RetainSampler(sampler cl.Sampler) error {
+	err := fake.record("RetainSampler", sampler)
+	return err
+}
+
+// ReleaseSampler records the call and returns the next scripted result.
+func (fake *Fake) ReleaseSampler(sampler cl.Sampler) error {
+	err := fake.record("ReleaseSampler", sampler)
+	return err
+}
+
+// SamplerInfo records the call and returns the next scripted result.
+func (fake *Fake) SamplerInfo(sampler cl.Sampler, paramName cl.SamplerInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("SamplerInfo", sampler, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// CreateProgramWithSource records the call and returns the next scripted result.
+func (fake *Fake) CreateProgramWithSource(context cl.Context, sources []string) (cl.Program, error) {
+	err := fake.record("CreateProgramWithSource", context, sources)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Program(fake.newHandle()), nil
+}
+
+// CreateProgramWithBinary records the call and returns the next scripted result.
+func (fake *Fake) CreateProgramWithBinary(context cl.Context, devices []cl.DeviceID, binaries [][]byte) (cl.Program, []error, error) {
+	err := fake.record("CreateProgramWithBinary", context, devices, binaries)
+	if err != nil {
+		return 0, nil, err
+	}
+	return cl.Program(fake.newHandle()), make([]error, len(binaries)), nil
+}
+
+// CreateProgramWithBuiltInKernels records the call and returns the next scripted result.
+func (fake *Fake) CreateProgramWithBuiltInKernels(context cl.Context, devices []cl.DeviceID, kernelNames string) (cl.Program, error) {
+	err := fake.record("CreateProgramWithBuiltInKernels", context, devices, kernelNames)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Program(fake.newHandle()), nil
+}
+
+// RetainProgram records the call and returns the next scripted result.
+func (fake *Fake) RetainProgram(program cl.Program) error {
+	err := fake.record("RetainProgram", program)
+	return err
+}
+
+// ReleaseProgram records the call and returns the next scripted result.
+func (fake *Fake) ReleaseProgram(program cl.Program) error {
+	err := fake.record("ReleaseProgram", program)
+	return err
+}
+
+// BuildProgram records the call and returns the next scripted result.
+func (fake *Fake) BuildProgram(program cl.Program, devices []cl.DeviceID, options string, callback func()) error {
+	err := fake.record("BuildProgram", program, devices, options, callback)
+	if (err == nil) && (callback != nil) {
+		callback()
+	}
+	return err
+}
+
+// CompileProgram records the call and returns the next scripted result.
+func (fake *Fake) CompileProgram(program cl.Program, devices []cl.DeviceID, options string, headers []cl.IncludeHeader, callback func()) error {
+	err := fake.record("CompileProgram", program, devices, options, headers, callback)
+	if (err == nil) && (callback != nil) {
+		callback()
+	}
+	return err
+}
+
+// LinkProgram records the call and returns the next scripted result.
+func (fake *Fake) LinkProgram(context cl.Context, devices []cl.DeviceID, options string, programs []cl.Program,
+	callback func(cl.Program)) (cl.Program, error) {
+	err := fake.record("LinkProgram", context, devices, options, programs, callback)
+	if err != nil {
+		return 0, err
+	}
+	program := cl.Program(fake.newHandle())
+	if callback != nil {
+		callback(program)
+	}
+	return program, nil
+}
+
+// ProgramBuildInfo records the call and returns the next scripted result.
+func (fake *Fake) ProgramBuildInfo(program cl.Program, device cl.DeviceID, paramName cl.ProgramBuildInfoName,
+	paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("ProgramBuildInfo", program, device, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// ProgramBuildInfoString records the call and returns the next scripted result.
+func (fake *Fake) ProgramBuildInfoString(program cl.Program, device cl.DeviceID, paramName cl.ProgramBuildInfoName) (string, error) {
+	err := fake.record("ProgramBuildInfoString", program, device, paramName)
+	return "", err
+}
+
+// ProgramInfo records the call and returns the next scripted result.
+func (fake *Fake) ProgramInfo(program cl.Program, paramName cl.ProgramInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("ProgramInfo", program, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// ProgramInfoString records the call and returns the next scripted result.
+func (fake *Fake) ProgramInfoString(program cl.Program, paramName cl.ProgramInfoName) (string, error) {
+	err := fake.record("ProgramInfoString", program, paramName)
+	return "", err
+}
+
+// ProgramBinaries records the call and returns the next scripted result.
+func (fake *Fake) ProgramBinaries(program cl.Program) (map[cl.DeviceID][]byte, error) {
+	err := fake.record("ProgramBinaries", program)
+	return nil, err
+}
+
+// CreateProgramWithBinaries records the call and returns the next scripted result.
+func (fake *Fake) CreateProgramWithBinaries(context cl.Context, binaries map[cl.DeviceID][]byte) (cl.Program, map[cl.DeviceID]error, error) {
+	err := fake.record("CreateProgramWithBinaries", context, binaries)
+	if err != nil {
+		return 0, nil, err
+	}
+	return cl.Program(fake.newHandle()), nil, nil
+}
+
+// CreateKernel records the call and returns the next scripted result.
+func (fake *Fake) CreateKernel(program cl.Program, name string) (cl.Kernel, error) {
+	err := fake.record("CreateKernel", program, name)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Kernel(fake.newHandle()), nil
+}
+
+// CreateKernelsInProgram records the call and returns the next scripted result.
+func (fake *Fake) CreateKernelsInProgram(program cl.Program) ([]cl.Kernel, error) {
+	err := fake.record("CreateKernelsInProgram", program)
+	return nil, err
+}
+
+// RetainKernel records the call and returns the next scripted result.
+func (fake *Fake) RetainKernel(kernel cl.Kernel) error {
+	err := fake.record("RetainKernel", kernel)
+	return err
+}
+
+// ReleaseKernel records the call and returns the next scripted result.
+func (fake *Fake) ReleaseKernel(kernel cl.Kernel) error {
+	err := fake.record("ReleaseKernel", kernel)
+	return err
+}
+
+// SetKernelArg records the call and returns the next scripted result.
+func (fake *Fake) SetKernelArg(kernel cl.Kernel, index uint32, size uintptr, value unsafe.Pointer) error {
+	err := fake.record("SetKernelArg", kernel, index, size, value)
+	return err
+}
+
+// KernelInfo records the call and returns the next scripted result.
+func (fake *Fake) KernelInfo(kernel cl.Kernel, paramName cl.KernelInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("KernelInfo", kernel, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// KernelInfoString records the call and returns the next scripted result.
+func (fake *Fake) KernelInfoString(kernel cl.Kernel, paramName cl.KernelInfoName) (string, error) {
+	err := fake.record("KernelInfoString", kernel, paramName)
+	return "", err
+}
+
+// KernelWorkGroupInfo records the call and returns the next scripted result.
+func (fake *Fake) KernelWorkGroupInfo(kernel cl.Kernel, device cl.DeviceID, paramName cl.KernelWorkGroupInfoName,
+	paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("KernelWorkGroupInfo", kernel, device, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// KernelArgInfo records the call and returns the next scripted result.
+func (fake *Fake) KernelArgInfo(kernel cl.Kernel, index uint32, paramName cl.KernelArgInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("KernelArgInfo", kernel, index, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// KernelArgInfoString records the call and returns the next scripted result.
+func (fake *Fake) KernelArgInfoString(kernel cl.Kernel, index uint32, paramName cl.KernelArgInfoName) (string, error) {
+	err := fake.record("KernelArgInfoString", kernel, index, paramName)
+	return "", err
+}
+
+// EnqueueNDRangeKernel records the call and returns the next scripted result.
+func (fake *Fake) EnqueueNDRangeKernel(commandQueue cl.CommandQueue, kernel cl.Kernel, workDimensions []cl.WorkDimension,
+	waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueNDRangeKernel", commandQueue, kernel, workDimensions, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueTask records the call and returns the next scripted result.
+func (fake *Fake) EnqueueTask(commandQueue cl.CommandQueue, kernel cl.Kernel, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueTask", commandQueue, kernel, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueNativeKernel records the call and returns the next scripted result.
+func (fake *Fake) EnqueueNativeKernel(commandQueue cl.CommandQueue, callback func([]unsafe.Pointer), memObjects []cl.MemObject,
+	waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueNativeKernel", commandQueue, callback, memObjects, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// CreateUserEvent records the call and returns the next scripted result.
+func (fake *Fake) CreateUserEvent(context cl.Context) (cl.Event, error) {
+	err := fake.record("CreateUserEvent", context)
+	if err != nil {
+		return 0, err
+	}
+	return cl.Event(fake.newHandle()), nil
+}
+
+// SetUserEventStatus records the call and returns the next scripted result.
+func (fake *Fake) SetUserEventStatus(event cl.Event, executionStatus int) error {
+	err := fake.record("SetUserEventStatus", event, executionStatus)
+	return err
+}
+
+// WaitForEvents records the call and returns the next scripted result.
+func (fake *Fake) WaitForEvents(events []cl.Event) error {
+	err := fake.record("WaitForEvents", events)
+	return err
+}
+
+// EventInfo records the call and returns the next scripted result.
+func (fake *Fake) EventInfo(event cl.Event, paramName cl.EventInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("EventInfo", event, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// RetainEvent records the call and returns the next scripted result.
+func (fake *Fake) RetainEvent(event cl.Event) error {
+	err := fake.record("RetainEvent", event)
+	return err
+}
+
+// ReleaseEvent records the call and returns the next scripted result.
+func (fake *Fake) ReleaseEvent(event cl.Event) error {
+	err := fake.record("ReleaseEvent", event)
+	return err
+}
+
+// EventProfilingInfo records the call and returns the next scripted result.
+func (fake *Fake) EventProfilingInfo(event cl.Event, paramName cl.EventProfilingInfoName, paramSize uintptr,
+	paramValue unsafe.Pointer) (uintptr, error) {
+	err := fake.record("EventProfilingInfo", event, paramName, paramSize, paramValue)
+	return 0, err
+}
+
+// SetEventCallback records the call and returns the next scripted result.
+func (fake *Fake) SetEventCallback(event cl.Event, callbackType cl.EventCommandExecutionStatus, callback func(error)) error {
+	err := fake.record("SetEventCallback", event, callbackType, callback)
+	if err == nil {
+		callback(nil)
+	}
+	return err
+}
+
+// EnqueueMarkerWithWaitList records the call and returns the next scripted result.
+func (fake *Fake) EnqueueMarkerWithWaitList(commandQueue cl.CommandQueue, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueMarkerWithWaitList", commandQueue, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
+
+// EnqueueBarrierWithWaitList records the call and returns the next scripted result.
+func (fake *Fake) EnqueueBarrierWithWaitList(commandQueue cl.CommandQueue, waitList []cl.Event, event *cl.Event) error {
+	err := fake.record("EnqueueBarrierWithWaitList", commandQueue, waitList, event)
+	if err == nil {
+		fake.assignEvent(event)
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package cltest_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+	"github.com/opencl-go/cl12/cltest"
+)
+
+func TestFakeScript(t *testing.T) {
+	t.Parallel()
+	var fake cltest.Fake
+	fake.Script("Finish", cl.ErrOutOfResources, nil)
+	var api cl.API = &fake
+	if err := api.Finish(1); !errors.Is(err, cl.ErrOutOfResources) {
+		t.Errorf("expected scripted error, got: %v", err)
+	}
+	if err := api.Finish(2); err != nil {
+		t.Errorf("expected success for nil entry, got: %v", err)
+	}
+	if err := api.Finish(3); err != nil {
+		t.Errorf("expected success after script, got: %v", err)
+	}
+	calls := fake.CallsTo("Finish")
+	if len(calls) != 3 {
+		t.Fatalf("unexpected number of recorded calls: %d", len(calls))
+	}
+	if (calls[0].Args[0] != cl.CommandQueue(1)) || !errors.Is(calls[0].Err, cl.ErrOutOfResources) {
+		t.Errorf("unexpected first call: %+v", calls[0])
+	}
+}
+
+func TestFakeHandles(t *testing.T) {
+	t.Parallel()
+	var fake cltest.Fake
+	first, err := fake.CreateCommandQueue(1, 2, 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue() failed: %v", err)
+	}
+	second, _ := fake.CreateCommandQueue(1, 2, 0)
+	if (first == 0) || (first == second) {
+		t.Errorf("handles not unique: %v, %v", first, second)
+	}
+	var event cl.Event
+	err = fake.EnqueueWriteBuffer(first, 3, false, 0, 4, nil, nil, &event)
+	if err != nil {
+		t.Fatalf("EnqueueWriteBuffer() failed: %v", err)
+	}
+	if event == 0 {
+		t.Errorf("no event assigned")
+	}
+	fake.Script("CreateCommandQueue", cl.ErrInvalidDevice)
+	queue, err := fake.CreateCommandQueue(1, 2, 0)
+	if !errors.Is(err, cl.ErrInvalidDevice) || (queue != 0) {
+		t.Errorf("unexpected result for scripted error: %v, %v", queue, err)
+	}
+}
+
+func TestFakeTerminateContext(t *testing.T) {
+	t.Parallel()
+	var fake cltest.Fake
+	var api cl.API = &fake
+	fake.Script("TerminateContext", cl.ErrInvalidContext)
+	terminator, err := api.LoadExtensionTerminateContextKhr(1)
+	if err != nil {
+		t.Fatalf("LoadExtensionTerminateContextKhr() failed: %v", err)
+	}
+	if err := terminator.TerminateContext(2); !errors.Is(err, cl.ErrInvalidContext) {
+		t.Errorf("expected scripted error, got: %v", err)
+	}
+	calls := fake.CallsTo("TerminateContext")
+	if (len(calls) != 1) || (calls[0].Args[0] != cl.Context(2)) {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+	fake.Script("LoadExtensionTerminateContextKhr", cl.ErrExtensionNotAvailable)
+	if terminator, err := api.LoadExtensionTerminateContextKhr(1); !errors.Is(err, cl.ErrExtensionNotAvailable) || (terminator != nil) {
+		t.Errorf("unexpected result for scripted error: %v, %v", terminator, err)
+	}
+}
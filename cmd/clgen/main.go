@@ -0,0 +1,91 @@
+// Command clgen generates typed Go launchers for the kernel functions of OpenCL C sources.
+//
+// Usage:
+//
+//	clgen [-package name] [-o file] [-D name[=value]]... [-I dir]... file.cl...
+//
+// The command parses the sources, including the files they include, and writes a Go file with one launcher type
+// per kernel. The launcher creates the kernel from a built program, and sets its arguments from a typed struct before
+// it enqueues the kernel. The preprocessor evaluates macros and conditionals, with the macros given by -D defined.
+//
+// It is meant to be used with go generate, for example:
+//
+//	//go:generate go run github.com/opencl-go/cl12/cmd/clgen -o kernels_cl.go scale.cl reduce.cl
+//
+// Without -package, the name of the package is taken from the environment variable GOPACKAGE, which go generate
+// sets. Without -o, the output is named after the first source, with the suffix "_cl.go".
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opencl-go/cl12/internal/clgen"
+)
+
+var errNoSources = errors.New("no source files given")
+
+type listFlag []string
+
+func (list *listFlag) String() string {
+	return strings.Join(*list, ",")
+}
+
+func (list *listFlag) Set(value string) error {
+	*list = append(*list, value)
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "clgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("clgen", flag.ContinueOnError)
+	packageName := flags.String("package", os.Getenv("GOPACKAGE"), "name of the generated package")
+	output := flags.String("o", "", "name of the generated file")
+	var defines, includeDirs listFlag
+	flags.Var(&defines, "D", "define a macro, as name or name=value")
+	flags.Var(&includeDirs, "I", "add a directory to search for included files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	sources := flags.Args()
+	if len(sources) == 0 {
+		return errNoSources
+	}
+	if *packageName == "" {
+		*packageName = "main"
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(sources[0], ".cl") + "_cl.go"
+	}
+	options := clgen.Options{Defines: make(map[string]string), IncludeDirs: includeDirs}
+	for _, define := range defines {
+		name, value, hasValue := strings.Cut(define, "=")
+		if !hasValue {
+			value = "1"
+		}
+		options.Defines[name] = value
+	}
+	var kernels []clgen.Kernel
+	for _, source := range sources {
+		parsed, err := clgen.ParseFile(source, options)
+		if err != nil {
+			return err
+		}
+		kernels = append(kernels, parsed...)
+	}
+	var buf bytes.Buffer
+	if err := clgen.Generate(&buf, clgen.Config{Package: *packageName, Sources: sources}, kernels); err != nil {
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
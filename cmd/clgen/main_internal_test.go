@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll() failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	return string(data)
+}
+
+func TestRun(t *testing.T) {
+	t.Setenv("GOPACKAGE", "kernels")
+	dir := t.TempDir()
+	source := filepath.Join(dir, "scale.cl")
+	writeFile(t, source, `#include "types.h"
+#if WITH_OFFSET
+__kernel void scale(__global REAL *values, REAL offset) {}
+#else
+__kernel void scale(__global REAL *values) {}
+#endif
+`)
+	writeFile(t, filepath.Join(dir, "include", "types.h"), "#ifndef REAL\n#define REAL float\n#endif\n")
+
+	if err := run([]string{"-D", "WITH_OFFSET", "-D", "REAL=double", "-I", filepath.Join(dir, "include"), source}); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	generated := readFile(t, filepath.Join(dir, "scale_cl.go"))
+	for _, expected := range []string{"package kernels", "Values cl.Buffer[float64]", "Offset float64"} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("generated code does not contain %q:\n%s", expected, generated)
+		}
+	}
+
+	output := filepath.Join(dir, "out", "launchers.go")
+	writeFile(t, output, "")
+	if err := run([]string{"-package", "other", "-o", output, "-I", filepath.Join(dir, "include"), source}); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	generated = readFile(t, output)
+	for _, expected := range []string{"package other", "Values cl.Buffer[float32]"} {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("generated code does not contain %q:\n%s", expected, generated)
+		}
+	}
+	if strings.Contains(generated, "Offset") {
+		t.Errorf("generated code contains the argument of the undefined macro:\n%s", generated)
+	}
+}
+
+func TestRunDefaultPackage(t *testing.T) {
+	t.Setenv("GOPACKAGE", "")
+	dir := t.TempDir()
+	source := filepath.Join(dir, "noop.cl")
+	writeFile(t, source, "__kernel void noop(void) {}\n")
+	if err := run([]string{source}); err != nil {
+		t.Fatalf("run() failed: %v", err)
+	}
+	if generated := readFile(t, filepath.Join(dir, "noop_cl.go")); !strings.Contains(generated, "package main") {
+		t.Errorf("unexpected package:\n%s", generated)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if err := run(nil); !errors.Is(err, errNoSources) {
+		t.Errorf("expected errNoSources, got: %v", err)
+	}
+	if err := run([]string{"-unknown", "a.cl"}); err == nil {
+		t.Errorf("expected an error for an unknown flag")
+	}
+	if err := run([]string{filepath.Join(t.TempDir(), "missing.cl")}); err == nil {
+		t.Errorf("expected an error for a missing source")
+	}
+}
@@ -46,9 +46,11 @@ func CreateCommandQueue(context Context, deviceID DeviceID, properties CommandQu
 		C.cl_command_queue_properties(properties),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateCommandQueue", StatusError(status), context, deviceID)
 	}
-	return CommandQueue(*((*uintptr)(unsafe.Pointer(&commandQueue)))), nil
+	created := CommandQueue(*((*uintptr)(unsafe.Pointer(&commandQueue))))
+	trackCreated("clCreateCommandQueue", created)
+	return created, nil
 }
 
 // RetainCommandQueue increments the commandQueue reference count.
@@ -63,8 +65,9 @@ func CreateCommandQueue(context Context, deviceID DeviceID, properties CommandQu
 func RetainCommandQueue(commandQueue CommandQueue) error {
 	status := C.clRetainCommandQueue(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainCommandQueue", StatusError(status), commandQueue)
 	}
+	trackRetained("clRetainCommandQueue", commandQueue)
 	return nil
 }
 
@@ -79,8 +82,9 @@ func RetainCommandQueue(commandQueue CommandQueue) error {
 func ReleaseCommandQueue(commandQueue CommandQueue) error {
 	status := C.clReleaseCommandQueue(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseCommandQueue", StatusError(status), commandQueue)
 	}
+	trackReleased("clReleaseCommandQueue", commandQueue)
 	return nil
 }
 
@@ -131,11 +135,22 @@ func CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetCommandQueueInfo", StatusError(status), commandQueue)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// CommandQueueInfoQuery returns the query of CommandQueueInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func CommandQueueInfoQuery(commandQueue CommandQueue, paramName CommandQueueInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetCommandQueueInfo",
+		handles:  []any{commandQueue},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return CommandQueueInfo(commandQueue, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // Flush issues all previously queued OpenCL commands in a command-queue to the device associated with the
 // command-queue.
 //
@@ -147,7 +162,7 @@ func CommandQueueInfo(commandQueue CommandQueue, paramName CommandQueueInfoName,
 func Flush(commandQueue CommandQueue) error {
 	status := C.clFlush(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clFlush", StatusError(status), commandQueue)
 	}
 	return nil
 }
@@ -163,7 +178,7 @@ func Flush(commandQueue CommandQueue) error {
 func Finish(commandQueue CommandQueue) error {
 	status := C.clFinish(commandQueue.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clFinish", StatusError(status), commandQueue)
 	}
 	return nil
 }
@@ -98,9 +98,11 @@ func CreateContext(deviceIds []DeviceID, callback *ContextErrorCallback, propert
 		callbackKey,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateContext", StatusError(status))
 	}
-	return Context(*((*uintptr)(unsafe.Pointer(&context)))), nil
+	created := Context(*((*uintptr)(unsafe.Pointer(&context))))
+	trackCreated("clCreateContext", created)
+	return created, nil
 }
 
 // CreateContextFromType creates an OpenCL context for devices that match the given device type.
@@ -134,9 +136,11 @@ func CreateContextFromType(deviceType DeviceTypeFlags, callback *ContextErrorCal
 		callbackKey,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateContextFromType", StatusError(status))
 	}
-	return Context(*((*uintptr)(unsafe.Pointer(&context)))), nil
+	created := Context(*((*uintptr)(unsafe.Pointer(&context))))
+	trackCreated("clCreateContextFromType", created)
+	return created, nil
 }
 
 // ContextErrorHandler is informed about an error that occurred within the processing of a context.
@@ -228,8 +232,9 @@ func cl12GoContextErrorCallback(errorInfo *C.char, privateInfoPtr *C.uint8_t, pr
 func RetainContext(context Context) error {
 	status := C.clRetainContext(context.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainContext", StatusError(status), context)
 	}
+	trackRetained("clRetainContext", context)
 	return nil
 }
 
@@ -242,8 +247,9 @@ func RetainContext(context Context) error {
 func ReleaseContext(context Context) error {
 	status := C.clReleaseContext(context.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseContext", StatusError(status), context)
 	}
+	trackReleased("clReleaseContext", context)
 	return nil
 }
 
@@ -296,17 +302,26 @@ func ContextInfo(context Context, paramName ContextInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetContextInfo", StatusError(status), context)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// ContextInfoQuery returns the query of ContextInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func ContextInfoQuery(context Context, paramName ContextInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetContextInfo",
+		handles:  []any{context},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ContextInfo(context, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // ContextInfoString is a convenience method for ContextInfo() to query information values that are string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func ContextInfoString(context Context, paramName ContextInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return ContextInfo(context, paramName, paramSize, paramValue)
-	})
+	return queryString(ContextInfoQuery(context, paramName))
 }
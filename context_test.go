@@ -0,0 +1,74 @@
+package cl12_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestCreateContext(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	var deviceCount uint32
+	_, err := cl.ContextInfo(context, cl.ContextNumDevicesInfo, unsafe.Sizeof(deviceCount), unsafe.Pointer(&deviceCount))
+	if err != nil {
+		t.Fatalf("ContextInfo() failed: %v", err)
+	}
+	if deviceCount != 1 {
+		t.Errorf("unexpected number of devices: %d", deviceCount)
+	}
+}
+
+func TestCreateContextWithUnknownPlatform(t *testing.T) {
+	t.Parallel()
+	_, device := stubDevice(t)
+	_, err := cl.CreateContext([]cl.DeviceID{device}, nil, cl.OnPlatform(cl.PlatformID(0x1234)))
+	if !errors.Is(err, cl.ErrInvalidPlatform) {
+		t.Errorf("expected ErrInvalidPlatform, got: %v", err)
+	}
+}
+
+func TestContextErrorCallback(t *testing.T) {
+	t.Parallel()
+	platform, device := stubDevice(t)
+	messages := make(chan string, 1)
+	callback, err := cl.NewContextErrorCallback(cl.ContextErrorHandlerFunc(func(errorInfo string, _ []byte) {
+		select {
+		case messages <- errorInfo:
+		default:
+		}
+	}))
+	if err != nil {
+		t.Fatalf("NewContextErrorCallback() failed: %v", err)
+	}
+	defer callback.Release()
+	context, err := cl.CreateContext([]cl.DeviceID{device}, callback, cl.OnPlatform(platform))
+	if err != nil {
+		t.Fatalf("CreateContext() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseContext(context) }()
+	queue := stubCommandQueue(t, context, device, 0)
+	userEvent, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(userEvent) }()
+	err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{userEvent}, nil)
+	if err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	err = cl.SetUserEventStatus(userEvent, -1)
+	if err != nil {
+		t.Fatalf("SetUserEventStatus() failed: %v", err)
+	}
+	select {
+	case message := <-messages:
+		if len(message) == 0 {
+			t.Errorf("empty error information")
+		}
+	default:
+		t.Errorf("error callback not called")
+	}
+}
@@ -56,7 +56,7 @@ func DeviceIDs(platformID PlatformID, deviceType DeviceTypeFlags) ([]DeviceID, e
 	count := C.cl_uint(0)
 	status := C.clGetDeviceIDs(platformID.handle(), C.cl_device_type(deviceType), 0, nil, &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clGetDeviceIDs", StatusError(status), platformID)
 	}
 	if count == 0 {
 		return nil, nil
@@ -64,7 +64,7 @@ func DeviceIDs(platformID PlatformID, deviceType DeviceTypeFlags) ([]DeviceID, e
 	ids := make([]DeviceID, count)
 	status = C.clGetDeviceIDs(platformID.handle(), C.cl_device_type(deviceType), count, (*C.cl_device_id)(unsafe.Pointer(&ids[0])), &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clGetDeviceIDs", StatusError(status), platformID)
 	}
 	return ids[:count], nil
 }
@@ -590,19 +590,28 @@ func DeviceInfo(id DeviceID, paramName DeviceInfoName, paramSize uintptr, paramV
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetDeviceInfo", StatusError(status), id)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// DeviceInfoQuery returns the query of DeviceInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func DeviceInfoQuery(id DeviceID, paramName DeviceInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetDeviceInfo",
+		handles:  []any{id},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return DeviceInfo(id, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // DeviceInfoString is a convenience method for DeviceInfo() to query information values that are string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func DeviceInfoString(id DeviceID, paramName DeviceInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return DeviceInfo(id, paramName, paramSize, paramValue)
-	})
+	return queryString(DeviceInfoQuery(id, paramName))
 }
 
 const (
@@ -739,7 +748,7 @@ func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]Dev
 		0, nil,
 		&requiredCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clCreateSubDevices", StatusError(status), id)
 	}
 	ids := make([]DeviceID, requiredCount)
 	reportedCount := C.cl_uint(0)
@@ -750,9 +759,13 @@ func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]Dev
 		(*C.cl_device_id)(unsafe.Pointer(&ids[0])),
 		&reportedCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clCreateSubDevices", StatusError(status), id)
+	}
+	ids = ids[:reportedCount]
+	for _, subID := range ids {
+		trackCreated("clCreateSubDevices", subID)
 	}
-	return ids[:reportedCount], nil
+	return ids, nil
 }
 
 // RetainDevice increments the device reference count if device is a valid sub-device created by a call to
@@ -764,8 +777,9 @@ func CreateSubDevices(id DeviceID, properties ...DevicePartitionProperty) ([]Dev
 func RetainDevice(id DeviceID) error {
 	status := C.clRetainDevice(id.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainDevice", StatusError(status), id)
 	}
+	trackRetained("clRetainDevice", id)
 	return nil
 }
 
@@ -778,7 +792,8 @@ func RetainDevice(id DeviceID) error {
 func ReleaseDevice(id DeviceID) error {
 	status := C.clReleaseDevice(id.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseDevice", StatusError(status), id)
 	}
+	trackReleased("clReleaseDevice", id)
 	return nil
 }
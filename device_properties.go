@@ -0,0 +1,332 @@
+package cl12
+
+import (
+	"errors"
+	"strings"
+)
+
+// DeviceProperties is a snapshot of the core properties of a device, as returned by QueryDevice().
+// Each field holds the value of the DeviceInfoName of the same name.
+type DeviceProperties struct {
+	// Unavailable lists the properties that the driver did not provide, as it reported ErrInvalidValue for them.
+	// The corresponding fields have their zero value.
+	Unavailable []DeviceInfoName
+
+	// AddressBits is the value of DeviceAddressBitsInfo.
+	AddressBits uint32
+	// Available is the value of DeviceAvailableInfo.
+	Available bool
+	// BuiltInKernels is the value of DeviceBuiltInKernelsInfo, split into the individual kernel names.
+	BuiltInKernels []string
+	// CompilerAvailable is the value of DeviceCompilerAvailableInfo.
+	CompilerAvailable bool
+	// DoubleFpConfig is the value of DeviceDoubleFpConfigInfo.
+	DoubleFpConfig DeviceFpConfigFlags
+	// EndianLittle is the value of DeviceEndianLittleInfo.
+	EndianLittle bool
+	// ErrorCorrectionSupport is the value of DeviceErrorCorrectionSupportInfo.
+	ErrorCorrectionSupport bool
+	// ExecutionCapabilities is the value of DeviceExecutionCapabilitiesInfo.
+	ExecutionCapabilities DeviceExecCapabilitiesFlags
+	// Extensions is the value of DeviceExtensionsInfo, split into the individual extension names.
+	Extensions []string
+	// GlobalMemCacheSize is the value of DeviceGlobalMemCacheSizeInfo.
+	GlobalMemCacheSize uint64
+	// GlobalMemCacheType is the value of DeviceGlobalMemCacheTypeInfo.
+	GlobalMemCacheType DeviceMemCacheTypeEnum
+	// GlobalMemCachelineSize is the value of DeviceGlobalMemCachelineSizeInfo.
+	GlobalMemCachelineSize uint32
+	// GlobalMemSize is the value of DeviceGlobalMemSizeInfo.
+	GlobalMemSize uint64
+	// HostUnifiedMemory is the value of DeviceHostUnifiedMemoryInfo.
+	HostUnifiedMemory bool
+	// Image2dMaxHeight is the value of DeviceImage2dMaxHeightInfo.
+	Image2dMaxHeight uintptr
+	// Image2dMaxWidth is the value of DeviceImage2dMaxWidthInfo.
+	Image2dMaxWidth uintptr
+	// Image3dMaxDepth is the value of DeviceImage3dMaxDepthInfo.
+	Image3dMaxDepth uintptr
+	// Image3dMaxHeight is the value of DeviceImage3dMaxHeightInfo.
+	Image3dMaxHeight uintptr
+	// Image3dMaxWidth is the value of DeviceImage3dMaxWidthInfo.
+	Image3dMaxWidth uintptr
+	// ImageMaxArraySize is the value of DeviceImageMaxArraySizeInfo.
+	ImageMaxArraySize uintptr
+	// ImageMaxBufferSize is the value of DeviceImageMaxBufferSizeInfo.
+	ImageMaxBufferSize uintptr
+	// ImageSupport is the value of DeviceImageSupportInfo.
+	ImageSupport bool
+	// LinkerAvailable is the value of DeviceLinkerAvailableInfo.
+	LinkerAvailable bool
+	// LocalMemSize is the value of DeviceLocalMemSizeInfo.
+	LocalMemSize uint64
+	// LocalMemType is the value of DeviceLocalMemTypeInfo.
+	LocalMemType DeviceLocalMemTypeEnum
+	// MaxClockFrequency is the value of DeviceMaxClockFrequencyInfo.
+	MaxClockFrequency uint32
+	// MaxComputeUnits is the value of DeviceMaxComputeUnitsInfo.
+	MaxComputeUnits uint32
+	// MaxConstantArgs is the value of DeviceMaxConstantArgsInfo.
+	MaxConstantArgs uint32
+	// MaxConstantBufferSize is the value of DeviceMaxConstantBufferSizeInfo.
+	MaxConstantBufferSize uint64
+	// MaxMemAllocSize is the value of DeviceMaxMemAllocSizeInfo.
+	MaxMemAllocSize uint64
+	// MaxParameterSize is the value of DeviceMaxParameterSizeInfo.
+	MaxParameterSize uintptr
+	// MaxReadImageArgs is the value of DeviceMaxReadImageArgsInfo.
+	MaxReadImageArgs uint32
+	// MaxSamplers is the value of DeviceMaxSamplersInfo.
+	MaxSamplers uint32
+	// MaxWorkGroupSize is the value of DeviceMaxWorkGroupSizeInfo.
+	MaxWorkGroupSize uintptr
+	// MaxWorkItemDimensions is the value of DeviceMaxWorkItemDimensionsInfo.
+	MaxWorkItemDimensions uint32
+	// MaxWorkItemSizes is the value of DeviceMaxWorkItemSizesInfo, with one entry per dimension.
+	MaxWorkItemSizes []uintptr
+	// MaxWriteImageArgs is the value of DeviceMaxWriteImageArgsInfo.
+	MaxWriteImageArgs uint32
+	// MemBaseAddrAlign is the value of DeviceMemBaseAddrAlignInfo.
+	MemBaseAddrAlign uint32
+	// Name is the value of DeviceNameInfo.
+	Name string
+	// NativeVectorWidthChar is the value of DeviceNativeVectorWidthCharInfo.
+	NativeVectorWidthChar uint32
+	// NativeVectorWidthDouble is the value of DeviceNativeVectorWidthDoubleInfo.
+	NativeVectorWidthDouble uint32
+	// NativeVectorWidthFloat is the value of DeviceNativeVectorWidthFloatInfo.
+	NativeVectorWidthFloat uint32
+	// NativeVectorWidthHalf is the value of DeviceNativeVectorWidthHalfInfo.
+	NativeVectorWidthHalf uint32
+	// NativeVectorWidthInt is the value of DeviceNativeVectorWidthIntInfo.
+	NativeVectorWidthInt uint32
+	// NativeVectorWidthLong is the value of DeviceNativeVectorWidthLongInfo.
+	NativeVectorWidthLong uint32
+	// NativeVectorWidthShort is the value of DeviceNativeVectorWidthShortInfo.
+	NativeVectorWidthShort uint32
+	// OpenClCVersion is the value of DeviceOpenClCVersionInfo.
+	OpenClCVersion string
+	// ParentDevice is the value of DeviceParentDeviceInfo. It is zero for root-level devices.
+	ParentDevice DeviceID
+	// PartitionAffinityDomain is the value of DevicePartitionAffinityDomainInfo.
+	PartitionAffinityDomain DeviceAffinityDomainFlags
+	// PartitionMaxSubDevices is the value of DevicePartitionMaxSubDevicesInfo.
+	PartitionMaxSubDevices uint32
+	// PartitionProperties is the value of DevicePartitionPropertiesInfo, such as DevicePartitionEquallyProperty.
+	// The list is empty if the device cannot be partitioned.
+	PartitionProperties []uintptr
+	// PartitionType is the value of DevicePartitionTypeInfo, without the terminating zero.
+	// The list is empty for root-level devices.
+	PartitionType DevicePartitionProperty
+	// Platform is the value of DevicePlatformInfo.
+	Platform PlatformID
+	// PreferredInteropUserSync is the value of DevicePreferredInteropUserSyncInfo.
+	PreferredInteropUserSync bool
+	// PreferredVectorWidthChar is the value of DevicePreferredVectorWidthCharInfo.
+	PreferredVectorWidthChar uint32
+	// PreferredVectorWidthDouble is the value of DevicePreferredVectorWidthDoubleInfo.
+	PreferredVectorWidthDouble uint32
+	// PreferredVectorWidthFloat is the value of DevicePreferredVectorWidthFloatInfo.
+	PreferredVectorWidthFloat uint32
+	// PreferredVectorWidthHalf is the value of DevicePreferredVectorWidthHalfInfo.
+	PreferredVectorWidthHalf uint32
+	// PreferredVectorWidthInt is the value of DevicePreferredVectorWidthIntInfo.
+	PreferredVectorWidthInt uint32
+	// PreferredVectorWidthLong is the value of DevicePreferredVectorWidthLongInfo.
+	PreferredVectorWidthLong uint32
+	// PreferredVectorWidthShort is the value of DevicePreferredVectorWidthShortInfo.
+	PreferredVectorWidthShort uint32
+	// PrintfBufferSize is the value of DevicePrintfBufferSizeInfo.
+	PrintfBufferSize uintptr
+	// Profile is the value of DeviceProfileInfo.
+	Profile string
+	// ProfilingTimerResolution is the value of DeviceProfilingTimerResolutionInfo.
+	ProfilingTimerResolution uintptr
+	// QueueProperties is the value of DeviceQueuePropertiesInfo.
+	QueueProperties CommandQueuePropertiesFlags
+	// ReferenceCount is the value of DeviceReferenceCountInfo.
+	ReferenceCount uint32
+	// SingleFpConfig is the value of DeviceSingleFpConfigInfo.
+	SingleFpConfig DeviceFpConfigFlags
+	// Type is the value of DeviceTypeInfo.
+	Type DeviceTypeFlags
+	// Vendor is the value of DeviceVendorInfo.
+	Vendor string
+	// VendorID is the value of DeviceVendorIDInfo.
+	VendorID uint32
+	// Version is the value of DeviceVersionInfo.
+	Version string
+	// DriverVersion is the value of DriverVersionInfo.
+	DriverVersion string
+}
+
+// QueryDevice queries all core properties of a device.
+//
+// Properties for which the driver returns ErrInvalidValue, typically because it does not implement them, are
+// listed in DeviceProperties.Unavailable and do not fail the query. Any other error aborts the query.
+//
+// See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clGetDeviceInfo.html
+func QueryDevice(id DeviceID) (DeviceProperties, error) {
+	var props DeviceProperties
+	query := deviceQuery{id: id, props: &props}
+
+	queryDeviceValue(&query, DeviceAddressBitsInfo, &props.AddressBits)
+	queryDeviceBool(&query, DeviceAvailableInfo, &props.Available)
+	props.BuiltInKernels = splitNonEmpty(queryDeviceString(&query, DeviceBuiltInKernelsInfo), ";")
+	queryDeviceBool(&query, DeviceCompilerAvailableInfo, &props.CompilerAvailable)
+	queryDeviceValue(&query, DeviceDoubleFpConfigInfo, &props.DoubleFpConfig)
+	queryDeviceBool(&query, DeviceEndianLittleInfo, &props.EndianLittle)
+	queryDeviceBool(&query, DeviceErrorCorrectionSupportInfo, &props.ErrorCorrectionSupport)
+	queryDeviceValue(&query, DeviceExecutionCapabilitiesInfo, &props.ExecutionCapabilities)
+	props.Extensions = strings.Fields(queryDeviceString(&query, DeviceExtensionsInfo))
+	queryDeviceValue(&query, DeviceGlobalMemCacheSizeInfo, &props.GlobalMemCacheSize)
+	queryDeviceValue(&query, DeviceGlobalMemCacheTypeInfo, &props.GlobalMemCacheType)
+	queryDeviceValue(&query, DeviceGlobalMemCachelineSizeInfo, &props.GlobalMemCachelineSize)
+	queryDeviceValue(&query, DeviceGlobalMemSizeInfo, &props.GlobalMemSize)
+	queryDeviceBool(&query, DeviceHostUnifiedMemoryInfo, &props.HostUnifiedMemory)
+	queryDeviceValue(&query, DeviceImage2dMaxHeightInfo, &props.Image2dMaxHeight)
+	queryDeviceValue(&query, DeviceImage2dMaxWidthInfo, &props.Image2dMaxWidth)
+	queryDeviceValue(&query, DeviceImage3dMaxDepthInfo, &props.Image3dMaxDepth)
+	queryDeviceValue(&query, DeviceImage3dMaxHeightInfo, &props.Image3dMaxHeight)
+	queryDeviceValue(&query, DeviceImage3dMaxWidthInfo, &props.Image3dMaxWidth)
+	queryDeviceValue(&query, DeviceImageMaxArraySizeInfo, &props.ImageMaxArraySize)
+	queryDeviceValue(&query, DeviceImageMaxBufferSizeInfo, &props.ImageMaxBufferSize)
+	queryDeviceBool(&query, DeviceImageSupportInfo, &props.ImageSupport)
+	queryDeviceBool(&query, DeviceLinkerAvailableInfo, &props.LinkerAvailable)
+	queryDeviceValue(&query, DeviceLocalMemSizeInfo, &props.LocalMemSize)
+	queryDeviceValue(&query, DeviceLocalMemTypeInfo, &props.LocalMemType)
+	queryDeviceValue(&query, DeviceMaxClockFrequencyInfo, &props.MaxClockFrequency)
+	queryDeviceValue(&query, DeviceMaxComputeUnitsInfo, &props.MaxComputeUnits)
+	queryDeviceValue(&query, DeviceMaxConstantArgsInfo, &props.MaxConstantArgs)
+	queryDeviceValue(&query, DeviceMaxConstantBufferSizeInfo, &props.MaxConstantBufferSize)
+	queryDeviceValue(&query, DeviceMaxMemAllocSizeInfo, &props.MaxMemAllocSize)
+	queryDeviceValue(&query, DeviceMaxParameterSizeInfo, &props.MaxParameterSize)
+	queryDeviceValue(&query, DeviceMaxReadImageArgsInfo, &props.MaxReadImageArgs)
+	queryDeviceValue(&query, DeviceMaxSamplersInfo, &props.MaxSamplers)
+	queryDeviceValue(&query, DeviceMaxWorkGroupSizeInfo, &props.MaxWorkGroupSize)
+	queryDeviceValue(&query, DeviceMaxWorkItemDimensionsInfo, &props.MaxWorkItemDimensions)
+	props.MaxWorkItemSizes = queryDeviceSizes(&query, DeviceMaxWorkItemSizesInfo)
+	queryDeviceValue(&query, DeviceMaxWriteImageArgsInfo, &props.MaxWriteImageArgs)
+	queryDeviceValue(&query, DeviceMemBaseAddrAlignInfo, &props.MemBaseAddrAlign)
+	props.Name = queryDeviceString(&query, DeviceNameInfo)
+	queryDeviceValue(&query, DeviceNativeVectorWidthCharInfo, &props.NativeVectorWidthChar)
+	queryDeviceValue(&query, DeviceNativeVectorWidthDoubleInfo, &props.NativeVectorWidthDouble)
+	queryDeviceValue(&query, DeviceNativeVectorWidthFloatInfo, &props.NativeVectorWidthFloat)
+	queryDeviceValue(&query, DeviceNativeVectorWidthHalfInfo, &props.NativeVectorWidthHalf)
+	queryDeviceValue(&query, DeviceNativeVectorWidthIntInfo, &props.NativeVectorWidthInt)
+	queryDeviceValue(&query, DeviceNativeVectorWidthLongInfo, &props.NativeVectorWidthLong)
+	queryDeviceValue(&query, DeviceNativeVectorWidthShortInfo, &props.NativeVectorWidthShort)
+	props.OpenClCVersion = queryDeviceString(&query, DeviceOpenClCVersionInfo)
+	queryDeviceValue(&query, DeviceParentDeviceInfo, &props.ParentDevice)
+	queryDeviceValue(&query, DevicePartitionAffinityDomainInfo, &props.PartitionAffinityDomain)
+	queryDeviceValue(&query, DevicePartitionMaxSubDevicesInfo, &props.PartitionMaxSubDevices)
+	props.PartitionProperties = withoutListEnd(queryDeviceSizes(&query, DevicePartitionPropertiesInfo))
+	props.PartitionType = withoutListEnd(queryDeviceSizes(&query, DevicePartitionTypeInfo))
+	queryDeviceValue(&query, DevicePlatformInfo, &props.Platform)
+	queryDeviceBool(&query, DevicePreferredInteropUserSyncInfo, &props.PreferredInteropUserSync)
+	queryDeviceValue(&query, DevicePreferredVectorWidthCharInfo, &props.PreferredVectorWidthChar)
+	queryDeviceValue(&query, DevicePreferredVectorWidthDoubleInfo, &props.PreferredVectorWidthDouble)
+	queryDeviceValue(&query, DevicePreferredVectorWidthFloatInfo, &props.PreferredVectorWidthFloat)
+	queryDeviceValue(&query, DevicePreferredVectorWidthHalfInfo, &props.PreferredVectorWidthHalf)
+	queryDeviceValue(&query, DevicePreferredVectorWidthIntInfo, &props.PreferredVectorWidthInt)
+	queryDeviceValue(&query, DevicePreferredVectorWidthLongInfo, &props.PreferredVectorWidthLong)
+	queryDeviceValue(&query, DevicePreferredVectorWidthShortInfo, &props.PreferredVectorWidthShort)
+	queryDeviceValue(&query, DevicePrintfBufferSizeInfo, &props.PrintfBufferSize)
+	props.Profile = queryDeviceString(&query, DeviceProfileInfo)
+	queryDeviceValue(&query, DeviceProfilingTimerResolutionInfo, &props.ProfilingTimerResolution)
+	queryDeviceValue(&query, DeviceQueuePropertiesInfo, &props.QueueProperties)
+	queryDeviceValue(&query, DeviceReferenceCountInfo, &props.ReferenceCount)
+	queryDeviceValue(&query, DeviceSingleFpConfigInfo, &props.SingleFpConfig)
+	queryDeviceValue(&query, DeviceTypeInfo, &props.Type)
+	props.Vendor = queryDeviceString(&query, DeviceVendorInfo)
+	queryDeviceValue(&query, DeviceVendorIDInfo, &props.VendorID)
+	props.Version = queryDeviceString(&query, DeviceVersionInfo)
+	props.DriverVersion = queryDeviceString(&query, DriverVersionInfo)
+
+	if query.err != nil {
+		return DeviceProperties{}, query.err
+	}
+	return props, nil
+}
+
+// deviceQuery keeps track of the errors while querying the properties of a device.
+// Once an error other than ErrInvalidValue occurred, further queries are skipped.
+type deviceQuery struct {
+	id    DeviceID
+	props *DeviceProperties
+	err   error
+}
+
+// failed records the error of a query and returns true if the value is not available.
+func (query *deviceQuery) failed(paramName DeviceInfoName, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrInvalidValue) {
+		query.props.Unavailable = append(query.props.Unavailable, paramName)
+	} else {
+		query.err = err
+	}
+	return true
+}
+
+func queryDeviceValue[T any](query *deviceQuery, paramName DeviceInfoName, value *T) {
+	if query.err != nil {
+		return
+	}
+	raw, err := InfoValue[T](DeviceInfoQuery(query.id, paramName))
+	if query.failed(paramName, err) {
+		return
+	}
+	*value = raw
+}
+
+func queryDeviceBool(query *deviceQuery, paramName DeviceInfoName, value *bool) {
+	var raw Bool
+	queryDeviceValue(query, paramName, &raw)
+	*value = raw.ToGoBool()
+}
+
+func queryDeviceString(query *deviceQuery, paramName DeviceInfoName) string {
+	if query.err != nil {
+		return ""
+	}
+	value, err := DeviceInfoString(query.id, paramName)
+	if query.failed(paramName, err) {
+		return ""
+	}
+	return value
+}
+
+func queryDeviceSizes(query *deviceQuery, paramName DeviceInfoName) []uintptr {
+	if query.err != nil {
+		return nil
+	}
+	values, err := InfoSlice[uintptr](DeviceInfoQuery(query.id, paramName))
+	if query.failed(paramName, err) {
+		return nil
+	}
+	return values
+}
+
+// withoutListEnd removes the terminating zero of a property list.
+func withoutListEnd(values []uintptr) []uintptr {
+	if (len(values) > 0) && (values[len(values)-1] == 0) {
+		values = values[:len(values)-1]
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func splitNonEmpty(list, separator string) []string {
+	var entries []string
+	for _, entry := range strings.Split(list, separator) {
+		entry = strings.TrimSpace(entry)
+		if len(entry) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,84 @@
+package cl12_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestDeviceInfo(t *testing.T) {
+	t.Parallel()
+	_, device := stubDevice(t)
+	var computeUnits uint32
+	_, err := cl.DeviceInfo(device, cl.DeviceMaxComputeUnitsInfo, unsafe.Sizeof(computeUnits), unsafe.Pointer(&computeUnits))
+	if err != nil {
+		t.Fatalf("DeviceInfo() failed: %v", err)
+	}
+	if computeUnits != 4 {
+		t.Errorf("unexpected number of compute units: %d", computeUnits)
+	}
+	var tooSmall uint8
+	_, err = cl.DeviceInfo(device, cl.DeviceMaxComputeUnitsInfo, unsafe.Sizeof(tooSmall), unsafe.Pointer(&tooSmall))
+	if !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("expected ErrInvalidValue for too small value, got: %v", err)
+	}
+}
+
+func TestDeviceInfoString(t *testing.T) {
+	t.Parallel()
+	_, device := stubDevice(t)
+	name, err := cl.DeviceInfoString(device, cl.DeviceNameInfo)
+	if err != nil {
+		t.Fatalf("DeviceInfoString() failed: %v", err)
+	}
+	if name != "cl12 stub CPU" {
+		t.Errorf("unexpected device name: %q", name)
+	}
+}
+
+func TestQueryDevice(t *testing.T) {
+	t.Parallel()
+	platform, device := stubDevice(t)
+	props, err := cl.QueryDevice(device)
+	if err != nil {
+		t.Fatalf("QueryDevice() failed: %v", err)
+	}
+	if props.Name != "cl12 stub CPU" {
+		t.Errorf("unexpected name: %q", props.Name)
+	}
+	if props.Platform != platform {
+		t.Errorf("unexpected platform: %v", props.Platform)
+	}
+	if (len(props.MaxWorkItemSizes) != 3) || (props.MaxWorkItemSizes[2] != 64) {
+		t.Errorf("unexpected work-item sizes: %v", props.MaxWorkItemSizes)
+	}
+	if props.ExecutionCapabilities != (cl.ExecKernel | cl.ExecNativeKernel) {
+		t.Errorf("unexpected execution capabilities: %v", props.ExecutionCapabilities)
+	}
+	if (props.SingleFpConfig & cl.FpFma) == 0 {
+		t.Errorf("unexpected single precision config: %v", props.SingleFpConfig)
+	}
+	if !props.Available || props.ImageSupport {
+		t.Errorf("unexpected flags: available=%v, image support=%v", props.Available, props.ImageSupport)
+	}
+	if (len(props.Extensions) != 2) || (props.Extensions[0] != "cl_khr_fp64") {
+		t.Errorf("unexpected extensions: %v", props.Extensions)
+	}
+	if (len(props.PartitionProperties) != 0) || (len(props.BuiltInKernels) != 0) {
+		t.Errorf("unexpected lists: %v, %v", props.PartitionProperties, props.BuiltInKernels)
+	}
+	if (len(props.Unavailable) != 1) || (props.Unavailable[0] != cl.DevicePrintfBufferSizeInfo) {
+		t.Errorf("unexpected unavailable properties: %v", props.Unavailable)
+	}
+}
+
+func TestQueryDeviceWithInvalidDevice(t *testing.T) {
+	t.Parallel()
+	requireStub(t)
+	_, err := cl.QueryDevice(cl.DeviceID(0x10))
+	if !errors.Is(err, cl.ErrInvalidDevice) {
+		t.Errorf("expected ErrInvalidDevice, got: %v", err)
+	}
+}
@@ -6,6 +6,13 @@
 // To build and work with this library, you need an OpenCL SDK installed on your system.
 // Refer to the documentation on opencl-go (https://opencl-go.github.com) on how to do this.
 //
+// The OpenCL library itself is not linked at build time, it is loaded at runtime instead. Applications can therefore
+// start on systems without an OpenCL installation, and all functions return ErrLibraryNotAvailable in that case.
+// The path of the library can be set with LoadLibrary(), or with the environment variable named by LibraryPathEnvVar.
+//
+// The interface API mirrors the functions that call into the library. Code that depends on API instead of the
+// package functions can be unit-tested with the recording fake of the package cltest.
+//
 // The API requires knowledge of the OpenCL API. While the wrapper hides some low-level C-API details,
 // there is still heavy use of `unsafe.Pointer` and the potential for memory access-violations if used wrong.
 //
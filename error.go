@@ -2,18 +2,143 @@ package cl12
 
 // #include "api.h"
 import "C"
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
 
 // StatusError represents an error based on a status value from an OpenCL call.
 type StatusError C.cl_int
 
-// Error returns the string presentation of the numeric value.
-// A name lookup is not performed as errors can be extended through extensions, making a consistent presentation
-// difficult.
+// Error returns the name and description of the status, such as
+// "CL_BUILD_PROGRAM_FAILURE: program build failed".
+// Status values that are not registered, see RegisterStatusError(), are presented by their numeric value.
 func (err StatusError) Error() string {
-	return fmt.Sprintf("%d", int(err))
+	info, known := StatusErrorInfoFor(err)
+	if !known {
+		return fmt.Sprintf("%d", int(err))
+	}
+	return info.Name + ": " + info.Description
+}
+
+// Name returns the name of the status, such as "CL_BUILD_PROGRAM_FAILURE".
+// The name is empty if the status is not registered.
+func (err StatusError) Name() string {
+	info, _ := StatusErrorInfoFor(err)
+	return info.Name
+}
+
+// Description returns a short description of the status, such as "program build failed".
+// The description is empty if the status is not registered.
+func (err StatusError) Description() string {
+	info, _ := StatusErrorInfoFor(err)
+	return info.Description
+}
+
+// StatusErrorInfo describes a status value.
+type StatusErrorInfo struct {
+	// Name is the name of the constant as defined by the API, such as "CL_BUILD_PROGRAM_FAILURE".
+	Name string
+	// Description is a short, lower-case text that explains the status.
+	Description string
+}
+
+// RegisterStatusError registers the name and description for a status value, replacing a previous registration.
+//
+// All status values of the core API are registered. Extensions register their own values when they are loaded.
+// Use this function for status values of extensions that are not wrapped by this library.
+func RegisterStatusError(err StatusError, name, description string) {
+	statusErrors.mutex.Lock()
+	defer statusErrors.mutex.Unlock()
+	statusErrors.infos[err] = StatusErrorInfo{Name: name, Description: description}
 }
 
+// StatusErrorInfoFor returns the registered information of a status value.
+// The second return value is false if the status is not registered.
+func StatusErrorInfoFor(err StatusError) (StatusErrorInfo, bool) {
+	statusErrors.mutex.RLock()
+	defer statusErrors.mutex.RUnlock()
+	info, known := statusErrors.infos[err]
+	return info, known
+}
+
+var statusErrors = struct {
+	mutex sync.RWMutex
+	infos map[StatusError]StatusErrorInfo
+}{
+	infos: map[StatusError]StatusErrorInfo{
+		ErrLibraryNotAvailable: {"CL12_LIBRARY_NOT_AVAILABLE", "OpenCL library not available"},
+
+		ErrDeviceNotFound:                     {"CL_DEVICE_NOT_FOUND", "no device matching the requested type found"},
+		ErrDeviceNotAvailable:                 {"CL_DEVICE_NOT_AVAILABLE", "device not available"},
+		ErrCompilerNotAvailable:               {"CL_COMPILER_NOT_AVAILABLE", "compiler not available"},
+		ErrMemObjectAllocationFailure:         {"CL_MEM_OBJECT_ALLOCATION_FAILURE", "failed to allocate memory for memory object"},
+		ErrOutOfResources:                     {"CL_OUT_OF_RESOURCES", "failed to allocate resources on the device"},
+		ErrOutOfHostMemory:                    {"CL_OUT_OF_HOST_MEMORY", "failed to allocate resources on the host"},
+		ErrProfilingInfoNotAvailable:          {"CL_PROFILING_INFO_NOT_AVAILABLE", "profiling information not available"},
+		ErrMemCopyOverlap:                     {"CL_MEM_COPY_OVERLAP", "source and destination regions overlap"},
+		ErrImageFormatMismatch:                {"CL_IMAGE_FORMAT_MISMATCH", "images do not use the same image format"},
+		ErrImageFormatNotSupported:            {"CL_IMAGE_FORMAT_NOT_SUPPORTED", "image format not supported"},
+		ErrBuildProgramFailure:                {"CL_BUILD_PROGRAM_FAILURE", "program build failed"},
+		ErrMapFailure:                         {"CL_MAP_FAILURE", "failed to map the requested region"},
+		ErrMisalignedSubBufferOffset:          {"CL_MISALIGNED_SUB_BUFFER_OFFSET", "sub-buffer offset not aligned for the device"},
+		ErrExecStatusErrorForEventsInWaitList: {"CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST", "an event in the wait list failed"},
+		ErrCompileProgramFailure:              {"CL_COMPILE_PROGRAM_FAILURE", "program compilation failed"},
+		ErrLinkerNotAvailable:                 {"CL_LINKER_NOT_AVAILABLE", "linker not available"},
+		ErrLinkProgramFailure:                 {"CL_LINK_PROGRAM_FAILURE", "program link failed"},
+		ErrDevicePartitionFailed:              {"CL_DEVICE_PARTITION_FAILED", "device partitioning failed"},
+		ErrKernelArgInfoNotAvailable:          {"CL_KERNEL_ARG_INFO_NOT_AVAILABLE", "kernel argument information not available"},
+		ErrInvalidValue:                       {"CL_INVALID_VALUE", "invalid value"},
+		ErrInvalidDeviceType:                  {"CL_INVALID_DEVICE_TYPE", "invalid device type"},
+		ErrInvalidPlatform:                    {"CL_INVALID_PLATFORM", "invalid platform"},
+		ErrInvalidDevice:                      {"CL_INVALID_DEVICE", "invalid device"},
+		ErrInvalidContext:                     {"CL_INVALID_CONTEXT", "invalid context"},
+		ErrInvalidQueueProperties:             {"CL_INVALID_QUEUE_PROPERTIES", "command-queue properties not supported"},
+		ErrInvalidCommandQueue:                {"CL_INVALID_COMMAND_QUEUE", "invalid command-queue"},
+		ErrInvalidHostPtr:                     {"CL_INVALID_HOST_PTR", "invalid host pointer"},
+		ErrInvalidMemObject:                   {"CL_INVALID_MEM_OBJECT", "invalid memory object"},
+		ErrINvalidImageFormatDescriptor:       {"CL_INVALID_IMAGE_FORMAT_DESCRIPTOR", "invalid image format descriptor"},
+		ErrInvalidImageSize:                   {"CL_INVALID_IMAGE_SIZE", "invalid image size"},
+		ErrInvalidSampler:                     {"CL_INVALID_SAMPLER", "invalid sampler"},
+		ErrInvalidBinary:                      {"CL_INVALID_BINARY", "invalid program binary"},
+		ErrInvalidBuildOptions:                {"CL_INVALID_BUILD_OPTIONS", "invalid build options"},
+		ErrInvalidProgram:                     {"CL_INVALID_PROGRAM", "invalid program"},
+		ErrInvalidProgramExecutable:           {"CL_INVALID_PROGRAM_EXECUTABLE", "no successfully built program executable"},
+		ErrInvalidKernelName:                  {"CL_INVALID_KERNEL_NAME", "kernel name not found in program"},
+		ErrInvalidKernelDefinition:            {"CL_INVALID_KERNEL_DEFINITION", "kernel definition differs between devices"},
+		ErrInvalidKernel:                      {"CL_INVALID_KERNEL", "invalid kernel"},
+		ErrInvalidArgIndex:                    {"CL_INVALID_ARG_INDEX", "invalid kernel argument index"},
+		ErrInvalidArgValue:                    {"CL_INVALID_ARG_VALUE", "invalid kernel argument value"},
+		ErrInvalidArgSize:                     {"CL_INVALID_ARG_SIZE", "invalid kernel argument size"},
+		ErrInvalidKernelArgs:                  {"CL_INVALID_KERNEL_ARGS", "kernel arguments not specified"},
+		ErrInvalidWorkDimension:               {"CL_INVALID_WORK_DIMENSION", "invalid number of work dimensions"},
+		ErrInvalidWorkGroupSize:               {"CL_INVALID_WORK_GROUP_SIZE", "invalid work-group size"},
+		ErrInvalidWorkItemSize:                {"CL_INVALID_WORK_ITEM_SIZE", "invalid work-item size"},
+		ErrInvalidGlobalOffset:                {"CL_INVALID_GLOBAL_OFFSET", "invalid global offset"},
+		ErrInvalidEventWaitList:               {"CL_INVALID_EVENT_WAIT_LIST", "invalid event wait list"},
+		ErrInvalidEvent:                       {"CL_INVALID_EVENT", "invalid event"},
+		ErrInvalidOperation:                   {"CL_INVALID_OPERATION", "invalid operation"},
+		ErrInvalidGlObject:                    {"CL_INVALID_GL_OBJECT", "invalid OpenGL object"},
+		ErrInvalidBufferSize:                  {"CL_INVALID_BUFFER_SIZE", "invalid buffer size"},
+		ErrInvalidMipLevel:                    {"CL_INVALID_MIP_LEVEL", "invalid mip-map level"},
+		ErrInvalidGlobalWorkSize:              {"CL_INVALID_GLOBAL_WORK_SIZE", "invalid global work size"},
+		ErrInvalidProperty:                    {"CL_INVALID_PROPERTY", "invalid property"},
+		ErrInvalidImageDescriptor:             {"CL_INVALID_IMAGE_DESCRIPTOR", "invalid image descriptor"},
+		ErrInvalidCompilerOptions:             {"CL_INVALID_COMPILER_OPTIONS", "invalid compiler options"},
+		ErrInvalidLinkerOptions:               {"CL_INVALID_LINKER_OPTIONS", "invalid linker options"},
+		ErrInvalidDevicePartitionCount:        {"CL_INVALID_DEVICE_PARTITION_COUNT", "invalid device partition count"},
+	},
+}
+
+// ErrLibraryNotAvailable is returned by all functions if the OpenCL library could not be loaded, or if the loaded
+// library does not provide the called function. See LoadLibrary().
+//
+// The value is a StatusError outside of any range reserved by Khronos, as it is reported in place of the status
+// of the OpenCL call.
+const ErrLibraryNotAvailable StatusError = C.CL12_LIBRARY_NOT_AVAILABLE
+
 // This block contains common error constants.
 const (
 	ErrDeviceNotFound                     StatusError = C.CL_DEVICE_NOT_FOUND
@@ -94,4 +219,76 @@ const (
 	ErrDataSizeLimitExceeded WrapperError = "data size limit exceeded"
 	// ErrOutOfMemory is returned by wrapper functions that need to allocate memory.
 	ErrOutOfMemory WrapperError = "out of memory"
+	// ErrInfoSizeMismatch is returned by InfoValue() and InfoSlice() if the size of the queried information does not
+	// match the requested type.
+	ErrInfoSizeMismatch WrapperError = "info size mismatch"
+	// ErrOutOfBounds is returned by functions that check offsets and lengths before they call into the library,
+	// such as the methods of Buffer.
+	ErrOutOfBounds WrapperError = "out of bounds"
+	// ErrKernelArgMissing is returned by BindKernelArgs() if no field binds an argument of the kernel.
+	ErrKernelArgMissing WrapperError = "kernel argument missing"
+	// ErrKernelArgSurplus is returned by BindKernelArgs() if a field refers to an argument that the kernel does not
+	// have, or that another field binds already.
+	ErrKernelArgSurplus WrapperError = "surplus kernel argument"
+	// ErrKernelArgUnsupported is returned by BindKernelArgs() for fields of a type that can not be set as kernel argument.
+	ErrKernelArgUnsupported WrapperError = "unsupported kernel argument"
+	// ErrKernelArgTypeMismatch is returned by SetKernelArgChecked() and BindKernelArgs() if a value does not match
+	// the type or the qualifiers of the kernel argument.
+	ErrKernelArgTypeMismatch WrapperError = "kernel argument type mismatch"
+	// ErrBuildOptionUnsupported is returned by the checks of BuildOptions for options that the OpenCL C version
+	// of a device does not support, or that are not allowed for the operation.
+	ErrBuildOptionUnsupported WrapperError = "build option not supported"
+	// ErrIncludeNotFound is returned by LinkProgramFS() if the file of an #include directive does not exist.
+	ErrIncludeNotFound WrapperError = "include file not found"
+	// ErrIncludeCycle is returned by LinkProgramFS() if a file includes itself, directly or through other files.
+	ErrIncludeCycle WrapperError = "include cycle"
+	// ErrIncludeConflict is returned by LinkProgramFS() if the same name in #include directives refers to different
+	// files, which the compiler could not tell apart.
+	ErrIncludeConflict WrapperError = "include name refers to different files"
+	// ErrTraceQueueNotAdded is returned by TraceRecorder.Record() for events of a command-queue that was not added
+	// to the recorder.
+	ErrTraceQueueNotAdded WrapperError = "command-queue not added to trace"
 )
+
+// OpError describes the failure of a function that calls into the OpenCL library.
+// The underlying error is typically a StatusError or a WrapperError, which can be tested for with errors.Is()
+// or retrieved with errors.As().
+type OpError struct {
+	// Function is the name of the OpenCL API function, such as "clCreateKernel".
+	Function string
+	// Handles are the objects the function was called with, such as CommandQueue or Kernel values.
+	// Names that identify objects, such as the name of a kernel, are listed as string values.
+	Handles []any
+	// Err is the underlying error.
+	Err error
+}
+
+func newOpError(function string, err error, handles ...any) error {
+	return &OpError{Function: function, Handles: handles, Err: err}
+}
+
+// Error returns the function, the handles, and the underlying error, such as
+// "clCreateKernel(0x7F3A10, \"main\"): CL_INVALID_KERNEL_NAME: kernel name not found in program".
+func (err *OpError) Error() string {
+	var text strings.Builder
+	text.WriteString(err.Function)
+	text.WriteString("(")
+	for i, handle := range err.Handles {
+		if i > 0 {
+			text.WriteString(", ")
+		}
+		if name, isName := handle.(string); isName {
+			text.WriteString(strconv.Quote(name))
+		} else {
+			_, _ = fmt.Fprintf(&text, "%v", handle)
+		}
+	}
+	text.WriteString("): ")
+	text.WriteString(err.Err.Error())
+	return text.String()
+}
+
+// Unwrap returns the underlying error.
+func (err *OpError) Unwrap() error {
+	return err.Err
+}
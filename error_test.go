@@ -0,0 +1,106 @@
+package cl12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestStatusErrorText(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		err      cl.StatusError
+		expected string
+	}{
+		{err: cl.ErrBuildProgramFailure, expected: "CL_BUILD_PROGRAM_FAILURE: program build failed"},
+		{err: cl.ErrInvalidValue, expected: "CL_INVALID_VALUE: invalid value"},
+		{err: cl.StatusError(-9999), expected: "-9999"},
+	}
+	for _, tc := range tt {
+		if text := tc.err.Error(); text != tc.expected {
+			t.Errorf("unexpected text for %d: %q", int(tc.err), text)
+		}
+	}
+}
+
+func TestRegisterStatusError(t *testing.T) {
+	t.Parallel()
+	const vendorError = cl.StatusError(-9100)
+	cl.RegisterStatusError(vendorError, "CL_VENDOR_TEST_ERROR", "vendor test error")
+	if vendorError.Name() != "CL_VENDOR_TEST_ERROR" {
+		t.Errorf("unexpected name: %q", vendorError.Name())
+	}
+	if vendorError.Error() != "CL_VENDOR_TEST_ERROR: vendor test error" {
+		t.Errorf("unexpected text: %q", vendorError.Error())
+	}
+}
+
+func TestOpErrorOfStatus(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{"__kernel void add(__global float *a) {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgram(program, []cl.DeviceID{device}, "", nil)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	_, err = cl.CreateKernel(program, "missing")
+	if !errors.Is(err, cl.ErrInvalidKernelName) {
+		t.Fatalf("expected ErrInvalidKernelName, got: %v", err)
+	}
+	var opErr *cl.OpError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected OpError, got: %T", err)
+	}
+	if opErr.Function != "clCreateKernel" {
+		t.Errorf("unexpected function: %q", opErr.Function)
+	}
+	expected := "clCreateKernel(" + program.String() + ", \"missing\"): " + cl.ErrInvalidKernelName.Error()
+	if err.Error() != expected {
+		t.Errorf("unexpected text: %q", err.Error())
+	}
+}
+
+func TestOpErrorOfWrapperError(t *testing.T) {
+	t.Parallel()
+	err := error(&cl.OpError{Function: "clGetDeviceInfo", Handles: []any{cl.DeviceID(0x10)}, Err: cl.ErrDataSizeLimitExceeded})
+	if !errors.Is(err, cl.ErrDataSizeLimitExceeded) {
+		t.Errorf("expected ErrDataSizeLimitExceeded, got: %v", err)
+	}
+	if err.Error() != "clGetDeviceInfo(0x10): data size limit exceeded" {
+		t.Errorf("unexpected text: %q", err.Error())
+	}
+}
+
+func TestOpErrorHandles(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	event := stubUserEvent(t, context)
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	if err := cl.SetUserEventStatus(event, int(cl.ErrOutOfResources)); err != nil {
+		t.Fatalf("SetUserEventStatus() failed: %v", err)
+	}
+	err := cl.WaitForEvents([]cl.Event{event})
+	if err == nil {
+		t.Fatalf("WaitForEvents() succeeded for a failed event")
+	}
+	expected := "clWaitForEvents([" + event.String() + "]): "
+	if !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("unexpected text of WaitForEvents(): %q", err.Error())
+	}
+
+	kernel := stubKernel(t, context, `__kernel void step(__global float *values) {}`, "-cl-kernel-arg-info", "step")
+	_, err = cl.KernelArgInfoString(kernel, 5, cl.KernelArgNameInfo)
+	if !errors.Is(err, cl.ErrInvalidArgIndex) {
+		t.Fatalf("expected ErrInvalidArgIndex, got: %v", err)
+	}
+	expected = "clGetKernelArgInfo(" + kernel.String() + ", 5): "
+	if !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("unexpected text of KernelArgInfo(): %q", err.Error())
+	}
+}
@@ -32,9 +32,11 @@ func CreateUserEvent(context Context) (Event, error) {
 	var status C.cl_int
 	event := C.clCreateUserEvent(context.handle(), &status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateUserEvent", StatusError(status), context)
 	}
-	return Event(*((*uintptr)(unsafe.Pointer(&event)))), nil
+	created := Event(*((*uintptr)(unsafe.Pointer(&event))))
+	trackCreated("clCreateUserEvent", created)
+	return created, nil
 }
 
 // SetUserEventStatus sets the execution status of a user event object.
@@ -49,7 +51,7 @@ func CreateUserEvent(context Context) (Event, error) {
 func SetUserEventStatus(event Event, executionStatus int) error {
 	status := C.clSetUserEventStatus(event.handle(), C.cl_int(executionStatus))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clSetUserEventStatus", StatusError(status), event)
 	}
 	return nil
 }
@@ -64,7 +66,7 @@ func WaitForEvents(events []Event) error {
 	}
 	status := C.clWaitForEvents(C.cl_uint(len(events)), (*C.cl_event)(rawEvents))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clWaitForEvents", StatusError(status), append([]Event{}, events...))
 	}
 	return nil
 }
@@ -171,6 +173,60 @@ const (
 	CommandFillImage EventCommandType = C.CL_COMMAND_FILL_IMAGE
 )
 
+// String returns the name of the command type, such as "CL_COMMAND_NDRANGE_KERNEL".
+func (commandType EventCommandType) String() string {
+	switch commandType {
+	case CommandNdRangeKernel:
+		return "CL_COMMAND_NDRANGE_KERNEL"
+	case CommandTask:
+		return "CL_COMMAND_TASK"
+	case CommandNativeKernel:
+		return "CL_COMMAND_NATIVE_KERNEL"
+	case CommandReadBuffer:
+		return "CL_COMMAND_READ_BUFFER"
+	case CommandWriteBuffer:
+		return "CL_COMMAND_WRITE_BUFFER"
+	case CommandCopyBuffer:
+		return "CL_COMMAND_COPY_BUFFER"
+	case CommandReadImage:
+		return "CL_COMMAND_READ_IMAGE"
+	case CommandWriteImage:
+		return "CL_COMMAND_WRITE_IMAGE"
+	case CommandCopyImage:
+		return "CL_COMMAND_COPY_IMAGE"
+	case CommandCopyImageToBuffer:
+		return "CL_COMMAND_COPY_IMAGE_TO_BUFFER"
+	case CommandCopyBufferToImage:
+		return "CL_COMMAND_COPY_BUFFER_TO_IMAGE"
+	case CommandMapBuffer:
+		return "CL_COMMAND_MAP_BUFFER"
+	case CommandMapImage:
+		return "CL_COMMAND_MAP_IMAGE"
+	case CommandUnmapMemObject:
+		return "CL_COMMAND_UNMAP_MEM_OBJECT"
+	case CommandMarker:
+		return "CL_COMMAND_MARKER"
+	case CommandReadBufferRect:
+		return "CL_COMMAND_READ_BUFFER_RECT"
+	case CommandWriteBufferRect:
+		return "CL_COMMAND_WRITE_BUFFER_RECT"
+	case CommandCopyBufferRect:
+		return "CL_COMMAND_COPY_BUFFER_RECT"
+	case CommandUser:
+		return "CL_COMMAND_USER"
+	case CommandBarrier:
+		return "CL_COMMAND_BARRIER"
+	case CommandMigrateMemObjects:
+		return "CL_COMMAND_MIGRATE_MEM_OBJECTS"
+	case CommandFillBuffer:
+		return "CL_COMMAND_FILL_BUFFER"
+	case CommandFillImage:
+		return "CL_COMMAND_FILL_IMAGE"
+	default:
+		return fmt.Sprintf("EventCommandType(0x%X)", uint(commandType))
+	}
+}
+
 // EventCommandExecutionStatus describes the execution status of an event.
 // Negative values are error status values.
 type EventCommandExecutionStatus C.cl_int
@@ -207,11 +263,22 @@ func EventInfo(event Event, paramName EventInfoName, paramSize uintptr, paramVal
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetEventInfo", StatusError(status), event)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// EventInfoQuery returns the query of EventInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func EventInfoQuery(event Event, paramName EventInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetEventInfo",
+		handles:  []any{event},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return EventInfo(event, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // RetainEvent increments the event reference count.
 // The OpenCL commands that return an event perform an implicit retain.
 //
@@ -219,8 +286,9 @@ func EventInfo(event Event, paramName EventInfoName, paramSize uintptr, paramVal
 func RetainEvent(event Event) error {
 	status := C.clRetainEvent(event.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainEvent", StatusError(status), event)
 	}
+	trackRetained("clRetainEvent", event)
 	return nil
 }
 
@@ -230,8 +298,9 @@ func RetainEvent(event Event) error {
 func ReleaseEvent(event Event) error {
 	status := C.clReleaseEvent(event.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseEvent", StatusError(status), event)
 	}
+	trackReleased("clReleaseEvent", event)
 	return nil
 }
 
@@ -281,11 +350,22 @@ func EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetEventProfilingInfo", StatusError(status), event)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// EventProfilingInfoQuery returns the query of EventProfilingInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func EventProfilingInfoQuery(event Event, paramName EventProfilingInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetEventProfilingInfo",
+		handles:  []any{event},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return EventProfilingInfo(event, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // SetEventCallback registers a user callback function for a specific command execution status.
 //
 // The command execution callback values for which a callback can be registered are: EventCommandSubmittedStatus,
@@ -298,12 +378,12 @@ func EventProfilingInfo(event Event, paramName EventProfilingInfoName, paramSize
 func SetEventCallback(event Event, callbackType EventCommandExecutionStatus, callback func(error)) error {
 	callbackUserData, err := userDataFor(callback)
 	if err != nil {
-		return err
+		return newOpError("clSetEventCallback", err, event)
 	}
 	status := C.cl12SetEventCallback(event.handle(), C.cl_int(callbackType), callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return newOpError("clSetEventCallback", StatusError(status), event)
 	}
 	return nil
 }
@@ -336,8 +416,9 @@ func EnqueueMarkerWithWaitList(commandQueue CommandQueue, waitList []Event, even
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueMarkerWithWaitList", StatusError(status), commandQueue)
 	}
+	trackEnqueuedEvent("clEnqueueMarkerWithWaitList", event)
 	return nil
 }
 
@@ -360,7 +441,8 @@ func EnqueueBarrierWithWaitList(commandQueue CommandQueue, waitList []Event, eve
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueBarrierWithWaitList", StatusError(status), commandQueue)
 	}
+	trackEnqueuedEvent("clEnqueueBarrierWithWaitList", event)
 	return nil
 }
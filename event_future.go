@@ -0,0 +1,135 @@
+package cl12
+
+import (
+	"context"
+	"fmt"
+)
+
+// EventDone returns a channel that receives the outcome of the event once its command completed: nil on success,
+// or the error of the failed command. The event is retained until then, so that the caller may release it.
+// If the callback can not be registered, the channel receives that error right away.
+//
+// The channel is buffered and receives exactly one value.
+func EventDone(event Event) <-chan error {
+	done := make(chan error, 1)
+	if err := RetainEvent(event); err != nil {
+		done <- err
+		return done
+	}
+	err := SetEventCallback(event, EventCommandCompleteStatus, func(err error) {
+		_ = ReleaseEvent(event)
+		done <- err
+	})
+	if err != nil {
+		_ = ReleaseEvent(event)
+		done <- err
+	}
+	return done
+}
+
+// Future represents the outcome of an event, or of a combination of futures.
+//
+// Unlike SetEventCallback(), which calls its callback on a thread of the OpenCL library, the callbacks of Then()
+// run on goroutines.
+type Future struct {
+	event Event
+	done  chan struct{}
+	err   error
+}
+
+// NewFuture returns a future for the event. The event is retained until its command completed.
+// If the callback can not be registered, the future is done with that error.
+func NewFuture(event Event) *Future {
+	future := newFuture(event)
+	done := EventDone(event)
+	go func() {
+		future.complete(<-done)
+	}()
+	return future
+}
+
+func newFuture(event Event) *Future {
+	return &Future{event: event, done: make(chan struct{})}
+}
+
+func (future *Future) complete(err error) {
+	future.err = err
+	close(future.done)
+}
+
+// Event returns the event of the future. It is zero for futures of All() and Any().
+// The event may have been released already, if the future is done and the caller did not keep a reference.
+func (future *Future) Event() Event {
+	return future.event
+}
+
+// Done returns a channel that is closed when the future is done.
+func (future *Future) Done() <-chan struct{} {
+	return future.done
+}
+
+// Err returns nil while the future is not done. Afterwards, it returns nil on success, or the error of the
+// failed command.
+func (future *Future) Err() error {
+	select {
+	case <-future.done:
+		return future.err
+	default:
+		return nil
+	}
+}
+
+// Wait blocks until the future is done and returns Err(), or until ctx is done and returns ctx.Err().
+func (future *Future) Wait(ctx context.Context) error {
+	select {
+	case <-future.done:
+		return future.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Then calls the callback on a new goroutine once the future is done, with the value of Err().
+// It returns the future, so that calls can be chained.
+func (future *Future) Then(callback func(error)) *Future {
+	go func() {
+		<-future.done
+		callback(future.err)
+	}()
+	return future
+}
+
+// All returns a future that is done once all futures are done. Its error is the first error of the futures,
+// in the order of the arguments. All() of no futures is done right away.
+func All(futures ...*Future) *Future {
+	combined := newFuture(0)
+	go func() {
+		var firstErr error
+		for _, future := range futures {
+			<-future.done
+			if firstErr == nil {
+				firstErr = future.err
+			}
+		}
+		combined.complete(firstErr)
+	}()
+	return combined
+}
+
+// Any returns a future that is done once any of the futures is done, with the error of that future.
+// Any() of no futures is done right away with an error that wraps ErrInvalidValue.
+func Any(futures ...*Future) *Future {
+	combined := newFuture(0)
+	if len(futures) == 0 {
+		combined.complete(fmt.Errorf("%w: Any() of no futures", ErrInvalidValue))
+		return combined
+	}
+	first := make(chan error, len(futures))
+	for _, future := range futures {
+		future.Then(func(err error) { first <- err })
+	}
+	go func() {
+		combined.complete(<-first)
+	}()
+	return combined
+}
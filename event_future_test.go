@@ -0,0 +1,88 @@
+package cl12_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestEventDone(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	event := stubUserEvent(t, clContext)
+	done := cl.EventDone(event)
+	_ = cl.ReleaseEvent(event)
+	select {
+	case err := <-done:
+		t.Fatalf("event done before completion: %v", err)
+	default:
+	}
+	_ = cl.SetUserEventStatus(event, int(cl.EventCommandCompleteStatus))
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFuture(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	event := stubUserEvent(t, clContext)
+	future := cl.NewFuture(event)
+	_ = cl.ReleaseEvent(event)
+	if err := future.Err(); err != nil {
+		t.Errorf("unexpected error of pending future: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := future.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got: %v", err)
+	}
+	called := make(chan error, 1)
+	future.Then(func(err error) { called <- err })
+
+	_ = cl.SetUserEventStatus(event, -1)
+	<-future.Done()
+	if err := future.Wait(context.Background()); !errors.Is(err, cl.StatusError(-1)) {
+		t.Errorf("expected status -1, got: %v", err)
+	}
+	if err := <-called; !errors.Is(err, cl.StatusError(-1)) {
+		t.Errorf("expected status -1 in callback, got: %v", err)
+	}
+}
+
+func TestAllAndAny(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	first := stubUserEvent(t, clContext)
+	defer func() { _ = cl.ReleaseEvent(first) }()
+	second := stubUserEvent(t, clContext)
+	defer func() { _ = cl.ReleaseEvent(second) }()
+	futures := []*cl.Future{cl.NewFuture(first), cl.NewFuture(second)}
+	all := cl.All(futures...)
+	anyOf := cl.Any(futures...)
+
+	_ = cl.SetUserEventStatus(second, -5)
+	if err := anyOf.Wait(context.Background()); !errors.Is(err, cl.StatusError(-5)) {
+		t.Errorf("expected status -5 of Any(), got: %v", err)
+	}
+	select {
+	case <-all.Done():
+		t.Fatalf("All() done before all futures")
+	default:
+	}
+	_ = cl.SetUserEventStatus(first, int(cl.EventCommandCompleteStatus))
+	if err := all.Wait(context.Background()); !errors.Is(err, cl.StatusError(-5)) {
+		t.Errorf("expected status -5 of All(), got: %v", err)
+	}
+
+	if err := cl.All().Wait(context.Background()); err != nil {
+		t.Errorf("unexpected error of empty All(): %v", err)
+	}
+	if err := cl.Any().Err(); !errors.Is(err, cl.ErrInvalidValue) || !strings.Contains(err.Error(), "no futures") {
+		t.Errorf("expected ErrInvalidValue of empty Any(), got: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package cl12
+
+import (
+	"context"
+	"errors"
+)
+
+// GoEvent creates a user event and runs the function on a new goroutine. The event completes once the function
+// returns, with EventCommandCompleteStatus if it returned nil, or with a failure status otherwise.
+// This way, commands can wait for work on the Go side by having the event in their wait list.
+//
+// The failure status is the one of a negative StatusError within the returned error, or
+// ErrExecStatusErrorForEventsInWaitList for any other error. A panic of the function, or a call of runtime.Goexit(),
+// fails the event as well; the panic is recovered and its value is discarded.
+// If ctx is done before the function returned, the event fails right away; the function continues to run, yet its
+// result is ignored.
+//
+// The caller owns the returned event and must release it. The event is retained until its status was set.
+func GoEvent(ctx context.Context, context Context, function func() error) (Event, error) {
+	event, err := CreateUserEvent(context)
+	if err != nil {
+		return 0, err
+	}
+	if err = RetainEvent(event); err != nil {
+		_ = ReleaseEvent(event)
+		return 0, err
+	}
+	returned := make(chan error, 1)
+	go runGoEventFunction(function, returned)
+	go func() {
+		var err error
+		select {
+		case err = <-returned:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		_ = SetUserEventStatus(event, goEventStatus(err))
+		_ = ReleaseEvent(event)
+	}()
+	return event, nil
+}
+
+// errGoEventAborted stands in for the result of a function of GoEvent() that panicked or called runtime.Goexit().
+const errGoEventAborted WrapperError = "function of event did not return"
+
+// runGoEventFunction sends the result of the function. The result is sent from a deferred call, as runtime.Goexit()
+// ends the goroutine after the deferred calls, even if they recover.
+func runGoEventFunction(function func() error, result chan<- error) {
+	err := error(errGoEventAborted)
+	defer func() {
+		_ = recover()
+		result <- err
+	}()
+	err = function()
+}
+
+func goEventStatus(err error) int {
+	if err == nil {
+		return int(EventCommandCompleteStatus)
+	}
+	var statusErr StatusError
+	if errors.As(err, &statusErr) && (statusErr < 0) {
+		return int(statusErr)
+	}
+	return int(ErrExecStatusErrorForEventsInWaitList)
+}
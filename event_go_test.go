@@ -0,0 +1,101 @@
+package cl12_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestGoEvent(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	queue := stubCommandQueue(t, clContext, device, 0)
+	release := make(chan struct{})
+	event, err := cl.GoEvent(context.Background(), clContext, func() error {
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GoEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	var marker cl.Event
+	if err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{event}, &marker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(marker) }()
+	done := cl.EventDone(marker)
+	select {
+	case err = <-done:
+		t.Fatalf("marker completed before the function returned: %v", err)
+	default:
+	}
+	close(release)
+	if err = <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGoEventFailures(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		function func() error
+		expected error
+	}{
+		{
+			name:     "error",
+			ctx:      context.Background(),
+			function: func() error { return errors.New("load failed") },
+			expected: cl.ErrExecStatusErrorForEventsInWaitList,
+		},
+		{
+			name:     "status",
+			ctx:      context.Background(),
+			function: func() error { return fmt.Errorf("reading: %w", cl.ErrOutOfHostMemory) },
+			expected: cl.ErrOutOfHostMemory,
+		},
+		{
+			name:     "panic",
+			ctx:      context.Background(),
+			function: func() error { panic("broken") },
+			expected: cl.ErrExecStatusErrorForEventsInWaitList,
+		},
+		{
+			name:     "goexit",
+			ctx:      context.Background(),
+			function: func() error { runtime.Goexit(); return nil },
+			expected: cl.ErrExecStatusErrorForEventsInWaitList,
+		},
+		{
+			name:     "canceled",
+			ctx:      canceled,
+			function: func() error { <-stop; return nil },
+			expected: cl.ErrExecStatusErrorForEventsInWaitList,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			event, err := cl.GoEvent(tc.ctx, clContext, tc.function)
+			if err != nil {
+				t.Fatalf("GoEvent() failed: %v", err)
+			}
+			defer func() { _ = cl.ReleaseEvent(event) }()
+			if err = <-cl.EventDone(event); !errors.Is(err, tc.expected) {
+				t.Errorf("expected %v, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
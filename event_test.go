@@ -0,0 +1,68 @@
+package cl12_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestSetEventCallback(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	event, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	results := make(chan error, 1)
+	err = cl.SetEventCallback(event, cl.EventCommandCompleteStatus, func(err error) { results <- err })
+	if err != nil {
+		t.Fatalf("SetEventCallback() failed: %v", err)
+	}
+	select {
+	case <-results:
+		t.Fatalf("callback called before the event completed")
+	default:
+	}
+	err = cl.SetUserEventStatus(event, int(cl.EventCommandCompleteStatus))
+	if err != nil {
+		t.Fatalf("SetUserEventStatus() failed: %v", err)
+	}
+	if err = <-results; err != nil {
+		t.Errorf("unexpected callback error: %v", err)
+	}
+}
+
+func TestSetEventCallbackWithFailedDependency(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	userEvent, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(userEvent) }()
+	var marker cl.Event
+	err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{userEvent}, &marker)
+	if err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(marker) }()
+	results := make(chan error, 1)
+	err = cl.SetEventCallback(marker, cl.EventCommandCompleteStatus, func(err error) { results <- err })
+	if err != nil {
+		t.Fatalf("SetEventCallback() failed: %v", err)
+	}
+	err = cl.SetUserEventStatus(userEvent, -1)
+	if err != nil {
+		t.Fatalf("SetUserEventStatus() failed: %v", err)
+	}
+	if err = <-results; !errors.Is(err, cl.ErrExecStatusErrorForEventsInWaitList) {
+		t.Errorf("unexpected callback error: %v", err)
+	}
+	err = cl.WaitForEvents([]cl.Event{marker})
+	if !errors.Is(err, cl.ErrExecStatusErrorForEventsInWaitList) {
+		t.Errorf("unexpected wait error: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package cl12
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Timings are the profiling timestamps of a command, in nanoseconds of the device time counter.
+type Timings struct {
+	// Queued is the time when the command was enqueued by the host. See ProfilingCommandQueuedInfo.
+	Queued uint64
+	// Submit is the time when the command was submitted to the device. See ProfilingCommandSubmitInfo.
+	Submit uint64
+	// Start is the time when the command started execution on the device. See ProfilingCommandStartInfo.
+	Start uint64
+	// End is the time when the command finished execution on the device. See ProfilingCommandEndInfo.
+	End uint64
+}
+
+// QueueDelay returns the time the command waited in the command-queue before it was submitted.
+func (timings Timings) QueueDelay() time.Duration {
+	return timingsInterval(timings.Queued, timings.Submit)
+}
+
+// SubmitDelay returns the time between the submission of the command and the start of its execution.
+func (timings Timings) SubmitDelay() time.Duration {
+	return timingsInterval(timings.Submit, timings.Start)
+}
+
+// Duration returns the execution time of the command.
+func (timings Timings) Duration() time.Duration {
+	return timingsInterval(timings.Start, timings.End)
+}
+
+// timingsInterval returns zero for timestamps out of order, which some implementations report for commands that
+// were not executed on the device.
+func timingsInterval(from, to uint64) time.Duration {
+	if to < from {
+		return 0
+	}
+	return time.Duration(to - from)
+}
+
+// ProfilingError is returned by EventTimings() if the profiling information of the event is not available,
+// and by TraceRecorder.AddQueue() for a command-queue without QueueProfilingEnable.
+type ProfilingError struct {
+	// Event is the queried event. It is zero for errors of TraceRecorder.AddQueue().
+	Event Event
+	// Queue is the command-queue of the event. It is zero for user events, which have no profiling information.
+	Queue CommandQueue
+	// QueueProfilingDisabled is set if the command-queue was created without QueueProfilingEnable.
+	// If it is not set, the command of the event has typically not completed yet.
+	QueueProfilingDisabled bool
+	// Err is the error of the query, which is or wraps ErrProfilingInfoNotAvailable.
+	Err error
+}
+
+// Error returns the underlying error, together with the reason if it is known.
+func (err *ProfilingError) Error() string {
+	switch {
+	case err.Queue == 0:
+		return fmt.Sprintf("%v: user event %v has no command-queue", err.Err, err.Event)
+	case err.QueueProfilingDisabled:
+		return fmt.Sprintf("%v: command-queue %v was created without profiling", err.Err, err.Queue)
+	default:
+		return err.Err.Error()
+	}
+}
+
+// Unwrap returns the underlying error.
+func (err *ProfilingError) Unwrap() error {
+	return err.Err
+}
+
+// EventTimings returns the profiling timestamps of the command of the event.
+//
+// If the profiling information is not available, the returned error is a *ProfilingError that tells whether the
+// command-queue of the event lacks QueueProfilingEnable.
+func EventTimings(event Event) (Timings, error) {
+	var timings Timings
+	fields := []struct {
+		name  EventProfilingInfoName
+		value *uint64
+	}{
+		{name: ProfilingCommandQueuedInfo, value: &timings.Queued},
+		{name: ProfilingCommandSubmitInfo, value: &timings.Submit},
+		{name: ProfilingCommandStartInfo, value: &timings.Start},
+		{name: ProfilingCommandEndInfo, value: &timings.End},
+	}
+	for _, field := range fields {
+		value, err := InfoValue[uint64](EventProfilingInfoQuery(event, field.name))
+		if errors.Is(err, ErrProfilingInfoNotAvailable) {
+			return Timings{}, newProfilingError(event, err)
+		}
+		if err != nil {
+			return Timings{}, err
+		}
+		*field.value = value
+	}
+	return timings, nil
+}
+
+func newProfilingError(event Event, cause error) error {
+	profilingErr := &ProfilingError{Event: event, Err: cause}
+	queue, err := InfoValue[CommandQueue](EventInfoQuery(event, EventCommandQueueInfo))
+	if err != nil {
+		return cause
+	}
+	profilingErr.Queue = queue
+	if queue == 0 {
+		return profilingErr
+	}
+	properties, err := InfoValue[CommandQueuePropertiesFlags](CommandQueueInfoQuery(queue, QueuePropertiesInfo))
+	if err != nil {
+		return cause
+	}
+	profilingErr.QueueProfilingDisabled = (properties & QueueProfilingEnable) == 0
+	return profilingErr
+}
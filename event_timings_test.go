@@ -0,0 +1,76 @@
+package cl12_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestEventTimings(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	queue := stubCommandQueue(t, clContext, device, cl.QueueProfilingEnable)
+	var marker cl.Event
+	if err := cl.EnqueueMarkerWithWaitList(queue, nil, &marker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(marker) }()
+	timings, err := cl.EventTimings(marker)
+	if err != nil {
+		t.Fatalf("EventTimings() failed: %v", err)
+	}
+	if (timings.Queued == 0) || (timings.Submit < timings.Queued) || (timings.Start < timings.Submit) || (timings.End < timings.Start) {
+		t.Errorf("unexpected timings: %+v", timings)
+	}
+	if total := timings.QueueDelay() + timings.SubmitDelay() + timings.Duration(); total != time.Duration(timings.End-timings.Queued) {
+		t.Errorf("unexpected durations: %v, %v, %v", timings.QueueDelay(), timings.SubmitDelay(), timings.Duration())
+	}
+	if (cl.Timings{Start: 20, End: 10}).Duration() != 0 {
+		t.Errorf("expected zero duration for timestamps out of order")
+	}
+}
+
+func TestEventTimingsNotAvailable(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	plainQueue := stubCommandQueue(t, clContext, device, 0)
+	profilingQueue := stubCommandQueue(t, clContext, device, cl.QueueProfilingEnable)
+	blocker := stubUserEvent(t, clContext)
+	defer func() { _ = cl.ReleaseEvent(blocker) }()
+	defer func() { _ = cl.SetUserEventStatus(blocker, int(cl.EventCommandCompleteStatus)) }()
+
+	var plainMarker cl.Event
+	if err := cl.EnqueueMarkerWithWaitList(plainQueue, nil, &plainMarker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(plainMarker) }()
+	var pendingMarker cl.Event
+	if err := cl.EnqueueMarkerWithWaitList(profilingQueue, []cl.Event{blocker}, &pendingMarker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(pendingMarker) }()
+
+	tests := []struct {
+		name     string
+		event    cl.Event
+		queue    cl.CommandQueue
+		disabled bool
+	}{
+		{name: "disabled", event: plainMarker, queue: plainQueue, disabled: true},
+		{name: "pending", event: pendingMarker, queue: profilingQueue, disabled: false},
+		{name: "user", event: blocker, queue: 0, disabled: false},
+	}
+	for _, tc := range tests {
+		_, err := cl.EventTimings(tc.event)
+		var profilingErr *cl.ProfilingError
+		if !errors.As(err, &profilingErr) || !errors.Is(err, cl.ErrProfilingInfoNotAvailable) {
+			t.Errorf("%s: expected a profiling error, got: %v", tc.name, err)
+			continue
+		}
+		if (profilingErr.Queue != tc.queue) || (profilingErr.QueueProfilingDisabled != tc.disabled) {
+			t.Errorf("%s: unexpected profiling error: %+v", tc.name, profilingErr)
+		}
+	}
+}
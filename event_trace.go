@@ -0,0 +1,261 @@
+package cl12
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// TraceRecorder collects the profiling timestamps of commands and writes them in the Chrome Trace Event format,
+// which chrome://tracing and Perfetto can open. Each device is presented as a process, and each command-queue as
+// a thread of its device.
+//
+// Commands are recorded from the command-queues that were added with AddQueue(). A recorder is safe for
+// concurrent use.
+//
+// Each device is presented with its own time base, as the profiling clocks of different devices are not
+// synchronized.
+type TraceRecorder struct {
+	mutex sync.Mutex
+	// idle is signaled when the count of pending commands drops to zero.
+	idle    *sync.Cond
+	pending int
+	devices map[DeviceID]traceProcess
+	tracks  map[CommandQueue]traceTrack
+	records []traceRecord
+	err     error
+}
+
+type traceProcess struct {
+	pid  int
+	name string
+}
+
+type traceTrack struct {
+	pid  int
+	tid  int
+	name string
+}
+
+type traceRecord struct {
+	track   traceTrack
+	label   string
+	command EventCommandType
+	timings Timings
+}
+
+// NewTraceRecorder returns a recorder without command-queues.
+func NewTraceRecorder() *TraceRecorder {
+	recorder := &TraceRecorder{
+		devices: make(map[DeviceID]traceProcess),
+		tracks:  make(map[CommandQueue]traceTrack),
+	}
+	recorder.idle = sync.NewCond(&recorder.mutex)
+	return recorder
+}
+
+// AddQueue designates the command-queue for recording, with the given name for its track.
+// If the name is empty, the track is named after the handle of the command-queue.
+// The command-queue must have been created with QueueProfilingEnable; otherwise the returned error is
+// a *ProfilingError.
+func (recorder *TraceRecorder) AddQueue(commandQueue CommandQueue, name string) error {
+	properties, err := InfoValue[CommandQueuePropertiesFlags](CommandQueueInfoQuery(commandQueue, QueuePropertiesInfo))
+	if err != nil {
+		return err
+	}
+	if (properties & QueueProfilingEnable) == 0 {
+		return &ProfilingError{Queue: commandQueue, QueueProfilingDisabled: true, Err: ErrProfilingInfoNotAvailable}
+	}
+	device, err := InfoValue[DeviceID](CommandQueueInfoQuery(commandQueue, QueueDeviceInfo))
+	if err != nil {
+		return err
+	}
+	deviceName, err := DeviceInfoString(device, DeviceNameInfo)
+	if err != nil {
+		return err
+	}
+	if len(name) == 0 {
+		name = "command-queue " + commandQueue.String()
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	process, known := recorder.devices[device]
+	if !known {
+		process = traceProcess{pid: len(recorder.devices) + 1, name: deviceName}
+		recorder.devices[device] = process
+	}
+	track, known := recorder.tracks[commandQueue]
+	if !known {
+		track.tid = len(recorder.tracks) + 1
+	}
+	track.pid = process.pid
+	track.name = name
+	recorder.tracks[commandQueue] = track
+	return nil
+}
+
+// Record registers the command of the event, which must stem from a command-queue of AddQueue().
+// The label names the command in the trace; if it is empty, the name of the command type is used.
+//
+// The event is retained until its command completed, after which its profiling timestamps are collected.
+// Errors of the collection are reported by Err().
+func (recorder *TraceRecorder) Record(event Event, label string) error {
+	commandQueue, err := InfoValue[CommandQueue](EventInfoQuery(event, EventCommandQueueInfo))
+	if err != nil {
+		return err
+	}
+	recorder.mutex.Lock()
+	track, known := recorder.tracks[commandQueue]
+	recorder.mutex.Unlock()
+	if !known {
+		return fmt.Errorf("%w: %v of event %v", ErrTraceQueueNotAdded, commandQueue, event)
+	}
+	command, err := InfoValue[EventCommandType](EventInfoQuery(event, EventCommandTypeInfo))
+	if err != nil {
+		return err
+	}
+	if len(label) == 0 {
+		label = command.String()
+	}
+	if err = RetainEvent(event); err != nil {
+		return err
+	}
+	done := EventDone(event)
+	recorder.mutex.Lock()
+	recorder.pending++
+	recorder.mutex.Unlock()
+	go func() {
+		err := <-done
+		var timings Timings
+		if err == nil {
+			timings, err = EventTimings(event)
+		}
+		_ = ReleaseEvent(event)
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+		recorder.pending--
+		if recorder.pending == 0 {
+			recorder.idle.Broadcast()
+		}
+		if err != nil {
+			if recorder.err == nil {
+				recorder.err = fmt.Errorf("%s: %w", label, err)
+			}
+			return
+		}
+		recorder.records = append(recorder.records, traceRecord{track: track, label: label, command: command, timings: timings})
+	}()
+	return nil
+}
+
+// RecordKernel registers the command of the event with Record(), labeled with the function name of the kernel.
+func (recorder *TraceRecorder) RecordKernel(event Event, kernel Kernel) error {
+	name, err := KernelInfoString(kernel, KernelFunctionNameInfo)
+	if err != nil {
+		return err
+	}
+	return recorder.Record(event, name)
+}
+
+// Err returns the first error of collecting the timestamps of a recorded command, such as the error of a failed
+// command. Such commands are missing from the trace. Err does not wait for pending commands.
+func (recorder *TraceRecorder) Err() error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.err
+}
+
+// traceEvent is an entry of the "traceEvents" array of the Chrome Trace Event format.
+type traceEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat,omitempty"`
+	Phase     string         `json:"ph"`
+	ProcessID int            `json:"pid"`
+	ThreadID  int            `json:"tid"`
+	Timestamp float64        `json:"ts"`
+	Duration  *float64       `json:"dur,omitempty"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// WriteTo waits for all recorded commands to complete, and writes the trace to the writer.
+// Commands that are recorded while WriteTo waits are waited for as well. WriteTo blocks for as long as a recorded
+// command does not complete, such as one that waits for a user event which is never set.
+//
+// Timestamps are in microseconds, relative to the earliest enqueued command of the same device.
+func (recorder *TraceRecorder) WriteTo(w io.Writer) (int64, error) {
+	recorder.mutex.Lock()
+	for recorder.pending > 0 {
+		recorder.idle.Wait()
+	}
+	file := recorder.traceFile()
+	recorder.mutex.Unlock()
+	data, err := json.Marshal(file)
+	if err != nil {
+		return 0, err
+	}
+	written, err := w.Write(data)
+	return int64(written), err
+}
+
+func (recorder *TraceRecorder) traceFile() traceFile {
+	file := traceFile{TraceEvents: []traceEvent{}, DisplayTimeUnit: "ns"}
+	for _, process := range recorder.devices {
+		file.TraceEvents = append(file.TraceEvents, traceEvent{
+			Name: "process_name", Phase: "M", ProcessID: process.pid,
+			Args: map[string]any{"name": process.name},
+		})
+	}
+	for _, track := range recorder.tracks {
+		file.TraceEvents = append(file.TraceEvents, traceEvent{
+			Name: "thread_name", Phase: "M", ProcessID: track.pid, ThreadID: track.tid,
+			Args: map[string]any{"name": track.name},
+		})
+	}
+	sort.Slice(file.TraceEvents, func(a, b int) bool {
+		first, second := file.TraceEvents[a], file.TraceEvents[b]
+		if first.ProcessID != second.ProcessID {
+			return first.ProcessID < second.ProcessID
+		}
+		return first.ThreadID < second.ThreadID
+	})
+
+	records := append([]traceRecord{}, recorder.records...)
+	sort.SliceStable(records, func(a, b int) bool { return records[a].timings.Start < records[b].timings.Start })
+	bases := make(map[int]uint64)
+	for _, record := range records {
+		if base, known := bases[record.track.pid]; !known || (record.timings.Queued < base) {
+			bases[record.track.pid] = record.timings.Queued
+		}
+	}
+	for _, record := range records {
+		base := bases[record.track.pid]
+		micros := func(timestamp uint64) float64 {
+			if timestamp < base {
+				return 0
+			}
+			return float64(timestamp-base) / 1000
+		}
+		duration := float64(record.timings.Duration()) / 1000
+		file.TraceEvents = append(file.TraceEvents, traceEvent{
+			Name:      record.label,
+			Category:  record.command.String(),
+			Phase:     "X",
+			ProcessID: record.track.pid,
+			ThreadID:  record.track.tid,
+			Timestamp: micros(record.timings.Start),
+			Duration:  &duration,
+			Args: map[string]any{
+				"queued": micros(record.timings.Queued),
+				"submit": micros(record.timings.Submit),
+			},
+		})
+	}
+	return file
+}
@@ -0,0 +1,153 @@
+package cl12_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestTraceRecorder(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	computeQueue := stubCommandQueue(t, clContext, device, cl.QueueProfilingEnable)
+	transferQueue := stubCommandQueue(t, clContext, device, cl.QueueProfilingEnable)
+	kernel := stubKernel(t, clContext, `__kernel void step() {}`, "", "step")
+	recorder := cl.NewTraceRecorder()
+	if err := recorder.AddQueue(computeQueue, "compute"); err != nil {
+		t.Fatalf("AddQueue() failed: %v", err)
+	}
+	if err := recorder.AddQueue(transferQueue, ""); err != nil {
+		t.Fatalf("AddQueue() failed: %v", err)
+	}
+
+	var kernelEvent cl.Event
+	if err := cl.EnqueueNDRangeKernel(computeQueue, kernel, []cl.WorkDimension{{GlobalSize: 16}}, nil, &kernelEvent); err != nil {
+		t.Fatalf("EnqueueNDRangeKernel() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(kernelEvent) }()
+	if err := recorder.RecordKernel(kernelEvent, kernel); err != nil {
+		t.Fatalf("RecordKernel() failed: %v", err)
+	}
+	var marker cl.Event
+	if err := cl.EnqueueMarkerWithWaitList(transferQueue, nil, &marker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(marker) }()
+	if err := recorder.Record(marker, ""); err != nil {
+		t.Fatalf("Record() failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := recorder.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() failed: %v", err)
+	}
+	if err := recorder.Err(); err != nil {
+		t.Errorf("unexpected collection error: %v", err)
+	}
+	var trace struct {
+		TraceEvents []struct {
+			Name     string         `json:"name"`
+			Category string         `json:"cat"`
+			Phase    string         `json:"ph"`
+			PID      int            `json:"pid"`
+			TID      int            `json:"tid"`
+			Args     map[string]any `json:"args"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("trace is not valid JSON: %v", err)
+	}
+	threads := make(map[int]string)
+	commands := make(map[string]string)
+	var processes int
+	for _, event := range trace.TraceEvents {
+		switch {
+		case event.Phase == "M" && event.Name == "process_name":
+			processes++
+		case event.Phase == "M" && event.Name == "thread_name":
+			threads[event.TID], _ = event.Args["name"].(string)
+		case event.Phase == "X":
+			commands[event.Name] = event.Category
+			if event.PID != 1 {
+				t.Errorf("unexpected process of %q: %d", event.Name, event.PID)
+			}
+		}
+	}
+	if (processes != 1) || (len(threads) != 2) || (threads[1] != "compute") {
+		t.Errorf("unexpected tracks: %d processes, threads %v", processes, threads)
+	}
+	if (commands["step"] != "CL_COMMAND_NDRANGE_KERNEL") || (commands["CL_COMMAND_MARKER"] != "CL_COMMAND_MARKER") {
+		t.Errorf("unexpected commands: %v", commands)
+	}
+}
+
+func TestTraceRecorderQueues(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	plainQueue := stubCommandQueue(t, clContext, device, 0)
+	otherQueue := stubCommandQueue(t, clContext, device, cl.QueueProfilingEnable)
+	recorder := cl.NewTraceRecorder()
+	err := recorder.AddQueue(plainQueue, "plain")
+	var profilingErr *cl.ProfilingError
+	if !errors.As(err, &profilingErr) || !errors.Is(err, cl.ErrProfilingInfoNotAvailable) {
+		t.Errorf("expected *ProfilingError with ErrProfilingInfoNotAvailable, got: %v", err)
+	} else if (profilingErr.Queue != plainQueue) || !profilingErr.QueueProfilingDisabled {
+		t.Errorf("unexpected queue %v, disabled %t", profilingErr.Queue, profilingErr.QueueProfilingDisabled)
+	}
+	var marker cl.Event
+	if err := cl.EnqueueMarkerWithWaitList(otherQueue, nil, &marker); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(marker) }()
+	if err := recorder.Record(marker, "marker"); !errors.Is(err, cl.ErrTraceQueueNotAdded) {
+		t.Errorf("expected ErrTraceQueueNotAdded, got: %v", err)
+	}
+}
+
+func TestTraceRecorderConcurrentWrite(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	commandQueue := stubCommandQueue(t, clContext, device, cl.QueueProfilingEnable)
+	recorder := cl.NewTraceRecorder()
+	if err := recorder.AddQueue(commandQueue, "markers"); err != nil {
+		t.Fatalf("AddQueue() failed: %v", err)
+	}
+	const count = 16
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var marker cl.Event
+			if err := cl.EnqueueMarkerWithWaitList(commandQueue, nil, &marker); err != nil {
+				t.Errorf("EnqueueMarkerWithWaitList() failed: %v", err)
+				return
+			}
+			defer func() { _ = cl.ReleaseEvent(marker) }()
+			if err := recorder.Record(marker, ""); err != nil {
+				t.Errorf("Record() failed: %v", err)
+			}
+		}()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := recorder.WriteTo(io.Discard); err != nil {
+				t.Errorf("WriteTo() failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	var buf bytes.Buffer
+	if _, err := recorder.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() failed: %v", err)
+	}
+	if commands := strings.Count(buf.String(), `"ph":"X"`); commands != count {
+		t.Errorf("expected %d commands in the trace, got %d", count, commands)
+	}
+}
@@ -0,0 +1,112 @@
+package cl12
+
+import (
+	"context"
+)
+
+// ContextTerminator terminates all pending work of a context. ExtensionTerminateContextKhr implements it.
+type ContextTerminator interface {
+	TerminateContext(context Context) error
+}
+
+// Waiter waits for events and command-queues until a context.Context is done.
+// The zero value only stops waiting; the pending commands continue.
+type Waiter struct {
+	// Terminator is called for the OpenCL context of the events or of the command-queue if the context.Context is
+	// done before the commands completed. This is typically an ExtensionTerminateContextKhr, for a context created
+	// with WithTermination(true). All objects of a terminated context must be released afterwards.
+	Terminator ContextTerminator
+}
+
+// WaitForEventsContext waits for the events like WaitForEvents(), but returns ctx.Err() once ctx is done.
+// See Waiter.WaitForEvents().
+func WaitForEventsContext(ctx context.Context, events []Event) error {
+	return Waiter{}.WaitForEvents(ctx, events)
+}
+
+// FinishContext waits for the commands of the queue like Finish(), but returns ctx.Err() once ctx is done.
+// See Waiter.Finish().
+//
+// Since: 1.2
+func FinishContext(ctx context.Context, commandQueue CommandQueue) error {
+	return Waiter{}.Finish(ctx, commandQueue)
+}
+
+// WaitForEvents waits for the events to complete. Unlike WaitForEvents(), it does not block in the OpenCL library:
+// It registers a callback for EventCommandCompleteStatus with each event, and returns ctx.Err() if ctx is done
+// before all callbacks were called. The events are retained until their callback was called.
+//
+// If any event failed, the returned error wraps ErrExecStatusErrorForEventsInWaitList, as the one of WaitForEvents().
+func (waiter Waiter) WaitForEvents(ctx context.Context, events []Event) error {
+	if len(events) == 0 {
+		return newOpError("clWaitForEvents", ErrInvalidValue)
+	}
+	completed := make(chan error, len(events))
+	for i, event := range events {
+		if err := RetainEvent(event); err != nil {
+			waiter.abandon(events[:i], i, completed)
+			return err
+		}
+		err := SetEventCallback(event, EventCommandCompleteStatus, func(err error) {
+			completed <- err
+		})
+		if err != nil {
+			_ = ReleaseEvent(event)
+			waiter.abandon(events[:i], i, completed)
+			return err
+		}
+	}
+	var failed bool
+	for received := 0; received < len(events); received++ {
+		select {
+		case err := <-completed:
+			failed = failed || (err != nil)
+		case <-ctx.Done():
+			if waiter.Terminator != nil {
+				if owner, err := InfoValue[Context](EventInfoQuery(events[0], EventContextInfo)); err == nil {
+					_ = waiter.Terminator.TerminateContext(owner)
+				}
+			}
+			waiter.abandon(events, len(events)-received, completed)
+			return ctx.Err()
+		}
+	}
+	for _, event := range events {
+		_ = ReleaseEvent(event)
+	}
+	if failed {
+		return newOpError("clWaitForEvents", ErrExecStatusErrorForEventsInWaitList, append([]Event{}, events...))
+	}
+	return nil
+}
+
+// abandon releases the events once the pending callbacks were called.
+func (Waiter) abandon(events []Event, pending int, completed <-chan error) {
+	if len(events) == 0 {
+		return
+	}
+	go func() {
+		for ; pending > 0; pending-- {
+			<-completed
+		}
+		for _, event := range events {
+			_ = ReleaseEvent(event)
+		}
+	}()
+}
+
+// Finish enqueues a marker into the queue, flushes the queue, and waits for the marker with WaitForEvents().
+// Once the marker completed, all commands that were enqueued before have completed as well.
+//
+// Since: 1.2
+func (waiter Waiter) Finish(ctx context.Context, commandQueue CommandQueue) error {
+	var marker Event
+	if err := EnqueueMarkerWithWaitList(commandQueue, nil, &marker); err != nil {
+		return err
+	}
+	defer func() { _ = ReleaseEvent(marker) }()
+	if err := Flush(commandQueue); err != nil {
+		return err
+	}
+	return waiter.WaitForEvents(ctx, []Event{marker})
+}
@@ -0,0 +1,103 @@
+package cl12_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+type recordingTerminator struct {
+	terminated chan cl.Context
+}
+
+func (terminator recordingTerminator) TerminateContext(context cl.Context) error {
+	terminator.terminated <- context
+	return nil
+}
+
+func TestWaitForEventsContext(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	first, err := cl.CreateUserEvent(clContext)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(first) }()
+	second, err := cl.CreateUserEvent(clContext)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(second) }()
+	_ = cl.SetUserEventStatus(first, int(cl.EventCommandCompleteStatus))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = cl.WaitForEventsContext(ctx, []cl.Event{first, second})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = cl.SetUserEventStatus(second, int(cl.EventCommandCompleteStatus))
+	}()
+	if err = cl.WaitForEventsContext(context.Background(), []cl.Event{first, second}); err != nil {
+		t.Errorf("WaitForEventsContext() failed: %v", err)
+	}
+}
+
+func TestWaitForEventsContextFailure(t *testing.T) {
+	t.Parallel()
+	clContext, _ := stubContext(t)
+	event, err := cl.CreateUserEvent(clContext)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(event) }()
+	_ = cl.SetUserEventStatus(event, -1)
+	err = cl.WaitForEventsContext(context.Background(), []cl.Event{event})
+	if !errors.Is(err, cl.ErrExecStatusErrorForEventsInWaitList) {
+		t.Errorf("expected ErrExecStatusErrorForEventsInWaitList, got: %v", err)
+	}
+	if err = cl.WaitForEventsContext(context.Background(), nil); !errors.Is(err, cl.ErrInvalidValue) {
+		t.Errorf("expected ErrInvalidValue, got: %v", err)
+	}
+}
+
+func TestFinishContext(t *testing.T) {
+	t.Parallel()
+	clContext, device := stubContext(t)
+	queue := stubCommandQueue(t, clContext, device, 0)
+	blocker, err := cl.CreateUserEvent(clContext)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseEvent(blocker) }()
+	if err = cl.EnqueueMarkerWithWaitList(queue, []cl.Event{blocker}, nil); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+
+	terminator := recordingTerminator{terminated: make(chan cl.Context, 1)}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = cl.Waiter{Terminator: terminator}.Finish(ctx, queue)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got: %v", err)
+	}
+	select {
+	case terminated := <-terminator.terminated:
+		if terminated != clContext {
+			t.Errorf("unexpected terminated context: %v", terminated)
+		}
+	default:
+		t.Errorf("context was not terminated")
+	}
+
+	_ = cl.SetUserEventStatus(blocker, int(cl.EventCommandCompleteStatus))
+	if err = cl.FinishContext(context.Background(), queue); err != nil {
+		t.Errorf("FinishContext() failed: %v", err)
+	}
+}
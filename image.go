@@ -92,9 +92,11 @@ func CreateImage(context Context, flags MemFlags, format ImageFormat, desc Image
 		hostPtr,
 		&status) //nolint:gocritic
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateImage", StatusError(status), context)
 	}
-	return MemObject(*((*uintptr)(unsafe.Pointer(&mem)))), nil
+	created := MemObject(*((*uintptr)(unsafe.Pointer(&mem))))
+	trackCreated("clCreateImage", created)
+	return created, nil
 }
 
 // SupportedImageFormats returns the list of image formats supported by an OpenCL implementation.
@@ -110,7 +112,7 @@ func SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectT
 		nil,
 		&requiredCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clGetSupportedImageFormats", StatusError(status), context)
 	}
 	if requiredCount == 0 {
 		return nil, nil
@@ -125,7 +127,7 @@ func SupportedImageFormats(context Context, flags MemFlags, imageType MemObjectT
 		(*C.cl_image_format)(unsafe.Pointer(&formats[0])),
 		&returnedCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clGetSupportedImageFormats", StatusError(status), context)
 	}
 	return formats[:returnedCount], nil
 }
@@ -164,8 +166,9 @@ func EnqueueMapImage(commandQueue CommandQueue,
 		(*C.cl_event)(unsafe.Pointer(event)),
 		&status)
 	if status != C.CL_SUCCESS {
-		return MappedImage{}, StatusError(status)
+		return MappedImage{}, newOpError("clEnqueueMapImage", StatusError(status), commandQueue, image)
 	}
+	trackEnqueuedEvent("clEnqueueMapImage", event)
 	return mapped, nil
 }
 
@@ -248,11 +251,22 @@ func ImageInfo(image MemObject, paramName ImageInfoName, paramSize uintptr, para
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetImageInfo", StatusError(status), image)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// ImageInfoQuery returns the query of ImageInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func ImageInfoQuery(image MemObject, paramName ImageInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetImageInfo",
+		handles:  []any{image},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ImageInfo(image, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // EnqueueReadImage enqueues a command to read from an image or image array object to host memory.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clEnqueueReadImage.html
@@ -276,8 +290,9 @@ func EnqueueReadImage(commandQueue CommandQueue, image MemObject, blocking bool,
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueReadImage", StatusError(status), commandQueue, image)
 	}
+	trackEnqueuedEvent("clEnqueueReadImage", event)
 	return nil
 }
 
@@ -304,8 +319,9 @@ func EnqueueWriteImage(commandQueue CommandQueue, image MemObject, blocking bool
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueWriteImage", StatusError(status), commandQueue, image)
 	}
+	trackEnqueuedEvent("clEnqueueWriteImage", event)
 	return nil
 }
 
@@ -335,8 +351,9 @@ func EnqueueFillImage(commandQueue CommandQueue, image MemObject, fillColor unsa
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueFillImage", StatusError(status), commandQueue, image)
 	}
+	trackEnqueuedEvent("clEnqueueFillImage", event)
 	return nil
 }
 
@@ -360,8 +377,9 @@ func EnqueueCopyImage(commandQueue CommandQueue, srcImage, dstImage MemObject, s
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueCopyImage", StatusError(status), commandQueue, srcImage, dstImage)
 	}
+	trackEnqueuedEvent("clEnqueueCopyImage", event)
 	return nil
 }
 
@@ -385,8 +403,9 @@ func EnqueueCopyImageToBuffer(commandQueue CommandQueue, srcImage, dstBuffer Mem
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueCopyImageToBuffer", StatusError(status), commandQueue, srcImage, dstBuffer)
 	}
+	trackEnqueuedEvent("clEnqueueCopyImageToBuffer", event)
 	return nil
 }
 
@@ -410,7 +429,8 @@ func EnqueueCopyBufferToImage(commandQueue CommandQueue, srcBuffer, dstImage Mem
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueCopyBufferToImage", StatusError(status), commandQueue, srcBuffer, dstImage)
 	}
+	trackEnqueuedEvent("clEnqueueCopyBufferToImage", event)
 	return nil
 }
@@ -0,0 +1,85 @@
+package cl12
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// infoSizeLimit is the maximum size, in bytes, that the convenience functions accept for an information value.
+const infoSizeLimit = 1024 * 1024 * 10
+
+// InfoQuery represents a call to one of the information functions, such as DeviceInfo(), for one parameter.
+// Queries are created with the functions that are named after the information function, such as DeviceInfoQuery(),
+// and are evaluated with InfoValue(), InfoSlice(), or the string-based convenience functions.
+type InfoQuery struct {
+	function string
+	handles  []any
+	load     func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)
+}
+
+// InfoValue returns the information value of a query as a value of type T.
+//
+// The type T must match the type of the information value, and must not contain Go pointers.
+// The size of the value, as reported by the library, is compared against the size of T before the value is
+// retrieved. A mismatch results in an error that wraps ErrInfoSizeMismatch.
+//
+// For example: InfoValue[uint32](DeviceInfoQuery(id, DeviceMaxComputeUnitsInfo))
+func InfoValue[T any](query InfoQuery) (T, error) {
+	var value T
+	size := unsafe.Sizeof(value)
+	requiredSize, err := query.load(0, nil)
+	if err != nil {
+		return value, err
+	}
+	if (requiredSize != size) || (size == 0) {
+		return value, query.sizeMismatch(requiredSize, size)
+	}
+	returnedSize, err := query.load(size, unsafe.Pointer(&value))
+	if err != nil {
+		return value, err
+	}
+	if returnedSize != size {
+		return value, query.sizeMismatch(returnedSize, size)
+	}
+	return value, nil
+}
+
+// InfoSlice returns the information value of a query as a slice of elements of type T.
+//
+// The type T must match the type of the elements, and must not contain Go pointers.
+// The size of the value, as reported by the library, must be a multiple of the size of T. A mismatch results in an
+// error that wraps ErrInfoSizeMismatch. An empty information value results in a nil slice.
+//
+// For example: InfoSlice[uintptr](DeviceInfoQuery(id, DeviceMaxWorkItemSizesInfo))
+func InfoSlice[T any](query InfoQuery) ([]T, error) {
+	var element T
+	elementSize := unsafe.Sizeof(element)
+	requiredSize, err := query.load(0, nil)
+	if err != nil {
+		return nil, err
+	}
+	if requiredSize == 0 {
+		return nil, nil
+	}
+	if (elementSize == 0) || ((requiredSize % elementSize) != 0) {
+		return nil, query.sizeMismatch(requiredSize, elementSize)
+	}
+	if requiredSize > infoSizeLimit {
+		return nil, newOpError(query.function, ErrDataSizeLimitExceeded, query.handles...)
+	}
+	values := make([]T, requiredSize/elementSize)
+	returnedSize, err := query.load(requiredSize, unsafe.Pointer(&values[0]))
+	if err != nil {
+		return nil, err
+	}
+	if (returnedSize > requiredSize) || ((returnedSize % elementSize) != 0) {
+		return nil, query.sizeMismatch(returnedSize, elementSize)
+	}
+	return values[:returnedSize/elementSize], nil
+}
+
+func (query InfoQuery) sizeMismatch(reportedSize, size uintptr) error {
+	return newOpError(query.function,
+		fmt.Errorf("%w: %d bytes reported for a type of %d bytes", ErrInfoSizeMismatch, reportedSize, size),
+		query.handles...)
+}
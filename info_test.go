@@ -0,0 +1,56 @@
+package cl12_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestInfoValue(t *testing.T) {
+	t.Parallel()
+	_, device := stubDevice(t)
+	computeUnits, err := cl.InfoValue[uint32](cl.DeviceInfoQuery(device, cl.DeviceMaxComputeUnitsInfo))
+	if err != nil {
+		t.Fatalf("InfoValue() failed: %v", err)
+	}
+	if computeUnits != 4 {
+		t.Errorf("unexpected number of compute units: %d", computeUnits)
+	}
+	available, err := cl.InfoValue[cl.Bool](cl.DeviceInfoQuery(device, cl.DeviceAvailableInfo))
+	if err != nil {
+		t.Fatalf("InfoValue() failed: %v", err)
+	}
+	if !available.ToGoBool() {
+		t.Errorf("device not available")
+	}
+}
+
+func TestInfoValueWithSizeMismatch(t *testing.T) {
+	t.Parallel()
+	_, device := stubDevice(t)
+	_, err := cl.InfoValue[uint64](cl.DeviceInfoQuery(device, cl.DeviceMaxComputeUnitsInfo))
+	if !errors.Is(err, cl.ErrInfoSizeMismatch) {
+		t.Errorf("expected ErrInfoSizeMismatch, got: %v", err)
+	}
+	var opErr *cl.OpError
+	if !errors.As(err, &opErr) || (opErr.Function != "clGetDeviceInfo") {
+		t.Errorf("expected OpError of clGetDeviceInfo, got: %v", err)
+	}
+}
+
+func TestInfoSlice(t *testing.T) {
+	t.Parallel()
+	_, device := stubDevice(t)
+	sizes, err := cl.InfoSlice[uintptr](cl.DeviceInfoQuery(device, cl.DeviceMaxWorkItemSizesInfo))
+	if err != nil {
+		t.Fatalf("InfoSlice() failed: %v", err)
+	}
+	if (len(sizes) != 3) || (sizes[0] != 1024) || (sizes[2] != 64) {
+		t.Errorf("unexpected sizes: %v", sizes)
+	}
+	_, err = cl.InfoSlice[[5]byte](cl.DeviceInfoQuery(device, cl.DeviceMaxWorkItemSizesInfo))
+	if !errors.Is(err, cl.ErrInfoSizeMismatch) {
+		t.Errorf("expected ErrInfoSizeMismatch, got: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package clgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// binaryPrecedence lists the binary operators of #if expressions, from lowest to highest precedence.
+var binaryPrecedence = [][]string{
+	{"||"}, {"&&"}, {"|"}, {"^"}, {"&"}, {"==", "!="}, {"<", ">", "<=", ">="}, {"<<", ">>"}, {"+", "-"}, {"*", "/", "%"},
+}
+
+// expression evaluates the integer constant expression of an #if directive.
+// Identifiers that remain after macro expansion evaluate to zero.
+type expression struct {
+	tokens []token
+	next   int
+	pos    Position
+}
+
+func evaluate(tokens []token, pos Position) (int64, error) {
+	e := &expression{tokens: tokens, pos: pos}
+	value, err := e.conditional()
+	if err != nil {
+		return 0, err
+	}
+	if e.next < len(e.tokens) {
+		return 0, e.errorf("unexpected %q", e.tokens[e.next].text)
+	}
+	return value, nil
+}
+
+func (e *expression) errorf(format string, args ...any) error {
+	return fmt.Errorf("%v: %w: #if: %s", e.pos, ErrSyntax, fmt.Sprintf(format, args...))
+}
+
+func (e *expression) peek() string {
+	if e.next < len(e.tokens) {
+		return e.tokens[e.next].text
+	}
+	return ""
+}
+
+func (e *expression) conditional() (int64, error) {
+	condition, err := e.binary(0)
+	if (err != nil) || (e.peek() != "?") {
+		return condition, err
+	}
+	e.next++
+	whenTrue, err := e.conditional()
+	if err != nil {
+		return 0, err
+	}
+	if e.peek() != ":" {
+		return 0, e.errorf("missing ':'")
+	}
+	e.next++
+	whenFalse, err := e.conditional()
+	if condition != 0 {
+		return whenTrue, err
+	}
+	return whenFalse, err
+}
+
+func (e *expression) binary(level int) (int64, error) {
+	if level == len(binaryPrecedence) {
+		return e.unary()
+	}
+	left, err := e.binary(level + 1)
+	for err == nil {
+		operator := e.peek()
+		if !containsString(binaryPrecedence[level], operator) {
+			break
+		}
+		e.next++
+		var right int64
+		right, err = e.binary(level + 1)
+		if err == nil {
+			left, err = e.apply(operator, left, right)
+		}
+	}
+	return left, err
+}
+
+func (e *expression) apply(operator string, left, right int64) (int64, error) {
+	switch operator {
+	case "||":
+		return boolValue((left != 0) || (right != 0)), nil
+	case "&&":
+		return boolValue((left != 0) && (right != 0)), nil
+	case "|":
+		return left | right, nil
+	case "^":
+		return left ^ right, nil
+	case "&":
+		return left & right, nil
+	case "==":
+		return boolValue(left == right), nil
+	case "!=":
+		return boolValue(left != right), nil
+	case "<":
+		return boolValue(left < right), nil
+	case ">":
+		return boolValue(left > right), nil
+	case "<=":
+		return boolValue(left <= right), nil
+	case ">=":
+		return boolValue(left >= right), nil
+	case "<<":
+		return left << uint64(right), nil
+	case ">>":
+		return left >> uint64(right), nil
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	}
+	if right == 0 {
+		return 0, e.errorf("division by zero")
+	}
+	if operator == "/" {
+		return left / right, nil
+	}
+	return left % right, nil
+}
+
+func (e *expression) unary() (int64, error) {
+	operator := e.peek()
+	switch operator {
+	case "!", "-", "+", "~":
+		e.next++
+		value, err := e.unary()
+		switch operator {
+		case "!":
+			return boolValue(value == 0), err
+		case "-":
+			return -value, err
+		case "~":
+			return ^value, err
+		}
+		return value, err
+	case "(":
+		e.next++
+		value, err := e.conditional()
+		if err != nil {
+			return 0, err
+		}
+		if e.peek() != ")" {
+			return 0, e.errorf("missing ')'")
+		}
+		e.next++
+		return value, nil
+	case "":
+		return 0, e.errorf("unexpected end of expression")
+	}
+	tok := e.tokens[e.next]
+	e.next++
+	if tok.isIdentifier() {
+		return 0, nil
+	}
+	value, err := strconv.ParseInt(strings.TrimRight(tok.text, "uUlL"), 0, 64)
+	if err != nil {
+		return 0, e.errorf("invalid number %q", tok.text)
+	}
+	return value, nil
+}
+
+func boolValue(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func containsString(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package clgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/opencl-go/cl12/internal/cltypes"
+)
+
+// Config describes the generated file.
+type Config struct {
+	// Package is the name of the Go package.
+	Package string
+	// Sources are the names of the source files, which are mentioned in the header of the generated file.
+	Sources []string
+}
+
+type generatedArg struct {
+	Arg
+	Index  int
+	Field  string
+	GoType string
+	// Size and Value are the expressions for SetKernelArg(); Prepare is an optional statement before them.
+	Prepare string
+	Size    string
+	Value   string
+}
+
+type generatedKernel struct {
+	Kernel
+	TypeName string
+	Args     []generatedArg
+}
+
+// Generate writes a Go source file with launchers for the kernels.
+//
+// For each kernel, the file contains a struct with one field per argument, and a type with a constructor that calls
+// CreateKernel(), and methods that call SetKernelArg() for each argument and EnqueueNDRangeKernel().
+// The Go type of the fields depends on the declaration of the argument:
+// cl.LocalMem for __local pointers; cl.Buffer for __global and __constant pointers to numerical types, with arrays
+// as elements for vector types;
+// cl.MemObject for images and other pointers; cl.Sampler for sampler_t;
+// the corresponding Go type for scalars, such as int32 for int, and arrays for vectors, such as [4]float32 for float4.
+// Other argument types result in an error that wraps ErrUnsupported.
+func Generate(w io.Writer, config Config, kernels []Kernel) error {
+	data := struct {
+		Config
+		Kernels   []generatedKernel
+		UseUnsafe bool
+	}{Config: config}
+	typeNames := make(map[string]string)
+	for _, kernel := range kernels {
+		generated := generatedKernel{Kernel: kernel, TypeName: exportedName(kernel.Name) + "Kernel"}
+		if other, exists := typeNames[generated.TypeName]; exists {
+			return fmt.Errorf("%v: %w: kernels %s and %s both map to %s", kernel.Pos, ErrUnsupported, other, kernel.Name, generated.TypeName)
+		}
+		typeNames[generated.TypeName] = kernel.Name
+		fields := make(map[string]bool)
+		for index, arg := range kernel.Args {
+			generatedArg, err := generateArg(arg, index, fields)
+			if err != nil {
+				return fmt.Errorf("%v: kernel %s: %w", kernel.Pos, kernel.Name, err)
+			}
+			data.UseUnsafe = data.UseUnsafe || (generatedArg.Value != "nil")
+			generated.Args = append(generated.Args, generatedArg)
+		}
+		data.Kernels = append(data.Kernels, generated)
+	}
+	var buf bytes.Buffer
+	if err := launcherTemplate.Execute(&buf, data); err != nil {
+		return err
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %w", err)
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+func generateArg(arg Arg, index int, fields map[string]bool) (generatedArg, error) {
+	generated := generatedArg{Arg: arg, Index: index, Field: exportedName(arg.Name)}
+	for fields[generated.Field] {
+		generated.Field += "_"
+	}
+	fields[generated.Field] = true
+	field := "args." + generated.Field
+	local := fmt.Sprintf("arg%d", index)
+	base, width := cltypes.SplitVector(strings.TrimRight(arg.TypeName, "*"))
+	kind, isScalar := cltypes.ScalarKind(base)
+	goScalar := kind.String()
+	switch {
+	case arg.IsPointer() && (arg.Address == AddressLocal):
+		generated.GoType = "cl.LocalMem"
+		generated.Size, generated.Value = "uintptr("+field+")", "nil"
+		return generated, nil
+	case arg.IsPointer() && isScalar && (goScalar != "uintptr"):
+		generated.GoType = "cl.Buffer[" + bufferElementType(goScalar, width) + "]"
+		generated.Prepare = local + " := " + field + ".MemObject()"
+		field = local
+	case arg.IsPointer() || arg.IsImage():
+		generated.GoType = "cl.MemObject"
+	case arg.TypeName == "sampler_t":
+		generated.GoType = "cl.Sampler"
+	case isScalar && (width == 0):
+		generated.GoType = goScalar
+	case isScalar && (width == 3):
+		generated.GoType = "[3]" + goScalar
+		generated.Prepare = fmt.Sprintf("%s := [4]%s{%s[0], %s[1], %s[2]}", local, goScalar, field, field, field)
+		field = local
+	case isScalar && ((width == 2) || (width == 4) || (width == 8) || (width == 16)):
+		generated.GoType = fmt.Sprintf("[%d]%s", width, goScalar)
+	default:
+		return generatedArg{}, fmt.Errorf("%w: %v", ErrUnsupported, arg)
+	}
+	generated.Size, generated.Value = "unsafe.Sizeof("+field+")", "unsafe.Pointer(&"+field+")"
+	return generated, nil
+}
+
+// bufferElementType returns the element type of a cl.Buffer for a pointer to the scalar or vector type.
+// The 3-component vectors have the size of 4-component vectors.
+func bufferElementType(goScalar string, width int) string {
+	switch width {
+	case 0:
+		return goScalar
+	case 3:
+		return "[4]" + goScalar
+	default:
+		return fmt.Sprintf("[%d]%s", width, goScalar)
+	}
+}
+
+// exportedName converts an OpenCL C identifier, such as "vector_add", to an exported Go name, such as "VectorAdd".
+func exportedName(name string) string {
+	var builder strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		builder.WriteString(string(runes))
+	}
+	exported := builder.String()
+	if (exported == "") || !unicode.IsUpper([]rune(exported)[0]) {
+		exported = "X" + exported
+	}
+	return exported
+}
+
+var launcherTemplate = template.Must(template.New("launcher").Funcs(template.FuncMap{
+	"join": func(names []string) string { return strings.Join(names, ", ") },
+}).Parse(`// Code generated by clgen from {{ join .Sources }}. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- if .UseUnsafe }}
+	"unsafe"
+{{ end }}
+	cl "github.com/opencl-go/cl12"
+)
+{{ range .Kernels }}
+// {{ .TypeName }}Args holds the arguments of the kernel "{{ .Name }}".
+type {{ .TypeName }}Args struct {
+{{- range .Args }}
+	// {{ .Field }} is argument {{ .Index }}: {{ .Arg }}.
+	{{ .Field }} {{ .GoType }}
+{{- end }}
+}
+
+// {{ .TypeName }} launches the kernel "{{ .Name }}", defined at {{ .Pos }}.
+type {{ .TypeName }} struct {
+	kernel cl.Kernel
+}
+
+// New{{ .TypeName }} creates the kernel "{{ .Name }}" from a built program.
+func New{{ .TypeName }}(program cl.Program) ({{ .TypeName }}, error) {
+	kernel, err := cl.CreateKernel(program, "{{ .Name }}")
+	if err != nil {
+		return {{ .TypeName }}{}, err
+	}
+	return {{ .TypeName }}{kernel: kernel}, nil
+}
+
+// Kernel returns the kernel object.
+func (k {{ .TypeName }}) Kernel() cl.Kernel {
+	return k.kernel
+}
+
+// Release decrements the reference count of the kernel object.
+func (k {{ .TypeName }}) Release() error {
+	return cl.ReleaseKernel(k.kernel)
+}
+
+// SetArgs sets all arguments of the kernel.
+func (k {{ .TypeName }}) SetArgs(args {{ .TypeName }}Args) error {
+{{- range .Args }}
+{{- if .Prepare }}
+	{{ .Prepare }}
+{{- end }}
+	if err := cl.SetKernelArg(k.kernel, {{ .Index }}, {{ .Size }}, {{ .Value }}); err != nil {
+		return err
+	}
+{{- end }}
+	return nil
+}
+
+// Enqueue sets all arguments and enqueues the kernel for execution. See cl.EnqueueNDRangeKernel().
+func (k {{ .TypeName }}) Enqueue(queue cl.CommandQueue, workDimensions []cl.WorkDimension, args {{ .TypeName }}Args,
+	waitList []cl.Event, event *cl.Event) error {
+	if err := k.SetArgs(args); err != nil {
+		return err
+	}
+	return cl.EnqueueNDRangeKernel(queue, k.kernel, workDimensions, waitList, event)
+}
+{{ end -}}
+`))
@@ -0,0 +1,102 @@
+package clgen_test
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"testing"
+
+	"github.com/opencl-go/cl12/internal/clgen"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	kernels, err := clgen.ParseFile("testdata/kernels.cl", clgen.Options{})
+	if err != nil {
+		t.Fatalf("ParseFile() failed: %v", err)
+	}
+	var buf bytes.Buffer
+	err = clgen.Generate(&buf, clgen.Config{Package: "kernels", Sources: []string{"kernels.cl"}}, kernels)
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if !regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`).Match(buf.Bytes()) {
+		t.Errorf("missing header of generated code")
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "kernels_cl.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("generated code is invalid: %v\n%s", err, buf.String())
+	}
+	fields := make(map[string]string)
+	functions := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, isType := spec.(*ast.TypeSpec)
+				if !isType {
+					continue
+				}
+				for _, field := range typeSpec.Type.(*ast.StructType).Fields.List {
+					for _, name := range field.Names {
+						fields[typeSpec.Name.Name+"."+name.Name] = types.ExprString(field.Type)
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			if decl.Recv != nil {
+				name = types.ExprString(decl.Recv.List[0].Type) + "." + name
+			}
+			functions[name] = true
+		}
+	}
+	expectedFields := map[string]string{
+		"ScaleKernelArgs.Values":     "cl.Buffer[float32]",
+		"ScaleKernelArgs.Factor":     "float32",
+		"ScaleKernelArgs.Offset":     "[4]float32",
+		"ScaleKernelArgs.Scratch":    "cl.LocalMem",
+		"ScaleKernelArgs.Count":      "uint32",
+		"ScaleKernel.kernel":         "cl.Kernel",
+		"BlendKernelArgs.Input":      "cl.Buffer[float32]",
+		"BlendKernelArgs.Table":      "cl.Buffer[int32]",
+		"BlendKernelArgs.Target":     "cl.MemObject",
+		"BlendKernelArgs.Sampler":    "cl.Sampler",
+		"BlendKernelArgs.Color":      "[3]uint8",
+		"BlendKernelArgs.Seed":       "uint64",
+		"BlendKernelArgs.Length":     "uintptr",
+		"CountItemsKernelArgs.Total": "cl.Buffer[uint32]",
+		"CountItemsKernelArgs.Items": "cl.MemObject",
+		"NoopKernel.kernel":          "cl.Kernel",
+		"ShadeKernelArgs.Pixels":     "cl.Buffer[[4]float32]",
+		"ShadeKernelArgs.Palette":    "cl.Buffer[[4]uint8]",
+	}
+	for name, expected := range expectedFields {
+		if fields[name] != expected {
+			t.Errorf("field %s has type %q, expected %q", name, fields[name], expected)
+		}
+	}
+	for _, name := range []string{"NewScaleKernel", "ScaleKernel.Kernel", "ScaleKernel.Release", "ScaleKernel.SetArgs",
+		"ScaleKernel.Enqueue", "NewNoopKernel", "NoopKernel.Enqueue"} {
+		if !functions[name] {
+			t.Errorf("function %s is missing", name)
+		}
+	}
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	t.Parallel()
+	kernels, err := clgen.ParseSource("test.cl", "__kernel void f(struct params p) {}", clgen.Options{})
+	if err != nil {
+		t.Fatalf("ParseSource() failed: %v", err)
+	}
+	var buf bytes.Buffer
+	err = clgen.Generate(&buf, clgen.Config{Package: "kernels"}, kernels)
+	if !errors.Is(err, clgen.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got: %v", err)
+	}
+}
@@ -0,0 +1,321 @@
+// Package clgen parses the kernel functions of OpenCL C sources, and generates typed Go launchers for them.
+// It is the implementation of the command cmd/clgen and does not need an OpenCL library.
+package clgen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrSyntax is returned for sources that can not be parsed.
+	ErrSyntax = errors.New("syntax error")
+	// ErrIncludeNotFound is returned if the file of an #include directive could not be found.
+	ErrIncludeNotFound = errors.New("include file not found")
+	// ErrUnsupported is returned by Generate() for kernel arguments that have no Go representation.
+	ErrUnsupported = errors.New("unsupported argument")
+)
+
+// Options control the preprocessor.
+type Options struct {
+	// Defines are the macros that are defined before the source is processed, like the compiler option "-D".
+	Defines map[string]string
+	// IncludeDirs are searched for included files, like the compiler option "-I". Files that are included with
+	// quotes are looked up relative to the including file first.
+	IncludeDirs []string
+}
+
+// AddressQualifier identifies the address space of a kernel argument.
+type AddressQualifier int
+
+// These constants are the possible address qualifiers.
+const (
+	AddressPrivate AddressQualifier = iota
+	AddressGlobal
+	AddressConstant
+	AddressLocal
+)
+
+// String returns the qualifier as it is written in OpenCL C.
+func (qualifier AddressQualifier) String() string {
+	return [...]string{"__private", "__global", "__constant", "__local"}[qualifier]
+}
+
+// AccessQualifier identifies how a kernel accesses an image argument.
+type AccessQualifier int
+
+// These constants are the possible access qualifiers.
+const (
+	AccessNone AccessQualifier = iota
+	AccessReadOnly
+	AccessWriteOnly
+	AccessReadWrite
+)
+
+// String returns the qualifier as it is written in OpenCL C, or an empty string for AccessNone.
+func (qualifier AccessQualifier) String() string {
+	return [...]string{"", "__read_only", "__write_only", "__read_write"}[qualifier]
+}
+
+// TypeQualifier is a set of qualifiers of the type of a kernel argument.
+type TypeQualifier int
+
+// These constants are the possible type qualifiers.
+const (
+	TypeConst TypeQualifier = 1 << iota
+	TypeRestrict
+	TypeVolatile
+)
+
+// String returns the qualifiers as they are written in OpenCL C.
+func (qualifier TypeQualifier) String() string {
+	var names []string
+	for i, name := range []string{"const", "restrict", "volatile"} {
+		if qualifier&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// Arg describes an argument of a kernel function.
+type Arg struct {
+	// Name is the name of the argument.
+	Name string
+	// TypeName is the name of the type, after macro expansion, in the form reported by CL_KERNEL_ARG_TYPE_NAME:
+	// Whitespace is removed, unsigned types use their short name, such as "uint", and pointers end with "*".
+	TypeName   string
+	Address    AddressQualifier
+	Access     AccessQualifier
+	Qualifiers TypeQualifier
+}
+
+// IsPointer returns true if the argument is a pointer.
+func (arg Arg) IsPointer() bool {
+	return strings.HasSuffix(arg.TypeName, "*")
+}
+
+// IsImage returns true if the argument is an image.
+func (arg Arg) IsImage() bool {
+	return strings.HasPrefix(arg.TypeName, "image")
+}
+
+// String returns the declaration of the argument in OpenCL C.
+func (arg Arg) String() string {
+	var parts []string
+	switch {
+	case arg.IsImage():
+		parts = append(parts, arg.Access.String())
+	case arg.IsPointer():
+		parts = append(parts, arg.Address.String())
+	}
+	if arg.Qualifiers&TypeConst != 0 {
+		parts = append(parts, "const")
+	}
+	parts = append(parts, arg.TypeName)
+	if arg.Qualifiers&TypeRestrict != 0 {
+		parts = append(parts, "restrict")
+	}
+	return strings.Join(append(parts, arg.Name), " ")
+}
+
+// Kernel describes a kernel function.
+type Kernel struct {
+	Name string
+	Args []Arg
+	// Pos is the position of the name of the function. If the name is the result of a macro, it is the position
+	// where the macro was used.
+	Pos Position
+}
+
+// ParseFile parses the kernel functions of an OpenCL C source file, and of the files it includes.
+// The kernels are returned in the order of their definitions; declarations without a body are ignored.
+func ParseFile(path string, options Options) ([]Kernel, error) {
+	p := newPreprocessor(options)
+	if err := p.processFile(path, Position{}); err != nil {
+		return nil, err
+	}
+	return parseKernels(p.out)
+}
+
+// ParseSource parses the kernel functions of an OpenCL C source, which is identified by name in positions.
+// Files included with quotes are looked up relative to the directory of name. See ParseFile().
+func ParseSource(name string, source string, options Options) ([]Kernel, error) {
+	p := newPreprocessor(options)
+	if err := p.process(name, source); err != nil {
+		return nil, err
+	}
+	return parseKernels(p.out)
+}
+
+func parseKernels(tokens []token) ([]Kernel, error) {
+	var kernels []Kernel
+	defined := make(map[string]Position)
+	for i := 0; i < len(tokens); i++ {
+		if (tokens[i].text != "__kernel") && (tokens[i].text != "kernel") {
+			continue
+		}
+		kernel, next, err := parseKernel(tokens, i+1)
+		if err != nil {
+			return nil, err
+		}
+		i = next - 1
+		if kernel == nil {
+			continue
+		}
+		if previous, exists := defined[kernel.Name]; exists {
+			return nil, fmt.Errorf("%v: %w: kernel %s already defined at %v", kernel.Pos, ErrSyntax, kernel.Name, previous)
+		}
+		defined[kernel.Name] = kernel.Pos
+		kernels = append(kernels, *kernel)
+	}
+	return kernels, nil
+}
+
+// parseKernel parses the function that follows a __kernel qualifier, starting at the given token.
+// It returns nil for declarations without a body, and the index of the token after the parameter list.
+func parseKernel(tokens []token, start int) (*Kernel, int, error) {
+	i := skipAttributes(tokens, start)
+	if (i >= len(tokens)) || (tokens[i].text != "void") {
+		return nil, 0, fmt.Errorf("%v: %w: kernel function must return void", tokens[start-1].pos, ErrSyntax)
+	}
+	i = skipAttributes(tokens, i+1)
+	if (i+1 >= len(tokens)) || !tokens[i].isIdentifier() || (tokens[i+1].text != "(") {
+		return nil, 0, fmt.Errorf("%v: %w: expected name and parameters of kernel function", tokens[start-1].pos, ErrSyntax)
+	}
+	kernel := &Kernel{Name: tokens[i].text, Pos: tokens[i].pos}
+	params := [][]token{nil}
+	depth := 0
+	for i += 2; i < len(tokens); i++ {
+		tok := tokens[i]
+		if (tok.text == ")") && (depth == 0) {
+			break
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case ",":
+			if depth == 0 {
+				params = append(params, nil)
+				continue
+			}
+		}
+		params[len(params)-1] = append(params[len(params)-1], tok)
+	}
+	if i >= len(tokens) {
+		return nil, 0, fmt.Errorf("%v: %w: unterminated parameters of kernel %s", kernel.Pos, ErrSyntax, kernel.Name)
+	}
+	next := skipAttributes(tokens, i+1)
+	if (next >= len(tokens)) || (tokens[next].text != "{") {
+		return nil, next, nil
+	}
+	if (len(params) == 1) && ((len(params[0]) == 0) || ((len(params[0]) == 1) && (params[0][0].text == "void"))) {
+		return kernel, next, nil
+	}
+	for index, param := range params {
+		arg, err := parseArg(param)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%v: kernel %s, argument %d: %w", kernel.Pos, kernel.Name, index, err)
+		}
+		kernel.Args = append(kernel.Args, arg)
+	}
+	return kernel, next, nil
+}
+
+// skipAttributes returns the index of the first token at or after start that is not part of an __attribute__.
+func skipAttributes(tokens []token, start int) int {
+	i := start
+	for (i+1 < len(tokens)) && (tokens[i].text == "__attribute__") && (tokens[i+1].text == "(") {
+		depth := 0
+		for i++; i < len(tokens); i++ {
+			if tokens[i].text == "(" {
+				depth++
+			} else if tokens[i].text == ")" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		i++
+	}
+	return i
+}
+
+func parseArg(tokens []token) (Arg, error) {
+	var arg Arg
+	var words []string
+	pointers := 0
+	unsigned := false
+	addressSet := false
+	for i := skipAttributes(tokens, 0); i < len(tokens); i = skipAttributes(tokens, i+1) {
+		text := tokens[i].text
+		switch text {
+		case "__global", "global":
+			arg.Address, addressSet = AddressGlobal, true
+		case "__constant", "constant":
+			arg.Address, addressSet = AddressConstant, true
+		case "__local", "local":
+			arg.Address, addressSet = AddressLocal, true
+		case "__private", "private":
+			arg.Address, addressSet = AddressPrivate, true
+		case "__read_only", "read_only":
+			arg.Access = AccessReadOnly
+		case "__write_only", "write_only":
+			arg.Access = AccessWriteOnly
+		case "__read_write", "read_write":
+			arg.Access = AccessReadWrite
+		case "const":
+			arg.Qualifiers |= TypeConst
+		case "restrict", "__restrict":
+			arg.Qualifiers |= TypeRestrict
+		case "volatile":
+			arg.Qualifiers |= TypeVolatile
+		case "unsigned":
+			unsigned = true
+		case "signed", "struct", "union", "enum":
+		case "*":
+			pointers++
+		case "[":
+			return Arg{}, fmt.Errorf("%w: arrays are not allowed as kernel arguments", ErrSyntax)
+		default:
+			if !tokens[i].isIdentifier() {
+				return Arg{}, fmt.Errorf("%w: unexpected %q", ErrSyntax, text)
+			}
+			words = append(words, text)
+		}
+	}
+	if len(words) == 0 {
+		return Arg{}, fmt.Errorf("%w: missing name", ErrSyntax)
+	}
+	arg.Name, words = words[len(words)-1], words[:len(words)-1]
+	if (len(words) > 1) && (words[len(words)-1] == "int") {
+		words = words[:len(words)-1]
+	}
+	arg.TypeName = strings.Join(words, "")
+	if unsigned {
+		if arg.TypeName == "" {
+			arg.TypeName = "int"
+		}
+		arg.TypeName = "u" + arg.TypeName
+	}
+	if arg.TypeName == "" {
+		return Arg{}, fmt.Errorf("%w: missing type of %s", ErrSyntax, arg.Name)
+	}
+	if arg.IsImage() {
+		arg.Address = AddressGlobal
+		if arg.Access == AccessNone {
+			arg.Access = AccessReadOnly
+		}
+	}
+	if pointers > 0 {
+		if !addressSet || (arg.Address == AddressPrivate) {
+			return Arg{}, fmt.Errorf("%w: pointer %s must be in the __global, __constant, or __local address space", ErrSyntax, arg.Name)
+		}
+		arg.TypeName += strings.Repeat("*", pointers)
+	}
+	return arg, nil
+}
@@ -0,0 +1,151 @@
+package clgen_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opencl-go/cl12/internal/clgen"
+)
+
+func TestParseFile(t *testing.T) {
+	t.Parallel()
+	kernels, err := clgen.ParseFile("testdata/kernels.cl", clgen.Options{})
+	if err != nil {
+		t.Fatalf("ParseFile() failed: %v", err)
+	}
+	expected := []clgen.Kernel{
+		{
+			Name: "scale",
+			Pos:  clgen.Position{File: "testdata/kernels.cl", Line: 10},
+			Args: []clgen.Arg{
+				{Name: "values", TypeName: "float*", Address: clgen.AddressGlobal},
+				{Name: "factor", TypeName: "float", Qualifiers: clgen.TypeConst},
+				{Name: "offset", TypeName: "float4"},
+				{Name: "scratch", TypeName: "float*", Address: clgen.AddressLocal},
+				{Name: "count", TypeName: "uint"},
+			},
+		},
+		{
+			Name: "blend",
+			Pos:  clgen.Position{File: "testdata/kernels.cl", Line: 16},
+			Args: []clgen.Arg{
+				{Name: "input", TypeName: "float*", Address: clgen.AddressGlobal, Qualifiers: clgen.TypeConst | clgen.TypeRestrict},
+				{Name: "table", TypeName: "int*", Address: clgen.AddressConstant},
+				{Name: "target", TypeName: "image2d_t", Address: clgen.AddressGlobal, Access: clgen.AccessWriteOnly},
+				{Name: "sampler", TypeName: "sampler_t"},
+				{Name: "color", TypeName: "uchar3"},
+				{Name: "seed", TypeName: "ulong"},
+				{Name: "length", TypeName: "size_t"},
+			},
+		},
+		{
+			Name: "count_items",
+			Pos:  clgen.Position{File: "testdata/kernels.cl", Line: 28},
+			Args: []clgen.Arg{
+				{Name: "total", TypeName: "uint*", Address: clgen.AddressGlobal, Qualifiers: clgen.TypeVolatile},
+				{Name: "items", TypeName: "void*", Address: clgen.AddressGlobal},
+			},
+		},
+		{
+			Name: "noop",
+			Pos:  clgen.Position{File: "testdata/kernels.cl", Line: 33},
+		},
+		{
+			Name: "shade",
+			Pos:  clgen.Position{File: "testdata/kernels.cl", Line: 35},
+			Args: []clgen.Arg{
+				{Name: "pixels", TypeName: "float4*", Address: clgen.AddressGlobal},
+				{Name: "palette", TypeName: "uchar3*", Address: clgen.AddressConstant},
+			},
+		},
+	}
+	if !reflect.DeepEqual(kernels, expected) {
+		t.Errorf("unexpected kernels:\n%+v\nexpected:\n%+v", kernels, expected)
+	}
+}
+
+func TestParseFileWithDefines(t *testing.T) {
+	t.Parallel()
+	kernels, err := clgen.ParseFile("testdata/kernels.cl", clgen.Options{
+		Defines: map[string]string{"USE_DOUBLE": "", "WITH_HISTOGRAM": "2"},
+	})
+	if err != nil {
+		t.Fatalf("ParseFile() failed: %v", err)
+	}
+	var names []string
+	for _, kernel := range kernels {
+		names = append(names, kernel.Name)
+	}
+	if strings.Join(names, " ") != "scale blend histogram noop shade" {
+		t.Errorf("unexpected kernels: %v", names)
+	}
+	if (kernels[0].Args[0].TypeName != "double*") || (kernels[0].Args[2].TypeName != "double4") {
+		t.Errorf("unexpected arguments: %+v", kernels[0].Args)
+	}
+}
+
+func TestParseSourceErrors(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		name     string
+		source   string
+		expected error
+		mention  string
+	}{
+		{name: "missing include", source: "\n#include \"missing.h\"", expected: clgen.ErrIncludeNotFound, mention: "test.cl:2"},
+		{name: "include cycle", source: `#include "cycle.h"`, expected: clgen.ErrSyntax, mention: "nested too deeply"},
+		{name: "unterminated if", source: "#ifdef A\n#if 1\n#endif", expected: clgen.ErrSyntax, mention: "test.cl:1"},
+		{name: "else without if", source: "#else", expected: clgen.ErrSyntax, mention: "test.cl:1"},
+		{name: "error directive", source: "#if !defined(A)\n#error A is required\n#endif", expected: clgen.ErrSyntax, mention: "A is required"},
+		{name: "macro arguments", source: "#define F(a, b) a\nF(1)", expected: clgen.ErrSyntax, mention: "expects 2 arguments"},
+		{name: "private pointer", source: "__kernel void f(float *values) {}", expected: clgen.ErrSyntax, mention: "values"},
+		{name: "array argument", source: "__kernel void f(__global float values[4]) {}", expected: clgen.ErrSyntax, mention: "arrays"},
+		{name: "return type", source: "__kernel int f(void) {}", expected: clgen.ErrSyntax, mention: "void"},
+		{
+			name:     "duplicate kernel",
+			source:   "__kernel void f(void) {}\n__kernel void f(void) {}",
+			expected: clgen.ErrSyntax,
+			mention:  "already defined at testdata/test.cl:1",
+		},
+	}
+	for _, tc := range tt {
+		_, err := clgen.ParseSource("testdata/test.cl", tc.source, clgen.Options{})
+		if !errors.Is(err, tc.expected) {
+			t.Errorf("%s: expected %v, got: %v", tc.name, tc.expected, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.mention) {
+			t.Errorf("%s: error does not mention %q: %v", tc.name, tc.mention, err)
+		}
+	}
+}
+
+func TestParseSourceMacros(t *testing.T) {
+	t.Parallel()
+	source := `
+#define ARGS(...) __VA_ARGS__
+#define NAME(prefix, type) prefix##_##type
+#define DECLARE(type) __kernel void NAME(fill, type)(ARGS(__global type *values, type value)) {}
+#if (1 << 4) / 2 == 8 && defined NAME && !defined(MISSING) ? 1 : 0
+DECLARE(int)
+#endif
+DECLARE(float2)
+#undef DECLARE
+DECLARE(char)
+`
+	kernels, err := clgen.ParseSource("macros.cl", source, clgen.Options{})
+	if err != nil {
+		t.Fatalf("ParseSource() failed: %v", err)
+	}
+	if len(kernels) != 2 {
+		t.Fatalf("unexpected kernels: %+v", kernels)
+	}
+	if (kernels[0].Name != "fill_int") || (kernels[0].Args[1].TypeName != "int") || (kernels[0].Pos.Line != 6) {
+		t.Errorf("unexpected first kernel: %+v", kernels[0])
+	}
+	if (kernels[1].Name != "fill_float2") || (kernels[1].Args[0].TypeName != "float2*") {
+		t.Errorf("unexpected second kernel: %+v", kernels[1])
+	}
+}
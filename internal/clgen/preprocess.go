@@ -0,0 +1,567 @@
+package clgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxIncludeDepth limits nested includes, which catches include cycles without guards.
+const maxIncludeDepth = 64
+
+// Position identifies a line in a source file.
+type Position struct {
+	File string
+	Line int
+}
+
+// String returns the position in the form "file:line".
+func (pos Position) String() string {
+	return fmt.Sprintf("%s:%d", pos.File, pos.Line)
+}
+
+// token is a preprocessing token. The hide set contains the names of the macros that produced the token,
+// which are not expanded again.
+type token struct {
+	text  string
+	pos   Position
+	space bool
+	hide  map[string]bool
+}
+
+func (tok token) isIdentifier() bool {
+	c := tok.text[0]
+	return (c == '_') || ((c >= 'a') && (c <= 'z')) || ((c >= 'A') && (c <= 'Z'))
+}
+
+type macro struct {
+	function bool
+	params   []string
+	variadic bool
+	body     []token
+}
+
+type conditional struct {
+	pos    Position
+	active bool
+	taken  bool
+	outer  bool
+}
+
+type preprocessor struct {
+	macros      map[string]macro
+	includeDirs []string
+	depth       int
+	pending     []token
+	out         []token
+}
+
+func newPreprocessor(options Options) *preprocessor {
+	p := &preprocessor{
+		macros:      make(map[string]macro),
+		includeDirs: options.IncludeDirs,
+	}
+	for name, value := range options.Defines {
+		body := tokenize(value, Position{File: "<command-line>", Line: 1})
+		p.macros[name] = macro{body: body}
+	}
+	return p
+}
+
+func (p *preprocessor) processFile(path string, includedAt Position) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		if includedAt.File != "" {
+			return fmt.Errorf("%v: %w", includedAt, err)
+		}
+		return err
+	}
+	return p.process(filepath.ToSlash(path), string(source))
+}
+
+func (p *preprocessor) process(name, source string) error {
+	var conditionals []conditional
+	active := func() bool {
+		return (len(conditionals) == 0) || conditionals[len(conditionals)-1].active
+	}
+	for _, line := range logicalLines(stripComments(source)) {
+		pos := Position{File: name, Line: line.number}
+		text := strings.TrimSpace(line.text)
+		if !strings.HasPrefix(text, "#") {
+			if active() {
+				p.pending = append(p.pending, tokenize(line.text, pos)...)
+			}
+			continue
+		}
+		tokens := tokenize(text[1:], pos)
+		if len(tokens) == 0 {
+			continue
+		}
+		directive, args := tokens[0].text, tokens[1:]
+		var err error
+		switch directive {
+		case "if", "ifdef", "ifndef":
+			outer := active()
+			taken := false
+			if outer {
+				taken, err = p.evaluateCondition(directive, args, pos)
+			}
+			conditionals = append(conditionals, conditional{pos: pos, active: outer && taken, taken: taken, outer: outer})
+		case "elif", "else":
+			if len(conditionals) == 0 {
+				return fmt.Errorf("%v: %w: #%s without #if", pos, ErrSyntax, directive)
+			}
+			current := &conditionals[len(conditionals)-1]
+			taken := false
+			if current.outer && !current.taken {
+				taken = true
+				if directive == "elif" {
+					taken, err = p.evaluateCondition("if", args, pos)
+				}
+			}
+			current.active = taken
+			current.taken = current.taken || taken
+		case "endif":
+			if len(conditionals) == 0 {
+				return fmt.Errorf("%v: %w: #endif without #if", pos, ErrSyntax)
+			}
+			conditionals = conditionals[:len(conditionals)-1]
+		default:
+			if active() {
+				err = p.directive(directive, args, pos)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(conditionals) > 0 {
+		return fmt.Errorf("%v: %w: unterminated #if", conditionals[len(conditionals)-1].pos, ErrSyntax)
+	}
+	return p.flush()
+}
+
+// directive handles all directives other than conditionals, for active lines.
+func (p *preprocessor) directive(directive string, args []token, pos Position) error {
+	switch directive {
+	case "define":
+		if err := p.flush(); err != nil {
+			return err
+		}
+		return p.define(args, pos)
+	case "undef":
+		if err := p.flush(); err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			return fmt.Errorf("%v: %w: #undef without name", pos, ErrSyntax)
+		}
+		delete(p.macros, args[0].text)
+	case "include":
+		if err := p.flush(); err != nil {
+			return err
+		}
+		return p.include(args, pos)
+	case "error":
+		return fmt.Errorf("%v: %w: #error %s", pos, ErrSyntax, joinTokens(args))
+	}
+	return nil
+}
+
+func (p *preprocessor) define(args []token, pos Position) error {
+	if (len(args) == 0) || !args[0].isIdentifier() {
+		return fmt.Errorf("%v: %w: #define without name", pos, ErrSyntax)
+	}
+	name := args[0].text
+	var m macro
+	body := args[1:]
+	if (len(body) > 0) && (body[0].text == "(") && !body[0].space {
+		m.function = true
+		i := 1
+		for ; (i < len(body)) && (body[i].text != ")"); i++ {
+			switch {
+			case body[i].text == ",":
+			case body[i].text == "...":
+				m.variadic = true
+				m.params = append(m.params, "__VA_ARGS__")
+			case body[i].isIdentifier():
+				m.params = append(m.params, body[i].text)
+			default:
+				return fmt.Errorf("%v: %w: invalid parameter %q of macro %s", pos, ErrSyntax, body[i].text, name)
+			}
+		}
+		if i == len(body) {
+			return fmt.Errorf("%v: %w: unterminated parameter list of macro %s", pos, ErrSyntax, name)
+		}
+		body = body[i+1:]
+	}
+	m.body = body
+	p.macros[name] = m
+	return nil
+}
+
+func (p *preprocessor) include(args []token, pos Position) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%v: %w: #include without file", pos, ErrSyntax)
+	}
+	var name string
+	var dirs []string
+	switch {
+	case strings.HasPrefix(args[0].text, `"`):
+		name = strings.Trim(args[0].text, `"`)
+		dirs = append([]string{filepath.Dir(filepath.FromSlash(pos.File))}, p.includeDirs...)
+	case args[0].text == "<":
+		for _, tok := range args[1:] {
+			if tok.text == ">" {
+				break
+			}
+			name += tok.text
+		}
+		dirs = p.includeDirs
+	default:
+		return fmt.Errorf("%v: %w: invalid #include %s", pos, ErrSyntax, joinTokens(args))
+	}
+	if p.depth >= maxIncludeDepth {
+		return fmt.Errorf("%v: %w: includes nested too deeply at %q", pos, ErrSyntax, name)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		p.depth++
+		err := p.processFile(path, pos)
+		p.depth--
+		return err
+	}
+	return fmt.Errorf("%v: %w: %q", pos, ErrIncludeNotFound, name)
+}
+
+func (p *preprocessor) evaluateCondition(directive string, args []token, pos Position) (bool, error) {
+	if directive != "if" {
+		if len(args) == 0 {
+			return false, fmt.Errorf("%v: %w: #%s without name", pos, ErrSyntax, directive)
+		}
+		_, defined := p.macros[args[0].text]
+		return defined == (directive == "ifdef"), nil
+	}
+	var resolved []token
+	for i := 0; i < len(args); i++ {
+		if args[i].text != "defined" {
+			resolved = append(resolved, args[i])
+			continue
+		}
+		name := ""
+		switch {
+		case (i+3 < len(args)) && (args[i+1].text == "(") && (args[i+3].text == ")"):
+			name = args[i+2].text
+			i += 3
+		case i+1 < len(args):
+			name = args[i+1].text
+			i++
+		}
+		value := "0"
+		if _, defined := p.macros[name]; defined {
+			value = "1"
+		}
+		resolved = append(resolved, token{text: value, pos: args[i].pos})
+	}
+	expanded, err := p.expand(resolved)
+	if err != nil {
+		return false, err
+	}
+	value, err := evaluate(expanded, pos)
+	return value != 0, err
+}
+
+// flush expands the pending text tokens and appends them to the output.
+func (p *preprocessor) flush() error {
+	expanded, err := p.expand(p.pending)
+	p.pending = nil
+	p.out = append(p.out, expanded...)
+	return err
+}
+
+// expand replaces all macros in the tokens, rescanning the replacements together with the tokens that follow.
+func (p *preprocessor) expand(tokens []token) ([]token, error) {
+	var out []token
+	rest := tokens
+	for len(rest) > 0 {
+		tok := rest[0]
+		m, isMacro := p.macros[tok.text]
+		if !isMacro || tok.hide[tok.text] {
+			out = append(out, tok)
+			rest = rest[1:]
+			continue
+		}
+		hide := withName(tok.hide, tok.text)
+		if !m.function {
+			rest = append(p.substitute(m, nil, hide, tok), rest[1:]...)
+			continue
+		}
+		if (len(rest) < 2) || (rest[1].text != "(") {
+			out = append(out, tok)
+			rest = rest[1:]
+			continue
+		}
+		args, remaining, err := macroArgs(rest[2:], tok)
+		if err != nil {
+			return nil, err
+		}
+		args, err = p.matchArgs(m, args, tok)
+		if err != nil {
+			return nil, err
+		}
+		rest = append(p.substitute(m, args, hide, tok), remaining...)
+	}
+	return out, nil
+}
+
+// macroArgs collects the arguments of a function-like macro call, up to the closing parenthesis.
+func macroArgs(tokens []token, call token) ([][]token, []token, error) {
+	args := [][]token{nil}
+	depth := 0
+	for i, tok := range tokens {
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			if depth == 0 {
+				return args, tokens[i+1:], nil
+			}
+			depth--
+		case ",":
+			if depth == 0 {
+				args = append(args, nil)
+				continue
+			}
+		}
+		args[len(args)-1] = append(args[len(args)-1], tok)
+	}
+	return nil, nil, fmt.Errorf("%v: %w: unterminated call of macro %s", call.pos, ErrSyntax, call.text)
+}
+
+func (p *preprocessor) matchArgs(m macro, args [][]token, call token) ([][]token, error) {
+	if (len(m.params) == 0) && (len(args) == 1) && (len(args[0]) == 0) {
+		return nil, nil
+	}
+	if m.variadic && (len(args) >= len(m.params)) {
+		variadic := args[len(m.params)-1]
+		for _, arg := range args[len(m.params):] {
+			variadic = append(variadic, token{text: ",", pos: call.pos})
+			variadic = append(variadic, arg...)
+		}
+		return append(args[:len(m.params)-1], variadic), nil
+	}
+	if len(args) != len(m.params) {
+		return nil, fmt.Errorf("%v: %w: macro %s expects %d arguments, got %d", call.pos, ErrSyntax, call.text, len(m.params), len(args))
+	}
+	return args, nil
+}
+
+// substitute returns the body of the macro with its parameters replaced, for the call at the given token.
+func (p *preprocessor) substitute(m macro, args [][]token, hide map[string]bool, call token) []token {
+	paramIndex := func(tok token) int {
+		for i, param := range m.params {
+			if tok.text == param {
+				return i
+			}
+		}
+		return -1
+	}
+	var out []token
+	body := m.body
+	for i := 0; i < len(body); i++ {
+		tok := body[i]
+		switch {
+		case m.function && (tok.text == "#") && (i+1 < len(body)) && (paramIndex(body[i+1]) >= 0):
+			out = append(out, token{text: strconv.Quote(joinTokens(args[paramIndex(body[i+1])]))})
+			i++
+		case (tok.text == "##") && (len(out) > 0) && (i+1 < len(body)):
+			next := []token{body[i+1]}
+			if index := paramIndex(body[i+1]); m.function && (index >= 0) {
+				next = args[index]
+			}
+			if len(next) > 0 {
+				out[len(out)-1].text += next[0].text
+				out = append(out, next[1:]...)
+			}
+			i++
+		case m.function && (paramIndex(tok) >= 0):
+			arg := args[paramIndex(tok)]
+			if (i+1 >= len(body)) || (body[i+1].text != "##") {
+				// Arguments are expanded on their own, unless they are pasted. Errors surface when the
+				// unexpanded call is met again during the rescan.
+				if expanded, err := p.expand(arg); err == nil {
+					arg = expanded
+				}
+			}
+			out = append(out, arg...)
+		default:
+			out = append(out, tok)
+		}
+	}
+	for i := range out {
+		out[i].pos = call.pos
+		out[i].hide = unionNames(out[i].hide, hide)
+	}
+	if len(out) > 0 {
+		out[0].space = call.space
+	}
+	return out
+}
+
+func withName(names map[string]bool, name string) map[string]bool {
+	return unionNames(names, map[string]bool{name: true})
+}
+
+func unionNames(a, b map[string]bool) map[string]bool {
+	if len(a) == 0 {
+		return b
+	}
+	union := make(map[string]bool, len(a)+len(b))
+	for name := range a {
+		union[name] = true
+	}
+	for name := range b {
+		union[name] = true
+	}
+	return union
+}
+
+func joinTokens(tokens []token) string {
+	var builder strings.Builder
+	for i, tok := range tokens {
+		if (i > 0) && tok.space {
+			builder.WriteByte(' ')
+		}
+		builder.WriteString(tok.text)
+	}
+	return builder.String()
+}
+
+type logicalLine struct {
+	number int
+	text   string
+}
+
+// logicalLines splits the source into lines, joining lines that end with a backslash.
+func logicalLines(source string) []logicalLine {
+	var lines []logicalLine
+	var current *logicalLine
+	for i, physical := range strings.Split(source, "\n") {
+		physical = strings.TrimSuffix(physical, "\r")
+		continued := strings.HasSuffix(physical, "\\")
+		physical = strings.TrimSuffix(physical, "\\")
+		if current == nil {
+			lines = append(lines, logicalLine{number: i + 1})
+			current = &lines[len(lines)-1]
+		}
+		current.text += physical
+		if !continued {
+			current = nil
+		}
+	}
+	return lines
+}
+
+// stripComments replaces comments with a space, keeping the line breaks of block comments.
+func stripComments(source string) string {
+	var builder strings.Builder
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case (c == '"') || (c == '\''):
+			end := i + 1
+			for (end < len(source)) && (source[end] != c) && (source[end] != '\n') {
+				if source[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(source) {
+				end = len(source) - 1
+			}
+			builder.WriteString(source[i : end+1])
+			i = end
+		case (c == '/') && (i+1 < len(source)) && (source[i+1] == '/'):
+			for (i+1 < len(source)) && (source[i+1] != '\n') {
+				i++
+			}
+			builder.WriteByte(' ')
+		case (c == '/') && (i+1 < len(source)) && (source[i+1] == '*'):
+			end := strings.Index(source[i+2:], "*/")
+			comment := source[i:]
+			if end >= 0 {
+				comment = source[i : i+2+end+2]
+			}
+			builder.WriteByte(' ')
+			builder.WriteString(strings.Repeat("\n", strings.Count(comment, "\n")))
+			i += len(comment) - 1
+		default:
+			builder.WriteByte(c)
+		}
+	}
+	return builder.String()
+}
+
+var punctuators = []string{"...", "##", "&&", "||", "==", "!=", "<=", ">=", "<<", ">>", "->", "++", "--"}
+
+// tokenize splits a line into preprocessing tokens.
+func tokenize(text string, pos Position) []token {
+	var tokens []token
+	space := false
+	for i := 0; i < len(text); {
+		c := text[i]
+		start := i
+		switch {
+		case (c == ' ') || (c == '\t') || (c == '\r') || (c == '\f') || (c == '\v'):
+			space = true
+			i++
+			continue
+		case isIdentifierChar(c) && !isDigit(c):
+			for (i < len(text)) && isIdentifierChar(text[i]) {
+				i++
+			}
+		case isDigit(c) || ((c == '.') && (i+1 < len(text)) && isDigit(text[i+1])):
+			for (i < len(text)) && (isIdentifierChar(text[i]) || (text[i] == '.') ||
+				(((text[i] == '+') || (text[i] == '-')) && strings.ContainsRune("eEpP", rune(text[i-1])))) {
+				i++
+			}
+		case (c == '"') || (c == '\''):
+			i++
+			for (i < len(text)) && (text[i] != c) {
+				if text[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i < len(text) {
+				i++
+			} else {
+				i = len(text)
+			}
+		default:
+			i++
+			for _, punctuator := range punctuators {
+				if strings.HasPrefix(text[start:], punctuator) {
+					i = start + len(punctuator)
+					break
+				}
+			}
+		}
+		tokens = append(tokens, token{text: text[start:i], pos: pos, space: space})
+		space = false
+	}
+	return tokens
+}
+
+func isDigit(c byte) bool {
+	return (c >= '0') && (c <= '9')
+}
+
+func isIdentifierChar(c byte) bool {
+	return (c == '_') || isDigit(c) || ((c >= 'a') && (c <= 'z')) || ((c >= 'A') && (c <= 'Z'))
+}
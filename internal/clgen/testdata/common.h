@@ -0,0 +1,14 @@
+#ifndef COMMON_H
+#define COMMON_H
+
+#ifdef USE_DOUBLE
+#define REAL double
+#else
+#define REAL float
+#endif
+
+#define REAL4 CONCAT(REAL, 4)
+#define CONCAT(a, b) CONCAT_(a, b)
+#define CONCAT_(a, b) a##b
+
+#endif
@@ -0,0 +1,35 @@
+#include "common.h"
+#include "common.h"
+
+/* A kernel that is not a kernel: __kernel void hidden(void) {} */
+// __kernel void commented(void) {}
+
+#define KERNEL __kernel __attribute__((reqd_work_group_size(64, 1, 1)))
+#define GLOBAL_PTR(type) __global type *
+
+KERNEL void scale(GLOBAL_PTR(REAL) values, const REAL factor, REAL4 offset,
+                  __local REAL *scratch, unsigned int count)
+{
+    values[get_global_id(0)] *= factor;
+}
+
+__kernel void blend(__global const float * restrict input, __constant int *table,
+    __write_only image2d_t target, sampler_t sampler,
+    uchar3 color, unsigned long seed, size_t \
+    length)
+{
+}
+
+#if defined(WITH_HISTOGRAM) && (WITH_HISTOGRAM > 1)
+__kernel void histogram(__global uint *bins) {}
+#elif 0
+__kernel void never(__global uint *bins) {}
+#else
+__kernel void count_items(__global volatile uint *total, __global void *items) {}
+#endif
+
+__kernel void prototype(__global int *values);
+
+__kernel void noop(void) {}
+
+__kernel void shade(__global float4 *pixels, __constant uchar3 *palette) {}
@@ -0,0 +1,45 @@
+// Package cltypes describes the scalar and vector types of OpenCL C, and how they correspond to Go types.
+package cltypes
+
+import (
+	"reflect"
+	"strings"
+)
+
+// scalarKinds maps the scalar types of OpenCL C to the kinds of the corresponding Go types.
+// The type half has no Go counterpart; its bits are presented as uint16.
+var scalarKinds = map[string]reflect.Kind{
+	"char":      reflect.Int8,
+	"uchar":     reflect.Uint8,
+	"short":     reflect.Int16,
+	"ushort":    reflect.Uint16,
+	"half":      reflect.Uint16,
+	"int":       reflect.Int32,
+	"uint":      reflect.Uint32,
+	"long":      reflect.Int64,
+	"ulong":     reflect.Uint64,
+	"float":     reflect.Float32,
+	"double":    reflect.Float64,
+	"size_t":    reflect.Uintptr,
+	"ptrdiff_t": reflect.Uintptr,
+	"intptr_t":  reflect.Uintptr,
+	"uintptr_t": reflect.Uintptr,
+}
+
+// ScalarKind returns the kind of the Go type that corresponds to the named scalar type of OpenCL C,
+// such as reflect.Float32 for "float". The name of the kind, such as "float32", is the name of the Go type.
+func ScalarKind(name string) (reflect.Kind, bool) {
+	kind, known := scalarKinds[name]
+	return kind, known
+}
+
+// SplitVector splits a type name such as "float4" into its base type and the vector width.
+// The width is zero for scalar types.
+func SplitVector(typeName string) (string, int) {
+	base := strings.TrimRight(typeName, "0123456789")
+	width := 0
+	for _, digit := range typeName[len(base):] {
+		width = width*10 + int(digit-'0')
+	}
+	return base, width
+}
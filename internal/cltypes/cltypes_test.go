@@ -0,0 +1,41 @@
+package cltypes_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opencl-go/cl12/internal/cltypes"
+)
+
+func TestSplitVector(t *testing.T) {
+	t.Parallel()
+	tt := []struct {
+		typeName string
+		base     string
+		width    int
+	}{
+		{typeName: "float", base: "float", width: 0},
+		{typeName: "float4", base: "float", width: 4},
+		{typeName: "uchar16", base: "uchar", width: 16},
+		{typeName: "image2d_t", base: "image2d_t", width: 0},
+	}
+	for _, tc := range tt {
+		base, width := cltypes.SplitVector(tc.typeName)
+		if (base != tc.base) || (width != tc.width) {
+			t.Errorf("%s: unexpected split: %q, %d", tc.typeName, base, width)
+		}
+	}
+}
+
+func TestScalarKind(t *testing.T) {
+	t.Parallel()
+	if kind, known := cltypes.ScalarKind("ushort"); !known || (kind != reflect.Uint16) || (kind.String() != "uint16") {
+		t.Errorf("unexpected kind for ushort: %v, %v", kind, known)
+	}
+	if kind, known := cltypes.ScalarKind("size_t"); !known || (kind != reflect.Uintptr) {
+		t.Errorf("unexpected kind for size_t: %v, %v", kind, known)
+	}
+	if _, known := cltypes.ScalarKind("void"); known {
+		t.Errorf("void is not a scalar type")
+	}
+}
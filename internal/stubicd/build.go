@@ -0,0 +1,49 @@
+// Package stubicd builds the stub OpenCL implementation from testdata/stubicd, which the tests of this module run
+// against.
+package stubicd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ErrNotSupported is returned if the stub cannot be built on the current system.
+var ErrNotSupported = errors.New("stub library not supported")
+
+// Build compiles the stub with the C compiler configured for cgo, and returns the path of the shared library
+// within the given directory.
+func Build(dir string) (string, error) {
+	if runtime.GOOS == "windows" {
+		return "", fmt.Errorf("%w on %s", ErrNotSupported, runtime.GOOS)
+	}
+	compiler := strings.Fields(goEnv("CC"))
+	if len(compiler) == 0 {
+		return "", fmt.Errorf("%w without a C compiler", ErrNotSupported)
+	}
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		return "", fmt.Errorf("%w without source location", ErrNotSupported)
+	}
+	source := filepath.Join(filepath.Dir(thisFile), "..", "..", "testdata", "stubicd", "stubicd.c")
+	path := filepath.Join(dir, "libOpenCLStub.so")
+	args := append([]string{}, compiler[1:]...)
+	args = append(args, strings.Fields(goEnv("CGO_CFLAGS"))...)
+	args = append(args, "-shared", "-fPIC", "-o", path, source, "-lpthread")
+	output, err := exec.Command(compiler[0], args...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to compile stub library: %w: %s", err, output)
+	}
+	return path, nil
+}
+
+func goEnv(name string) string {
+	output, err := exec.Command("go", "env", name).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -34,9 +34,11 @@ func CreateKernel(program Program, name string) (Kernel, error) {
 	var status C.cl_int
 	kernel := C.clCreateKernel(program.handle(), rawName, &status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateKernel", StatusError(status), program, name)
 	}
-	return Kernel(*((*uintptr)(unsafe.Pointer(&kernel)))), nil
+	created := Kernel(*((*uintptr)(unsafe.Pointer(&kernel))))
+	trackCreated("clCreateKernel", created)
+	return created, nil
 }
 
 // CreateKernelsInProgram creates kernel objects for all kernel functions in a program object.
@@ -61,7 +63,7 @@ func CreateKernelsInProgram(program Program) ([]Kernel, error) {
 	var requiredCount C.cl_uint
 	status := C.clCreateKernelsInProgram(program.handle(), 0, nil, &requiredCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clCreateKernelsInProgram", StatusError(status), program)
 	}
 	if requiredCount == 0 {
 		return nil, nil
@@ -74,9 +76,13 @@ func CreateKernelsInProgram(program Program) ([]Kernel, error) {
 		(*C.cl_kernel)(unsafe.Pointer(&kernels[0])),
 		&returnedCount)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clCreateKernelsInProgram", StatusError(status), program)
 	}
-	return kernels[:int(returnedCount)], nil
+	kernels = kernels[:int(returnedCount)]
+	for _, kernel := range kernels {
+		trackCreated("clCreateKernelsInProgram", kernel)
+	}
+	return kernels, nil
 }
 
 // RetainKernel increments the kernel reference count.
@@ -85,8 +91,9 @@ func CreateKernelsInProgram(program Program) ([]Kernel, error) {
 func RetainKernel(kernel Kernel) error {
 	status := C.clRetainKernel(kernel.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainKernel", StatusError(status), kernel)
 	}
+	trackRetained("clRetainKernel", kernel)
 	return nil
 }
 
@@ -99,8 +106,9 @@ func RetainKernel(kernel Kernel) error {
 func ReleaseKernel(kernel Kernel) error {
 	status := C.clReleaseKernel(kernel.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseKernel", StatusError(status), kernel)
 	}
+	trackReleased("clReleaseKernel", kernel)
 	return nil
 }
 
@@ -114,7 +122,7 @@ func SetKernelArg(kernel Kernel, index uint32, size uintptr, value unsafe.Pointe
 		C.size_t(size),
 		value)
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clSetKernelArg", StatusError(status), kernel, index)
 	}
 	return nil
 }
@@ -174,20 +182,29 @@ func KernelInfo(kernel Kernel, paramName KernelInfoName, paramSize uintptr, para
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetKernelInfo", StatusError(status), kernel)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// KernelInfoQuery returns the query of KernelInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func KernelInfoQuery(kernel Kernel, paramName KernelInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetKernelInfo",
+		handles:  []any{kernel},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return KernelInfo(kernel, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // KernelInfoString is a convenience method for KernelInfo() to query information values that are
 // string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func KernelInfoString(kernel Kernel, paramName KernelInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return KernelInfo(kernel, paramName, paramSize, paramValue)
-	})
+	return queryString(KernelInfoQuery(kernel, paramName))
 }
 
 // KernelWorkGroupInfoName identifies properties of a kernel work group, which can be queried with KernelWorkGroupInfo().
@@ -268,11 +285,22 @@ func KernelWorkGroupInfo(kernel Kernel, device DeviceID, paramName KernelWorkGro
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetKernelWorkGroupInfo", StatusError(status), kernel, device)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// KernelWorkGroupInfoQuery returns the query of KernelWorkGroupInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func KernelWorkGroupInfoQuery(kernel Kernel, device DeviceID, paramName KernelWorkGroupInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetKernelWorkGroupInfo",
+		handles:  []any{kernel, device},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return KernelWorkGroupInfo(kernel, device, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // KernelArgInfoName identifies properties of a kernel argument, which can be queried with KernelArgInfo().
 type KernelArgInfoName C.cl_kernel_arg_info
 
@@ -362,20 +390,29 @@ func KernelArgInfo(kernel Kernel, index uint32, paramName KernelArgInfoName, par
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetKernelArgInfo", StatusError(status), kernel, index)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// KernelArgInfoQuery returns the query of KernelArgInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func KernelArgInfoQuery(kernel Kernel, index uint32, paramName KernelArgInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetKernelArgInfo",
+		handles:  []any{kernel},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return KernelArgInfo(kernel, index, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // KernelArgInfoString is a convenience method for KernelArgInfo() to query information values that are
 // string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func KernelArgInfoString(kernel Kernel, index uint32, paramName KernelArgInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return KernelArgInfo(kernel, index, paramName, paramSize, paramValue)
-	})
+	return queryString(KernelArgInfoQuery(kernel, index, paramName))
 }
 
 // WorkDimension describes the parameters within one dimension of a work group.
@@ -412,8 +449,9 @@ func EnqueueNDRangeKernel(commandQueue CommandQueue, kernel Kernel, workDimensio
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueNDRangeKernel", StatusError(status), commandQueue, kernel)
 	}
+	trackEnqueuedEvent("clEnqueueNDRangeKernel", event)
 	return nil
 }
 
@@ -435,8 +473,9 @@ func EnqueueTask(commandQueue CommandQueue, kernel Kernel, waitList []Event, eve
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueTask", StatusError(status), commandQueue, kernel)
 	}
+	trackEnqueuedEvent("clEnqueueTask", event)
 	return nil
 }
 
@@ -457,8 +496,9 @@ func EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Point
 		callback(memPtr)
 	})
 	if err != nil {
-		return err
+		return newOpError("clEnqueueNativeKernel", err, commandQueue)
 	}
+	trackEnqueuedEvent("clEnqueueNativeKernel", event)
 	var rawWaitList unsafe.Pointer
 	if len(waitList) > 0 {
 		rawWaitList = unsafe.Pointer(&waitList[0])
@@ -490,7 +530,7 @@ func EnqueueNativeKernel(commandQueue CommandQueue, callback func([]unsafe.Point
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return newOpError("clEnqueueNativeKernel", StatusError(status), commandQueue)
 	}
 	return nil
 }
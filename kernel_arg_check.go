@@ -0,0 +1,251 @@
+package cl12
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/opencl-go/cl12/internal/cltypes"
+)
+
+// typedMemObject is implemented by memory objects that know the type of their elements, such as Buffer.
+type typedMemObject interface {
+	memObjectProvider
+	elementType() reflect.Type
+}
+
+// kernelArgDeclaration holds the declaration of a kernel argument, as reported by KernelArgInfo().
+type kernelArgDeclaration struct {
+	index         uint32
+	name          string
+	typeName      string
+	address       KernelArgAddressQualifier
+	access        KernelArgAccessQualifier
+	typeQualifier KernelArgTypeQualifier
+}
+
+// SetKernelArgChecked sets the argument value for a specific argument of a kernel, after it checked the value
+// against the declaration of the argument.
+//
+// The value can be of any type that BindKernelArgs() supports. The declaration is queried with KernelArgInfo(),
+// which requires the program to be built with the option "-cl-kernel-arg-info". If the information is not available,
+// the function returns an error that wraps ErrKernelArgInfoNotAvailable.
+//
+// The following rules apply, with violations reported by an error that wraps ErrKernelArgTypeMismatch:
+// __local pointers require a LocalMem value;
+// __global and __constant pointers require a memory object. The element type of a Buffer must match the type pointed
+// to, such as [4]float32 for float4, or [4]uint8 for uchar3. void pointers and pointers to other types accept any buffer;
+// image arguments require a memory object;
+// sampler_t requires a Sampler;
+// all other arguments require the numerical Go type of the same OpenCL C type, such as int32 for int,
+// [4]float32 for float4, or uintptr for size_t.
+//
+// Memory objects created with MemWriteOnlyFlag are rejected for arguments the kernel only reads from, which are
+// __constant or const pointers and __read_only images. Memory objects created with MemReadOnlyFlag are rejected
+// for __write_only images.
+func SetKernelArgChecked(kernel Kernel, index uint32, value any) error {
+	argValue := reflect.ValueOf(value)
+	if !argValue.IsValid() || !isKernelArgType(argValue.Type()) {
+		return newOpError("clSetKernelArg", fmt.Errorf("%w: %T", ErrKernelArgUnsupported, value), kernel)
+	}
+	if err := checkKernelArg(kernel, index, argValue); err != nil {
+		return err
+	}
+	size, raw := kernelArgValue(argValue)
+	return SetKernelArg(kernel, index, size, raw)
+}
+
+func checkKernelArg(kernel Kernel, index uint32, value reflect.Value) error {
+	arg, err := kernelArgDeclarationOf(kernel, index)
+	if err != nil {
+		return err
+	}
+	mismatch := arg.mismatch(value)
+	if mismatch != "" {
+		return newOpError("clSetKernelArg",
+			fmt.Errorf("%w: %v can not be set from %v: %s", ErrKernelArgTypeMismatch, arg, value.Type(), mismatch),
+			kernel)
+	}
+	mem, isMem := kernelArgMemObject(value)
+	if !isMem || (mem == 0) {
+		return nil
+	}
+	flags, err := InfoValue[MemFlags](MemObjectInfoQuery(mem, MemFlagsInfo))
+	if err != nil {
+		return err
+	}
+	if (flags&MemWriteOnlyFlag != 0) && arg.readOnly() {
+		return newOpError("clSetKernelArg",
+			fmt.Errorf("%w: %v can not be set from a write-only memory object", ErrKernelArgTypeMismatch, arg),
+			kernel, mem)
+	}
+	if (flags&MemReadOnlyFlag != 0) && (arg.access == KernelArgAccessWriteOnly) {
+		return newOpError("clSetKernelArg",
+			fmt.Errorf("%w: %v can not be set from a read-only memory object", ErrKernelArgTypeMismatch, arg),
+			kernel, mem)
+	}
+	return nil
+}
+
+func kernelArgDeclarationOf(kernel Kernel, index uint32) (arg kernelArgDeclaration, err error) {
+	arg.index = index
+	if arg.name, err = KernelArgInfoString(kernel, index, KernelArgNameInfo); err != nil {
+		return kernelArgDeclaration{}, err
+	}
+	if arg.typeName, err = KernelArgInfoString(kernel, index, KernelArgTypeNameInfo); err != nil {
+		return kernelArgDeclaration{}, err
+	}
+	if arg.address, err = InfoValue[KernelArgAddressQualifier](KernelArgInfoQuery(kernel, index, KernelArgAddressQualifierInfo)); err != nil {
+		return kernelArgDeclaration{}, err
+	}
+	if arg.access, err = InfoValue[KernelArgAccessQualifier](KernelArgInfoQuery(kernel, index, KernelArgAccessQualifierInfo)); err != nil {
+		return kernelArgDeclaration{}, err
+	}
+	if arg.typeQualifier, err = InfoValue[KernelArgTypeQualifier](KernelArgInfoQuery(kernel, index, KernelArgTypeQualifierInfo)); err != nil {
+		return kernelArgDeclaration{}, err
+	}
+	return arg, nil
+}
+
+// String returns the argument with its declaration in OpenCL C notation, such as
+// `argument 0 "values" (__global const float*)`.
+func (arg kernelArgDeclaration) String() string {
+	var qualifiers []string
+	switch {
+	case arg.isImage():
+		switch arg.access {
+		case KernelArgAccessReadOnly:
+			qualifiers = append(qualifiers, "__read_only")
+		case KernelArgAccessWriteOnly:
+			qualifiers = append(qualifiers, "__write_only")
+		case KernelArgAccessReadWrite:
+			qualifiers = append(qualifiers, "__read_write")
+		}
+	case arg.isPointer():
+		switch arg.address {
+		case KernelArgAddressGlobal:
+			qualifiers = append(qualifiers, "__global")
+		case KernelArgAddressLocal:
+			qualifiers = append(qualifiers, "__local")
+		case KernelArgAddressConstant:
+			qualifiers = append(qualifiers, "__constant")
+		}
+	}
+	if arg.typeQualifier&KernelArgTypeConst != 0 {
+		qualifiers = append(qualifiers, "const")
+	}
+	qualifiers = append(qualifiers, arg.typeName)
+	return fmt.Sprintf("argument %d %q (%s)", arg.index, arg.name, strings.Join(qualifiers, " "))
+}
+
+func (arg kernelArgDeclaration) isImage() bool {
+	return strings.HasPrefix(arg.typeName, "image")
+}
+
+func (arg kernelArgDeclaration) isPointer() bool {
+	return strings.HasSuffix(arg.typeName, "*")
+}
+
+// readOnly returns true if the kernel can only read from the argument.
+func (arg kernelArgDeclaration) readOnly() bool {
+	if arg.isImage() {
+		return arg.access == KernelArgAccessReadOnly
+	}
+	return arg.isPointer() && ((arg.address == KernelArgAddressConstant) || (arg.typeQualifier&KernelArgTypeConst != 0))
+}
+
+// mismatch returns a description why the argument can not be set from the value, or an empty string if it can.
+func (arg kernelArgDeclaration) mismatch(value reflect.Value) string {
+	valueType := value.Type()
+	isMem := (valueType == kernelArgMemObjectType) || valueType.Implements(kernelArgProviderType)
+	switch {
+	case arg.isPointer() && (arg.address == KernelArgAddressLocal):
+		if valueType != kernelArgLocalMemType {
+			return "requires LocalMem"
+		}
+	case arg.isImage():
+		if !isMem {
+			return "requires a memory object"
+		}
+	case arg.isPointer():
+		if !isMem {
+			return "requires a memory object"
+		}
+		if typed, isTyped := kernelArgTypedMemObject(value); isTyped {
+			if !kernelArgPointeeMatches(strings.TrimSuffix(arg.typeName, "*"), typed.elementType()) {
+				return fmt.Sprintf("elements are of type %v", typed.elementType())
+			}
+		}
+	case arg.typeName == "sampler_t":
+		if valueType != kernelArgSamplerType {
+			return "requires a Sampler"
+		}
+	default:
+		if isMem || (valueType == kernelArgLocalMemType) || (valueType == kernelArgSamplerType) ||
+			!kernelArgValueMatches(arg.typeName, valueType) {
+			return fmt.Sprintf("requires a numerical type that corresponds to %s", arg.typeName)
+		}
+	}
+	return ""
+}
+
+// kernelArgMemObject returns the memory object of a value, if it is one or it provides one.
+// A nil pointer that would provide one results in a zero memory object.
+func kernelArgMemObject(value reflect.Value) (MemObject, bool) {
+	switch {
+	case value.Type() == kernelArgMemObjectType:
+		return MemObject(value.Uint()), true
+	case value.Type().Implements(kernelArgProviderType):
+		if (value.Kind() == reflect.Ptr) && value.IsNil() {
+			return 0, true
+		}
+		return value.Interface().(memObjectProvider).MemObject(), true
+	default:
+		return 0, false
+	}
+}
+
+// kernelArgTypedMemObject returns the value as typedMemObject, if it implements it and is not a nil pointer.
+func kernelArgTypedMemObject(value reflect.Value) (typedMemObject, bool) {
+	if !value.Type().Implements(kernelArgTypedType) || ((value.Kind() == reflect.Ptr) && value.IsNil()) {
+		return nil, false
+	}
+	return value.Interface().(typedMemObject), true
+}
+
+// kernelArgValueMatches returns true if the Go type corresponds to the scalar or vector type of OpenCL C.
+func kernelArgValueMatches(typeName string, valueType reflect.Type) bool {
+	base, width := cltypes.SplitVector(typeName)
+	if valueType.Kind() == reflect.Array {
+		return (width == valueType.Len()) && isKernelArgScalarName(base, valueType.Elem())
+	}
+	return (width == 0) && isKernelArgScalarName(base, valueType)
+}
+
+// kernelArgPointeeMatches returns true if a buffer with the given element type can be used for a pointer to the
+// named type. Pointers to void and other non-numerical types accept any element type. Pointers to vectors require
+// arrays of the same length, with 3-component vectors requiring arrays of length 4, as they occupy the storage of
+// four components.
+func kernelArgPointeeMatches(pointee string, elementType reflect.Type) bool {
+	base, width := cltypes.SplitVector(pointee)
+	if _, isScalar := cltypes.ScalarKind(base); !isScalar {
+		return true
+	}
+	if width == 3 {
+		width = 4
+	}
+	length := 0
+	if elementType.Kind() == reflect.Array {
+		length = elementType.Len()
+		elementType = elementType.Elem()
+	}
+	return (length == width) && isKernelArgScalarName(base, elementType)
+}
+
+func isKernelArgScalarName(name string, scalarType reflect.Type) bool {
+	if (scalarType.Kind() == reflect.Uintptr) && (scalarType != kernelArgUintptrType) {
+		return false
+	}
+	kind, isScalar := cltypes.ScalarKind(name)
+	return isScalar && (kind == scalarType.Kind())
+}
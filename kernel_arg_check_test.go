@@ -0,0 +1,184 @@
+package cl12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+const kernelArgCheckSource = `__kernel void blend(__global float *values, __constant int *table, const __global float *input,
+	uint count, float2 range, __local int *scratch, __global void *raw) {}`
+
+func TestSetKernelArgChecked(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	kernel := stubKernel(t, context, kernelArgCheckSource, "-cl-kernel-arg-info", "blend")
+	values, err := cl.CreateBufferOf[float32](context, cl.MemReadWriteFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = values.Release() }()
+	table, err := cl.CreateBufferOf[int32](context, cl.MemReadOnlyFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = table.Release() }()
+	args := []any{values, table, values.MemObject(), uint32(16), [2]float32{0, 1}, cl.LocalMem(64), table}
+	for index, arg := range args {
+		if err = cl.SetKernelArgChecked(kernel, uint32(index), arg); err != nil {
+			t.Fatalf("SetKernelArgChecked() failed for argument %d: %v", index, err)
+		}
+	}
+	err = cl.EnqueueNDRangeKernel(queue, kernel, []cl.WorkDimension{{GlobalSize: 16}}, nil, nil)
+	if err != nil {
+		t.Errorf("EnqueueNDRangeKernel() failed: %v", err)
+	}
+}
+
+func TestSetKernelArgCheckedVectorWidth(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	kernel := stubKernel(t, context, `__kernel void shade(__global float4 *pixels, __constant uchar3 *palette,
+	__global float *values) {}`, "-cl-kernel-arg-info", "shade")
+	pixels, err := cl.CreateBufferOf[[4]float32](context, cl.MemReadWriteFlag, 4)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = pixels.Release() }()
+	pairs, err := cl.CreateBufferOf[[2]float32](context, cl.MemReadWriteFlag, 4)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = pairs.Release() }()
+	palette, err := cl.CreateBufferOf[[4]uint8](context, cl.MemReadOnlyFlag, 4)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = palette.Release() }()
+	for index, arg := range []any{pixels, palette} {
+		if err = cl.SetKernelArgChecked(kernel, uint32(index), arg); err != nil {
+			t.Errorf("SetKernelArgChecked() failed for argument %d: %v", index, err)
+		}
+	}
+	tt := []struct {
+		name    string
+		index   uint32
+		value   any
+		mention string
+	}{
+		{name: "narrower vector", index: 0, value: pairs, mention: "__global float4*"},
+		{name: "vector for scalar", index: 2, value: pixels, mention: "__global float*"},
+	}
+	for _, tc := range tt {
+		err := cl.SetKernelArgChecked(kernel, tc.index, tc.value)
+		if !errors.Is(err, cl.ErrKernelArgTypeMismatch) {
+			t.Errorf("%s: expected ErrKernelArgTypeMismatch, got: %v", tc.name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.mention) {
+			t.Errorf("%s: error does not mention %s: %v", tc.name, tc.mention, err)
+		}
+	}
+}
+
+// pointerBuffer provides its memory object with a pointer receiver.
+type pointerBuffer struct {
+	mem cl.MemObject
+}
+
+func (buf *pointerBuffer) MemObject() cl.MemObject {
+	return buf.mem
+}
+
+func TestSetKernelArgCheckedPointerReceiver(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	kernel := stubKernel(t, context, kernelArgCheckSource, "-cl-kernel-arg-info", "blend")
+	values, err := cl.CreateBufferOf[float32](context, cl.MemReadWriteFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = values.Release() }()
+	if err = cl.SetKernelArgChecked(kernel, 0, &pointerBuffer{mem: values.MemObject()}); err != nil {
+		t.Errorf("SetKernelArgChecked() failed: %v", err)
+	}
+	args := struct {
+		Values  *pointerBuffer `cl:"values"`
+		Table   *pointerBuffer `cl:"table"`
+		Input   *pointerBuffer `cl:"input"`
+		Count   uint32         `cl:"count"`
+		Range   [2]float32     `cl:"range"`
+		Scratch cl.LocalMem    `cl:"scratch"`
+		Raw     *pointerBuffer `cl:"raw"`
+	}{Values: &pointerBuffer{mem: values.MemObject()}, Table: &pointerBuffer{}, Input: &pointerBuffer{}, Scratch: 64}
+	if err = cl.BindKernelArgs(kernel, &args); err != nil {
+		t.Errorf("BindKernelArgs() failed: %v", err)
+	}
+}
+
+func TestSetKernelArgCheckedMismatch(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	kernel := stubKernel(t, context, kernelArgCheckSource, "-cl-kernel-arg-info", "blend")
+	integers, err := cl.CreateBufferOf[int32](context, cl.MemReadWriteFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = integers.Release() }()
+	writeOnly, err := cl.CreateBufferOf[int32](context, cl.MemWriteOnlyFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = writeOnly.Release() }()
+	tt := []struct {
+		name    string
+		index   uint32
+		value   any
+		mention string
+	}{
+		{name: "element type", index: 0, value: integers, mention: "__global float*"},
+		{name: "scalar for pointer", index: 0, value: float32(1), mention: "requires a memory object"},
+		{name: "write-only constant", index: 1, value: writeOnly, mention: "__constant int*"},
+		{name: "write-only const", index: 2, value: writeOnly.MemObject(), mention: "write-only"},
+		{name: "same size", index: 3, value: float32(16), mention: `"count"`},
+		{name: "signedness", index: 3, value: int32(16), mention: "uint"},
+		{name: "vector width", index: 4, value: [4]float32{}, mention: "float2"},
+		{name: "memory for local", index: 5, value: integers, mention: "LocalMem"},
+		{name: "local for scalar", index: 3, value: cl.LocalMem(4), mention: "uint"},
+	}
+	for _, tc := range tt {
+		err := cl.SetKernelArgChecked(kernel, tc.index, tc.value)
+		if !errors.Is(err, cl.ErrKernelArgTypeMismatch) {
+			t.Errorf("%s: expected ErrKernelArgTypeMismatch, got: %v", tc.name, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.mention) {
+			t.Errorf("%s: error does not mention %s: %v", tc.name, tc.mention, err)
+		}
+	}
+	mismatched := struct {
+		Values  cl.Buffer[int32] `cl:"values"`
+		Table   cl.Buffer[int32] `cl:"table"`
+		Input   cl.MemObject     `cl:"input"`
+		Count   uint32           `cl:"count"`
+		Range   [2]float32       `cl:"range"`
+		Scratch cl.LocalMem      `cl:"scratch"`
+		Raw     cl.MemObject     `cl:"raw"`
+	}{Values: integers, Table: integers, Scratch: 64}
+	if err = cl.BindKernelArgs(kernel, mismatched); !errors.Is(err, cl.ErrKernelArgTypeMismatch) {
+		t.Errorf("BindKernelArgs() expected ErrKernelArgTypeMismatch, got: %v", err)
+	}
+}
+
+func TestSetKernelArgCheckedWithoutArgInfo(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	kernel := stubKernel(t, context, kernelArgCheckSource, "", "blend")
+	err := cl.SetKernelArgChecked(kernel, 3, uint32(16))
+	if !errors.Is(err, cl.ErrKernelArgInfoNotAvailable) {
+		t.Errorf("expected ErrKernelArgInfoNotAvailable, got: %v", err)
+	}
+}
@@ -0,0 +1,250 @@
+package cl12
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// KernelArgTag is the struct tag that BindKernelArgs() evaluates.
+const KernelArgTag = "cl"
+
+// LocalMem describes an argument in the __local address space by its size in bytes.
+// The memory is allocated by the device for each work group, which is why it has no value.
+type LocalMem uintptr
+
+// memObjectProvider is implemented by types that wrap a memory object, such as Buffer and managed.MemObject.
+type memObjectProvider interface {
+	MemObject() MemObject
+}
+
+// kernelArgBinding is a field of the struct provided to BindKernelArgs().
+type kernelArgBinding struct {
+	field string
+	name  string
+	index int
+	value reflect.Value
+}
+
+// BindKernelArgs sets the arguments of a kernel from the fields of a struct, or a pointer to a struct.
+//
+// Only fields with the tag `cl` are considered, including those of embedded structs. The tag refers to the argument
+// either by its index, such as `cl:"0"`, or by its name, such as `cl:"values"`. Names are resolved with
+// KernelArgInfo() and KernelArgNameInfo, which requires the program to be built with the option "-cl-kernel-arg-info".
+// If the information is not available, the function falls back to the index of a tag that provides both, such as
+// `cl:"values,0"`.
+//
+// The following field types are supported:
+// MemObject, Buffer, and other types with a method MemObject() MemObject, such as the memory objects of package
+// managed;
+// Sampler; LocalMem for __local arguments;
+// the fixed-size integer and floating point types, as well as uintptr for size_t;
+// arrays of these numerical types with a length of 2, 3, 4, 8, or 16 for vector types. Arrays with a length of 3
+// are padded to the size of 4 elements, as specified for the vector types with three components.
+//
+// If the names are available, each value is checked against the declaration of its argument, see
+// SetKernelArgChecked(). All arguments are resolved and checked before the first one is set.
+//
+// The function returns an error that wraps ErrKernelArgMissing if an argument of the kernel is not bound by any
+// field, ErrKernelArgSurplus if a field refers to an argument that the kernel does not have or that another field
+// binds already, ErrKernelArgUnsupported for fields of other types, and ErrKernelArgTypeMismatch if a check fails.
+// The error names the field or argument.
+func BindKernelArgs(kernel Kernel, args any) error {
+	bindings, err := kernelArgBindings(kernel, args)
+	if err != nil {
+		return err
+	}
+	count, err := InfoValue[uint32](KernelInfoQuery(kernel, KernelNumArgsInfo))
+	if err != nil {
+		return err
+	}
+	names, err := kernelArgNames(kernel, count)
+	if err != nil {
+		return err
+	}
+	bound := make([]*kernelArgBinding, count)
+	for i := range bindings {
+		binding := &bindings[i]
+		if err = binding.resolve(names); err != nil {
+			return newOpError("clSetKernelArg", err, kernel)
+		}
+		if binding.index >= int(count) {
+			return newOpError("clSetKernelArg",
+				fmt.Errorf("%w: field %s refers to argument %d, the kernel has %d", ErrKernelArgSurplus, binding.field, binding.index, count),
+				kernel)
+		}
+		if other := bound[binding.index]; other != nil {
+			return newOpError("clSetKernelArg",
+				fmt.Errorf("%w: fields %s and %s both bind %s", ErrKernelArgSurplus, other.field, binding.field,
+					kernelArgDescription(names, binding.index)),
+				kernel)
+		}
+		bound[binding.index] = binding
+	}
+	for index, binding := range bound {
+		if binding == nil {
+			return newOpError("clSetKernelArg",
+				fmt.Errorf("%w: %s", ErrKernelArgMissing, kernelArgDescription(names, index)),
+				kernel)
+		}
+	}
+	if names != nil {
+		for _, binding := range bound {
+			if err = checkKernelArg(kernel, uint32(binding.index), binding.value); err != nil {
+				return fmt.Errorf("field %s: %w", binding.field, err)
+			}
+		}
+	}
+	for _, binding := range bound {
+		size, value := kernelArgValue(binding.value)
+		if err = SetKernelArg(kernel, uint32(binding.index), size, value); err != nil {
+			return fmt.Errorf("field %s: %w", binding.field, err)
+		}
+	}
+	return nil
+}
+
+func kernelArgBindings(kernel Kernel, args any) ([]kernelArgBinding, error) {
+	structValue := reflect.ValueOf(args)
+	if (structValue.Kind() == reflect.Pointer) && !structValue.IsNil() {
+		structValue = structValue.Elem()
+	}
+	if structValue.Kind() != reflect.Struct {
+		return nil, newOpError("clSetKernelArg", fmt.Errorf("%w: %T is not a struct", ErrKernelArgUnsupported, args), kernel)
+	}
+	var bindings []kernelArgBinding
+	for _, field := range reflect.VisibleFields(structValue.Type()) {
+		tag, tagged := field.Tag.Lookup(KernelArgTag)
+		if !tagged {
+			continue
+		}
+		value, err := structValue.FieldByIndexErr(field.Index)
+		if err != nil {
+			return nil, newOpError("clSetKernelArg",
+				fmt.Errorf("%w: field %s is embedded through a nil pointer", ErrKernelArgUnsupported, field.Name), kernel)
+		}
+		binding := kernelArgBinding{field: field.Name, index: -1, value: value}
+		for _, part := range strings.Split(tag, ",") {
+			if index, err := strconv.Atoi(part); err == nil && (index >= 0) {
+				binding.index = index
+			} else {
+				binding.name = part
+			}
+		}
+		if (binding.name == "") && (binding.index < 0) {
+			return nil, newOpError("clSetKernelArg",
+				fmt.Errorf("%w: field %s has an empty tag", ErrKernelArgUnsupported, field.Name), kernel)
+		}
+		if !field.IsExported() || !isKernelArgType(field.Type) {
+			return nil, newOpError("clSetKernelArg",
+				fmt.Errorf("%w: field %s of type %v", ErrKernelArgUnsupported, field.Name, field.Type), kernel)
+		}
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
+
+// kernelArgNames returns the names of all arguments, or nil if the kernel does not provide this information.
+func kernelArgNames(kernel Kernel, count uint32) ([]string, error) {
+	names := make([]string, count)
+	for i := uint32(0); i < count; i++ {
+		name, err := KernelArgInfoString(kernel, i, KernelArgNameInfo)
+		if errors.Is(err, ErrKernelArgInfoNotAvailable) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+func kernelArgDescription(names []string, index int) string {
+	if names == nil {
+		return fmt.Sprintf("argument %d", index)
+	}
+	return fmt.Sprintf("argument %d %q", index, names[index])
+}
+
+// resolve determines the index of the argument, preferring the name if the names are known.
+func (binding *kernelArgBinding) resolve(names []string) error {
+	if binding.name == "" {
+		return nil
+	}
+	if names == nil {
+		if binding.index < 0 {
+			return fmt.Errorf("%w: field %s refers to argument %q by name only", ErrKernelArgInfoNotAvailable, binding.field, binding.name)
+		}
+		return nil
+	}
+	for index, name := range names {
+		if name == binding.name {
+			binding.index = index
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: field %s refers to argument %q, which the kernel does not have", ErrKernelArgSurplus, binding.field, binding.name)
+}
+
+var (
+	kernelArgUintptrType   = reflect.TypeOf(uintptr(0))
+	kernelArgLocalMemType  = reflect.TypeOf(LocalMem(0))
+	kernelArgSamplerType   = reflect.TypeOf(Sampler(0))
+	kernelArgMemObjectType = reflect.TypeOf(MemObject(0))
+	kernelArgProviderType  = reflect.TypeOf((*memObjectProvider)(nil)).Elem()
+	kernelArgTypedType     = reflect.TypeOf((*typedMemObject)(nil)).Elem()
+)
+
+func isKernelArgType(argType reflect.Type) bool {
+	switch {
+	case (argType == kernelArgLocalMemType) || (argType == kernelArgSamplerType) || (argType == kernelArgMemObjectType):
+		return true
+	case argType.Implements(kernelArgProviderType):
+		return true
+	case argType.Kind() == reflect.Array:
+		switch argType.Len() {
+		case 2, 3, 4, 8, 16:
+			return isKernelArgScalarType(argType.Elem())
+		default:
+			return false
+		}
+	default:
+		return isKernelArgScalarType(argType)
+	}
+}
+
+func isKernelArgScalarType(argType reflect.Type) bool {
+	switch argType.Kind() {
+	case reflect.Int8, reflect.Uint8, reflect.Int16, reflect.Uint16, reflect.Int32, reflect.Uint32,
+		reflect.Int64, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Uintptr:
+		return argType == kernelArgUintptrType
+	default:
+		return false
+	}
+}
+
+// kernelArgValue returns the size and the pointer to a copy of the value, as expected by SetKernelArg().
+// The value must be of a type for which isKernelArgType() returns true.
+func kernelArgValue(value reflect.Value) (uintptr, unsafe.Pointer) {
+	switch {
+	case value.Type() == kernelArgLocalMemType:
+		return uintptr(value.Uint()), nil
+	case value.Type().Implements(kernelArgProviderType):
+		mem, _ := kernelArgMemObject(value)
+		return unsafe.Sizeof(mem), unsafe.Pointer(&mem)
+	case (value.Kind() == reflect.Array) && (value.Len() == 3):
+		padded := reflect.New(reflect.ArrayOf(4, value.Type().Elem()))
+		reflect.Copy(padded.Elem(), value)
+		return padded.Elem().Type().Size(), padded.UnsafePointer()
+	default:
+		copied := reflect.New(value.Type())
+		copied.Elem().Set(value)
+		return value.Type().Size(), copied.UnsafePointer()
+	}
+}
@@ -0,0 +1,148 @@
+package cl12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+const kernelArgsSource = `__kernel void scale(__global float *values, const float factor, float3 offset,
+	__local float *scratch, const ulong count) {}`
+
+func TestBindKernelArgsByName(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	kernel := stubKernel(t, context, kernelArgsSource, "-cl-kernel-arg-info", "scale")
+	values, err := cl.CreateBufferOf[float32](context, cl.MemReadWriteFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = values.Release() }()
+	args := struct {
+		Count   uint64             `cl:"count"`
+		Values  cl.Buffer[float32] `cl:"values"`
+		Factor  float32            `cl:"factor"`
+		Offset  [3]float32         `cl:"offset"`
+		Scratch cl.LocalMem        `cl:"scratch"`
+		Ignored string
+	}{Count: 16, Values: values, Factor: 2, Offset: [3]float32{1, 2, 3}, Scratch: 256}
+	if err = cl.BindKernelArgs(kernel, &args); err != nil {
+		t.Fatalf("BindKernelArgs() failed: %v", err)
+	}
+	err = cl.EnqueueNDRangeKernel(queue, kernel, []cl.WorkDimension{{GlobalSize: 16}}, nil, nil)
+	if err != nil {
+		t.Errorf("EnqueueNDRangeKernel() failed: %v", err)
+	}
+}
+
+func TestBindKernelArgsByIndex(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	kernel := stubKernel(t, context, kernelArgsSource, "", "scale")
+	values, err := cl.CreateBufferOf[float32](context, cl.MemReadWriteFlag, 16)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = values.Release() }()
+	type scaleArgs struct {
+		Values  cl.MemObject `cl:"values,0"`
+		Factor  float32      `cl:"1"`
+		Offset  [3]float32   `cl:"2"`
+		Scratch cl.LocalMem  `cl:"3,scratch"`
+		Count   uint64       `cl:"4"`
+	}
+	if err = cl.BindKernelArgs(kernel, scaleArgs{Values: values.MemObject(), Factor: 2, Scratch: 64, Count: 16}); err != nil {
+		t.Fatalf("BindKernelArgs() failed: %v", err)
+	}
+	err = cl.EnqueueNDRangeKernel(queue, kernel, []cl.WorkDimension{{GlobalSize: 16}}, nil, nil)
+	if err != nil {
+		t.Errorf("EnqueueNDRangeKernel() failed: %v", err)
+	}
+	nameOnly := struct {
+		Values cl.MemObject `cl:"values"`
+	}{Values: values.MemObject()}
+	if err = cl.BindKernelArgs(kernel, nameOnly); !errors.Is(err, cl.ErrKernelArgInfoNotAvailable) {
+		t.Errorf("expected ErrKernelArgInfoNotAvailable, got: %v", err)
+	}
+}
+
+func TestBindKernelArgsErrors(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	kernel := stubKernel(t, context, kernelArgsSource, "-cl-kernel-arg-info", "scale")
+	type complete struct {
+		Values  cl.MemObject `cl:"values"`
+		Factor  float32      `cl:"factor"`
+		Offset  [3]float32   `cl:"offset"`
+		Scratch cl.LocalMem  `cl:"scratch"`
+		Count   uint64       `cl:"count"`
+	}
+	tt := []struct {
+		name     string
+		args     any
+		expected error
+		mention  string
+	}{
+		{
+			name: "missing argument",
+			args: struct {
+				Values cl.MemObject `cl:"values"`
+				Factor float32      `cl:"factor"`
+				Offset [3]float32   `cl:"offset"`
+				Count  uint64       `cl:"count"`
+			}{},
+			expected: cl.ErrKernelArgMissing,
+			mention:  `"scratch"`,
+		},
+		{
+			name: "unknown name",
+			args: struct {
+				complete
+				Bias float32 `cl:"bias"`
+			}{},
+			expected: cl.ErrKernelArgSurplus,
+			mention:  "Bias",
+		},
+		{
+			name: "index out of range",
+			args: struct {
+				complete
+				Extra float32 `cl:"5"`
+			}{},
+			expected: cl.ErrKernelArgSurplus,
+			mention:  "Extra",
+		},
+		{
+			name: "bound twice",
+			args: struct {
+				complete
+				Again float32 `cl:"1"`
+			}{},
+			expected: cl.ErrKernelArgSurplus,
+			mention:  "Again",
+		},
+		{
+			name: "unsupported type",
+			args: struct {
+				Factor int `cl:"factor"`
+			}{},
+			expected: cl.ErrKernelArgUnsupported,
+			mention:  "Factor",
+		},
+		{name: "not a struct", args: 42, expected: cl.ErrKernelArgUnsupported, mention: "int"},
+	}
+	for _, tc := range tt {
+		err := cl.BindKernelArgs(kernel, tc.args)
+		if !errors.Is(err, tc.expected) {
+			t.Errorf("%s: expected %v, got: %v", tc.name, tc.expected, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tc.mention) {
+			t.Errorf("%s: error does not mention %s: %v", tc.name, tc.mention, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cl12_test
+
+import (
+	"errors"
+	"testing"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestEnqueueNativeKernel(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	data := []uint32{1, 2, 3, 4}
+	mem, err := cl.CreateBuffer(context, cl.MemCopyHostPtrFlag, len(data)*4, unsafe.Pointer(&data[0]))
+	if err != nil {
+		t.Fatalf("CreateBuffer() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseMemObject(mem) }()
+	var sum uint32
+	err = cl.EnqueueNativeKernel(queue, func(args []unsafe.Pointer) {
+		for _, value := range unsafe.Slice((*uint32)(args[0]), len(data)) {
+			sum += value
+		}
+	}, []cl.MemObject{mem}, nil, nil)
+	if err != nil {
+		t.Fatalf("EnqueueNativeKernel() failed: %v", err)
+	}
+	err = cl.Finish(queue)
+	if err != nil {
+		t.Fatalf("Finish() failed: %v", err)
+	}
+	if sum != 10 {
+		t.Errorf("unexpected sum: %d", sum)
+	}
+}
+
+func TestKernelArgInfoString(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{
+		`__kernel void scale(__global float *values, const float factor) {}`,
+	})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgram(program, []cl.DeviceID{device}, "-cl-kernel-arg-info", nil)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, "scale")
+	if err != nil {
+		t.Fatalf("CreateKernel() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseKernel(kernel) }()
+	typeName, err := cl.KernelArgInfoString(kernel, 0, cl.KernelArgTypeNameInfo)
+	if err != nil {
+		t.Fatalf("KernelArgInfoString() failed: %v", err)
+	}
+	if typeName != "float*" {
+		t.Errorf("unexpected type name: %q", typeName)
+	}
+	argName, err := cl.KernelArgInfoString(kernel, 1, cl.KernelArgNameInfo)
+	if err != nil {
+		t.Fatalf("KernelArgInfoString() failed: %v", err)
+	}
+	if argName != "factor" {
+		t.Errorf("unexpected argument name: %q", argName)
+	}
+}
+
+func TestEnqueueNDRangeKernelWithoutArgs(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	program, err := cl.CreateProgramWithSource(context, []string{`__kernel void fill(__global int *values) {}`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgram(program, nil, "", nil)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, "fill")
+	if err != nil {
+		t.Fatalf("CreateKernel() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseKernel(kernel) }()
+	err = cl.EnqueueNDRangeKernel(queue, kernel, []cl.WorkDimension{{GlobalSize: 16}}, nil, nil)
+	if !errors.Is(err, cl.ErrInvalidKernelArgs) {
+		t.Errorf("expected ErrInvalidKernelArgs, got: %v", err)
+	}
+}
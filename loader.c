@@ -0,0 +1,134 @@
+#include "api.h"
+
+#include <stdio.h>
+
+#ifdef _WIN32
+#include <windows.h>
+#else
+#include <dlfcn.h>
+#endif
+
+extern void cl12GoLoadDefaultLibrary(void);
+
+#define CL12_FIELD_STATUS(name, params, args) cl_int (CL_API_CALL *name) params;
+#define CL12_FIELD_OBJECT(type, name, params, args) type (CL_API_CALL *name) params;
+#define CL12_FIELD_POINTER(type, name, params, args) type (CL_API_CALL *name) params;
+
+typedef struct
+{
+    CL12_FUNCTIONS(CL12_FIELD_STATUS, CL12_FIELD_OBJECT, CL12_FIELD_POINTER)
+} cl12FunctionTable;
+
+static cl12FunctionTable cl12Functions;
+static int cl12Loaded;
+
+static void *cl12OpenLibrary(char const *path, char *errorText, size_t errorTextSize)
+{
+#ifdef _WIN32
+    HMODULE library = LoadLibraryA(path);
+    if (library == NULL)
+    {
+        snprintf(errorText, errorTextSize, "%s: error code %lu", path, (unsigned long)(GetLastError()));
+    }
+    return (void *)(library);
+#else
+    void *library = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+    if (library == NULL)
+    {
+        char const *reason = dlerror();
+        snprintf(errorText, errorTextSize, "%s", (reason != NULL) ? reason : path);
+    }
+    return library;
+#endif
+}
+
+static void cl12CloseLibrary(void *library)
+{
+#ifdef _WIN32
+    FreeLibrary((HMODULE)(library));
+#else
+    dlclose(library);
+#endif
+}
+
+static void *cl12LibrarySymbol(void *library, char const *name)
+{
+#ifdef _WIN32
+    return (void *)(GetProcAddress((HMODULE)(library), name));
+#else
+    return dlsym(library, name);
+#endif
+}
+
+int cl12LoadLibrary(char const *path, char *errorText, size_t errorTextSize)
+{
+    cl12FunctionTable functions;
+    void *library;
+
+    if (__atomic_load_n(&cl12Loaded, __ATOMIC_ACQUIRE))
+    {
+        return 1;
+    }
+    library = cl12OpenLibrary(path, errorText, errorTextSize);
+    if (library == NULL)
+    {
+        return 0;
+    }
+#define CL12_RESOLVE_STATUS(name, params, args) *(void **)(&functions.name) = cl12LibrarySymbol(library, "cl" #name);
+#define CL12_RESOLVE_OBJECT(type, name, params, args) *(void **)(&functions.name) = cl12LibrarySymbol(library, "cl" #name);
+#define CL12_RESOLVE_POINTER(type, name, params, args) *(void **)(&functions.name) = cl12LibrarySymbol(library, "cl" #name);
+    CL12_FUNCTIONS(CL12_RESOLVE_STATUS, CL12_RESOLVE_OBJECT, CL12_RESOLVE_POINTER)
+    if (functions.GetPlatformIDs == NULL)
+    {
+        snprintf(errorText, errorTextSize, "%s: clGetPlatformIDs not found", path);
+        cl12CloseLibrary(library);
+        return 0;
+    }
+    cl12Functions = functions;
+    __atomic_store_n(&cl12Loaded, 1, __ATOMIC_RELEASE);
+    return 1;
+}
+
+static int cl12EnsureLoaded(void)
+{
+    if (__atomic_load_n(&cl12Loaded, __ATOMIC_ACQUIRE))
+    {
+        return 1;
+    }
+    cl12GoLoadDefaultLibrary();
+    return __atomic_load_n(&cl12Loaded, __ATOMIC_ACQUIRE);
+}
+
+#define CL12_DEFINE_STATUS(name, params, args) \
+    cl_int cl12Dispatch##name params \
+    { \
+        if (!cl12EnsureLoaded() || (cl12Functions.name == NULL)) \
+        { \
+            return CL12_LIBRARY_NOT_AVAILABLE; \
+        } \
+        return cl12Functions.name args; \
+    }
+#define CL12_DEFINE_OBJECT(type, name, params, args) \
+    type cl12Dispatch##name params \
+    { \
+        if (!cl12EnsureLoaded() || (cl12Functions.name == NULL)) \
+        { \
+            if (errcodeRet != NULL) \
+            { \
+                *errcodeRet = CL12_LIBRARY_NOT_AVAILABLE; \
+            } \
+            return NULL; \
+        } \
+        return cl12Functions.name args; \
+    }
+#define CL12_DEFINE_POINTER(type, name, params, args) \
+    type cl12Dispatch##name params \
+    { \
+        if (!cl12EnsureLoaded() || (cl12Functions.name == NULL)) \
+        { \
+            return NULL; \
+        } \
+        return cl12Functions.name args; \
+    }
+
+CL12_FUNCTIONS(CL12_DEFINE_STATUS, CL12_DEFINE_OBJECT, CL12_DEFINE_POINTER)
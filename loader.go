@@ -0,0 +1,87 @@
+package cl12
+
+// #cgo linux LDFLAGS: -ldl
+// #include "api.h"
+import "C"
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// LibraryPathEnvVar is the name of the environment variable that can specify the path of the OpenCL library.
+// If set, the library at this path is loaded instead of the default library of the system.
+const LibraryPathEnvVar = "CL12_OPENCL_LIBRARY"
+
+var library struct {
+	mutex     sync.Mutex
+	attempted bool
+}
+
+// LoadLibrary loads the OpenCL library from the given path and resolves all OpenCL 1.2 entry points.
+//
+// The OpenCL library is not linked at build time. Without a call to LoadLibrary(), the library is loaded with
+// the first call of any OpenCL function. In that case, the path is taken from the environment variable named by
+// LibraryPathEnvVar, or the default library of the system is used.
+// An empty path for LoadLibrary() follows the same rules.
+//
+// Once a library was loaded successfully, it stays loaded and further calls to LoadLibrary() have no effect.
+//
+// The returned error wraps ErrLibraryNotAvailable if the library could not be loaded.
+func LoadLibrary(path string) error {
+	library.mutex.Lock()
+	defer library.mutex.Unlock()
+	library.attempted = true
+	if len(path) > 0 {
+		return loadLibrary([]string{path})
+	}
+	return loadLibrary(defaultLibraryPaths())
+}
+
+//export cl12GoLoadDefaultLibrary
+func cl12GoLoadDefaultLibrary() {
+	library.mutex.Lock()
+	defer library.mutex.Unlock()
+	if library.attempted {
+		return
+	}
+	library.attempted = true
+	_ = loadLibrary(defaultLibraryPaths())
+}
+
+func defaultLibraryPaths() []string {
+	if path := os.Getenv(LibraryPathEnvVar); len(path) > 0 {
+		return []string{path}
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"/System/Library/Frameworks/OpenCL.framework/OpenCL"}
+	case "windows":
+		return []string{"OpenCL.dll"}
+	default:
+		return []string{"libOpenCL.so.1", "libOpenCL.so"}
+	}
+}
+
+func loadLibrary(paths []string) error {
+	const errorTextSize = 512
+	errorText := (*C.char)(C.calloc(errorTextSize, 1))
+	if errorText == nil {
+		return ErrOutOfMemory
+	}
+	defer C.free(unsafe.Pointer(errorText))
+	reasons := make([]string, 0, len(paths))
+	for _, path := range paths {
+		rawPath := C.CString(path)
+		loaded := C.cl12LoadLibrary(rawPath, errorText, errorTextSize)
+		C.free(unsafe.Pointer(rawPath))
+		if loaded != 0 {
+			return nil
+		}
+		reasons = append(reasons, C.GoString(errorText))
+	}
+	return fmt.Errorf("%w: %s", ErrLibraryNotAvailable, strings.Join(reasons, "; "))
+}
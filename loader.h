@@ -0,0 +1,397 @@
+#pragma once
+
+// CL12_LIBRARY_NOT_AVAILABLE is the status returned by all entry points if the OpenCL library could not be loaded,
+// or if the loaded library does not provide the called function.
+// It is the lowest possible status value, which is outside of any range reserved by Khronos.
+#define CL12_LIBRARY_NOT_AVAILABLE (-2147483647 - 1)
+
+// CL12_FUNCTIONS lists all OpenCL 1.2 entry points that are resolved at runtime.
+//
+// STATUS entries return a cl_int status value.
+// OBJECT entries return an object and report their status via the parameter errcodeRet.
+// POINTER entries return a pointer without a status.
+#define CL12_FUNCTIONS(STATUS, OBJECT, POINTER) \
+    STATUS(GetPlatformIDs, \
+        (cl_uint numEntries, cl_platform_id *platforms, cl_uint *numPlatforms), \
+        (numEntries, platforms, numPlatforms)) \
+    STATUS(GetPlatformInfo, \
+        (cl_platform_id platform, cl_platform_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (platform, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    POINTER(void *, GetExtensionFunctionAddressForPlatform, \
+        (cl_platform_id platform, char const *funcName), \
+        (platform, funcName)) \
+    STATUS(UnloadPlatformCompiler, \
+        (cl_platform_id platform), \
+        (platform)) \
+    STATUS(GetDeviceIDs, \
+        (cl_platform_id platform, cl_device_type deviceType, cl_uint numEntries, cl_device_id *devices, cl_uint *numDevices), \
+        (platform, deviceType, numEntries, devices, numDevices)) \
+    STATUS(GetDeviceInfo, \
+        (cl_device_id device, cl_device_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (device, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(CreateSubDevices, \
+        (cl_device_id inDevice, cl_device_partition_property const *properties, cl_uint numDevices, cl_device_id *outDevices, cl_uint *numDevicesRet), \
+        (inDevice, properties, numDevices, outDevices, numDevicesRet)) \
+    STATUS(RetainDevice, \
+        (cl_device_id device), \
+        (device)) \
+    STATUS(ReleaseDevice, \
+        (cl_device_id device), \
+        (device)) \
+    OBJECT(cl_context, CreateContext, \
+        (cl_context_properties const *properties, cl_uint numDevices, cl_device_id const *devices, \
+            void (CL_CALLBACK *notify)(char const *, void const *, size_t, void *), void *userData, cl_int *errcodeRet), \
+        (properties, numDevices, devices, notify, userData, errcodeRet)) \
+    OBJECT(cl_context, CreateContextFromType, \
+        (cl_context_properties const *properties, cl_device_type deviceType, \
+            void (CL_CALLBACK *notify)(char const *, void const *, size_t, void *), void *userData, cl_int *errcodeRet), \
+        (properties, deviceType, notify, userData, errcodeRet)) \
+    STATUS(RetainContext, \
+        (cl_context context), \
+        (context)) \
+    STATUS(ReleaseContext, \
+        (cl_context context), \
+        (context)) \
+    STATUS(GetContextInfo, \
+        (cl_context context, cl_context_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (context, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    OBJECT(cl_command_queue, CreateCommandQueue, \
+        (cl_context context, cl_device_id device, cl_command_queue_properties properties, cl_int *errcodeRet), \
+        (context, device, properties, errcodeRet)) \
+    STATUS(RetainCommandQueue, \
+        (cl_command_queue commandQueue), \
+        (commandQueue)) \
+    STATUS(ReleaseCommandQueue, \
+        (cl_command_queue commandQueue), \
+        (commandQueue)) \
+    STATUS(GetCommandQueueInfo, \
+        (cl_command_queue commandQueue, cl_command_queue_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (commandQueue, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(Flush, \
+        (cl_command_queue commandQueue), \
+        (commandQueue)) \
+    STATUS(Finish, \
+        (cl_command_queue commandQueue), \
+        (commandQueue)) \
+    OBJECT(cl_mem, CreateBuffer, \
+        (cl_context context, cl_mem_flags flags, size_t size, void *hostPtr, cl_int *errcodeRet), \
+        (context, flags, size, hostPtr, errcodeRet)) \
+    OBJECT(cl_mem, CreateSubBuffer, \
+        (cl_mem buffer, cl_mem_flags flags, cl_buffer_create_type createType, void const *createInfo, cl_int *errcodeRet), \
+        (buffer, flags, createType, createInfo, errcodeRet)) \
+    OBJECT(cl_mem, CreateImage, \
+        (cl_context context, cl_mem_flags flags, cl_image_format const *format, cl_image_desc const *desc, void *hostPtr, cl_int *errcodeRet), \
+        (context, flags, format, desc, hostPtr, errcodeRet)) \
+    STATUS(RetainMemObject, \
+        (cl_mem mem), \
+        (mem)) \
+    STATUS(ReleaseMemObject, \
+        (cl_mem mem), \
+        (mem)) \
+    STATUS(GetSupportedImageFormats, \
+        (cl_context context, cl_mem_flags flags, cl_mem_object_type imageType, cl_uint numEntries, cl_image_format *formats, cl_uint *numFormats), \
+        (context, flags, imageType, numEntries, formats, numFormats)) \
+    STATUS(GetMemObjectInfo, \
+        (cl_mem mem, cl_mem_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (mem, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(GetImageInfo, \
+        (cl_mem image, cl_image_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (image, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(SetMemObjectDestructorCallback, \
+        (cl_mem mem, void (CL_CALLBACK *notify)(cl_mem, void *), void *userData), \
+        (mem, notify, userData)) \
+    OBJECT(cl_sampler, CreateSampler, \
+        (cl_context context, cl_bool normalizedCoords, cl_addressing_mode addressingMode, cl_filter_mode filterMode, cl_int *errcodeRet), \
+        (context, normalizedCoords, addressingMode, filterMode, errcodeRet)) \
+    STATUS(RetainSampler, \
+        (cl_sampler sampler), \
+        (sampler)) \
+    STATUS(ReleaseSampler, \
+        (cl_sampler sampler), \
+        (sampler)) \
+    STATUS(GetSamplerInfo, \
+        (cl_sampler sampler, cl_sampler_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (sampler, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    OBJECT(cl_program, CreateProgramWithSource, \
+        (cl_context context, cl_uint count, char const **strings, size_t const *lengths, cl_int *errcodeRet), \
+        (context, count, strings, lengths, errcodeRet)) \
+    OBJECT(cl_program, CreateProgramWithBinary, \
+        (cl_context context, cl_uint numDevices, cl_device_id const *devices, size_t const *lengths, \
+            unsigned char const **binaries, cl_int *binaryStatus, cl_int *errcodeRet), \
+        (context, numDevices, devices, lengths, binaries, binaryStatus, errcodeRet)) \
+    OBJECT(cl_program, CreateProgramWithBuiltInKernels, \
+        (cl_context context, cl_uint numDevices, cl_device_id const *devices, char const *kernelNames, cl_int *errcodeRet), \
+        (context, numDevices, devices, kernelNames, errcodeRet)) \
+    STATUS(RetainProgram, \
+        (cl_program program), \
+        (program)) \
+    STATUS(ReleaseProgram, \
+        (cl_program program), \
+        (program)) \
+    STATUS(BuildProgram, \
+        (cl_program program, cl_uint numDevices, cl_device_id const *devices, char const *options, \
+            void (CL_CALLBACK *notify)(cl_program, void *), void *userData), \
+        (program, numDevices, devices, options, notify, userData)) \
+    STATUS(CompileProgram, \
+        (cl_program program, cl_uint numDevices, cl_device_id const *devices, char const *options, \
+            cl_uint numInputHeaders, cl_program const *inputHeaders, char const **headerIncludeNames, \
+            void (CL_CALLBACK *notify)(cl_program, void *), void *userData), \
+        (program, numDevices, devices, options, numInputHeaders, inputHeaders, headerIncludeNames, notify, userData)) \
+    OBJECT(cl_program, LinkProgram, \
+        (cl_context context, cl_uint numDevices, cl_device_id const *devices, char const *options, \
+            cl_uint numInputPrograms, cl_program const *inputPrograms, \
+            void (CL_CALLBACK *notify)(cl_program, void *), void *userData, cl_int *errcodeRet), \
+        (context, numDevices, devices, options, numInputPrograms, inputPrograms, notify, userData, errcodeRet)) \
+    STATUS(GetProgramInfo, \
+        (cl_program program, cl_program_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (program, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(GetProgramBuildInfo, \
+        (cl_program program, cl_device_id device, cl_program_build_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (program, device, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    OBJECT(cl_kernel, CreateKernel, \
+        (cl_program program, char const *kernelName, cl_int *errcodeRet), \
+        (program, kernelName, errcodeRet)) \
+    STATUS(CreateKernelsInProgram, \
+        (cl_program program, cl_uint numKernels, cl_kernel *kernels, cl_uint *numKernelsRet), \
+        (program, numKernels, kernels, numKernelsRet)) \
+    STATUS(RetainKernel, \
+        (cl_kernel kernel), \
+        (kernel)) \
+    STATUS(ReleaseKernel, \
+        (cl_kernel kernel), \
+        (kernel)) \
+    STATUS(SetKernelArg, \
+        (cl_kernel kernel, cl_uint argIndex, size_t argSize, void const *argValue), \
+        (kernel, argIndex, argSize, argValue)) \
+    STATUS(GetKernelInfo, \
+        (cl_kernel kernel, cl_kernel_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (kernel, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(GetKernelArgInfo, \
+        (cl_kernel kernel, cl_uint argIndex, cl_kernel_arg_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (kernel, argIndex, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(GetKernelWorkGroupInfo, \
+        (cl_kernel kernel, cl_device_id device, cl_kernel_work_group_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (kernel, device, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(WaitForEvents, \
+        (cl_uint numEvents, cl_event const *eventList), \
+        (numEvents, eventList)) \
+    STATUS(GetEventInfo, \
+        (cl_event event, cl_event_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (event, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    OBJECT(cl_event, CreateUserEvent, \
+        (cl_context context, cl_int *errcodeRet), \
+        (context, errcodeRet)) \
+    STATUS(RetainEvent, \
+        (cl_event event), \
+        (event)) \
+    STATUS(ReleaseEvent, \
+        (cl_event event), \
+        (event)) \
+    STATUS(SetUserEventStatus, \
+        (cl_event event, cl_int executionStatus), \
+        (event, executionStatus)) \
+    STATUS(SetEventCallback, \
+        (cl_event event, cl_int commandExecCallbackType, void (CL_CALLBACK *notify)(cl_event, cl_int, void *), void *userData), \
+        (event, commandExecCallbackType, notify, userData)) \
+    STATUS(GetEventProfilingInfo, \
+        (cl_event event, cl_profiling_info paramName, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet), \
+        (event, paramName, paramValueSize, paramValue, paramValueSizeRet)) \
+    STATUS(EnqueueReadBuffer, \
+        (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingRead, size_t offset, size_t size, void *ptr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, buffer, blockingRead, offset, size, ptr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueReadBufferRect, \
+        (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingRead, \
+            size_t const *bufferOrigin, size_t const *hostOrigin, size_t const *region, \
+            size_t bufferRowPitch, size_t bufferSlicePitch, size_t hostRowPitch, size_t hostSlicePitch, void *ptr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, buffer, blockingRead, bufferOrigin, hostOrigin, region, \
+            bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, ptr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueWriteBuffer, \
+        (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingWrite, size_t offset, size_t size, void const *ptr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, buffer, blockingWrite, offset, size, ptr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueWriteBufferRect, \
+        (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingWrite, \
+            size_t const *bufferOrigin, size_t const *hostOrigin, size_t const *region, \
+            size_t bufferRowPitch, size_t bufferSlicePitch, size_t hostRowPitch, size_t hostSlicePitch, void const *ptr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, buffer, blockingWrite, bufferOrigin, hostOrigin, region, \
+            bufferRowPitch, bufferSlicePitch, hostRowPitch, hostSlicePitch, ptr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueFillBuffer, \
+        (cl_command_queue commandQueue, cl_mem buffer, void const *pattern, size_t patternSize, size_t offset, size_t size, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, buffer, pattern, patternSize, offset, size, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueCopyBuffer, \
+        (cl_command_queue commandQueue, cl_mem srcBuffer, cl_mem dstBuffer, size_t srcOffset, size_t dstOffset, size_t size, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, srcBuffer, dstBuffer, srcOffset, dstOffset, size, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueCopyBufferRect, \
+        (cl_command_queue commandQueue, cl_mem srcBuffer, cl_mem dstBuffer, \
+            size_t const *srcOrigin, size_t const *dstOrigin, size_t const *region, \
+            size_t srcRowPitch, size_t srcSlicePitch, size_t dstRowPitch, size_t dstSlicePitch, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, srcBuffer, dstBuffer, srcOrigin, dstOrigin, region, \
+            srcRowPitch, srcSlicePitch, dstRowPitch, dstSlicePitch, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueReadImage, \
+        (cl_command_queue commandQueue, cl_mem image, cl_bool blockingRead, size_t const *origin, size_t const *region, \
+            size_t rowPitch, size_t slicePitch, void *ptr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, image, blockingRead, origin, region, rowPitch, slicePitch, ptr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueWriteImage, \
+        (cl_command_queue commandQueue, cl_mem image, cl_bool blockingWrite, size_t const *origin, size_t const *region, \
+            size_t inputRowPitch, size_t inputSlicePitch, void const *ptr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, image, blockingWrite, origin, region, inputRowPitch, inputSlicePitch, ptr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueFillImage, \
+        (cl_command_queue commandQueue, cl_mem image, void const *fillColor, size_t const *origin, size_t const *region, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, image, fillColor, origin, region, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueCopyImage, \
+        (cl_command_queue commandQueue, cl_mem srcImage, cl_mem dstImage, \
+            size_t const *srcOrigin, size_t const *dstOrigin, size_t const *region, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, srcImage, dstImage, srcOrigin, dstOrigin, region, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueCopyImageToBuffer, \
+        (cl_command_queue commandQueue, cl_mem srcImage, cl_mem dstBuffer, \
+            size_t const *srcOrigin, size_t const *region, size_t dstOffset, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, srcImage, dstBuffer, srcOrigin, region, dstOffset, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueCopyBufferToImage, \
+        (cl_command_queue commandQueue, cl_mem srcBuffer, cl_mem dstImage, \
+            size_t srcOffset, size_t const *dstOrigin, size_t const *region, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, srcBuffer, dstImage, srcOffset, dstOrigin, region, numEventsInWaitList, eventWaitList, event)) \
+    OBJECT(void *, EnqueueMapBuffer, \
+        (cl_command_queue commandQueue, cl_mem buffer, cl_bool blockingMap, cl_map_flags mapFlags, size_t offset, size_t size, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event, cl_int *errcodeRet), \
+        (commandQueue, buffer, blockingMap, mapFlags, offset, size, numEventsInWaitList, eventWaitList, event, errcodeRet)) \
+    OBJECT(void *, EnqueueMapImage, \
+        (cl_command_queue commandQueue, cl_mem image, cl_bool blockingMap, cl_map_flags mapFlags, \
+            size_t const *origin, size_t const *region, size_t *imageRowPitch, size_t *imageSlicePitch, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event, cl_int *errcodeRet), \
+        (commandQueue, image, blockingMap, mapFlags, origin, region, imageRowPitch, imageSlicePitch, \
+            numEventsInWaitList, eventWaitList, event, errcodeRet)) \
+    STATUS(EnqueueUnmapMemObject, \
+        (cl_command_queue commandQueue, cl_mem mem, void *mappedPtr, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, mem, mappedPtr, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueMigrateMemObjects, \
+        (cl_command_queue commandQueue, cl_uint numMemObjects, cl_mem const *memObjects, cl_mem_migration_flags flags, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, numMemObjects, memObjects, flags, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueNDRangeKernel, \
+        (cl_command_queue commandQueue, cl_kernel kernel, cl_uint workDim, \
+            size_t const *globalWorkOffset, size_t const *globalWorkSize, size_t const *localWorkSize, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, kernel, workDim, globalWorkOffset, globalWorkSize, localWorkSize, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueTask, \
+        (cl_command_queue commandQueue, cl_kernel kernel, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, kernel, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueNativeKernel, \
+        (cl_command_queue commandQueue, void (CL_CALLBACK *userFunc)(void *), void *args, size_t argsSize, \
+            cl_uint numMemObjects, cl_mem const *memList, void const **argsMemLoc, \
+            cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, userFunc, args, argsSize, numMemObjects, memList, argsMemLoc, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueMarkerWithWaitList, \
+        (cl_command_queue commandQueue, cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, numEventsInWaitList, eventWaitList, event)) \
+    STATUS(EnqueueBarrierWithWaitList, \
+        (cl_command_queue commandQueue, cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event), \
+        (commandQueue, numEventsInWaitList, eventWaitList, event))
+
+#define CL12_DECLARE_STATUS(name, params, args) extern cl_int cl12Dispatch##name params;
+#define CL12_DECLARE_OBJECT(type, name, params, args) extern type cl12Dispatch##name params;
+#define CL12_DECLARE_POINTER(type, name, params, args) extern type cl12Dispatch##name params;
+CL12_FUNCTIONS(CL12_DECLARE_STATUS, CL12_DECLARE_OBJECT, CL12_DECLARE_POINTER)
+#undef CL12_DECLARE_STATUS
+#undef CL12_DECLARE_OBJECT
+#undef CL12_DECLARE_POINTER
+
+// cl12LoadLibrary loads the OpenCL library from the given path and resolves all entry points.
+// It returns a non-zero value on success. On failure, a description is written to errorText.
+extern int cl12LoadLibrary(char const *path, char *errorText, size_t errorTextSize);
+
+// The following definitions redirect all OpenCL calls to the dispatch functions, which call into the loaded library.
+#define clGetPlatformIDs cl12DispatchGetPlatformIDs
+#define clGetPlatformInfo cl12DispatchGetPlatformInfo
+#define clGetExtensionFunctionAddressForPlatform cl12DispatchGetExtensionFunctionAddressForPlatform
+#define clUnloadPlatformCompiler cl12DispatchUnloadPlatformCompiler
+#define clGetDeviceIDs cl12DispatchGetDeviceIDs
+#define clGetDeviceInfo cl12DispatchGetDeviceInfo
+#define clCreateSubDevices cl12DispatchCreateSubDevices
+#define clRetainDevice cl12DispatchRetainDevice
+#define clReleaseDevice cl12DispatchReleaseDevice
+#define clCreateContext cl12DispatchCreateContext
+#define clCreateContextFromType cl12DispatchCreateContextFromType
+#define clRetainContext cl12DispatchRetainContext
+#define clReleaseContext cl12DispatchReleaseContext
+#define clGetContextInfo cl12DispatchGetContextInfo
+#define clCreateCommandQueue cl12DispatchCreateCommandQueue
+#define clRetainCommandQueue cl12DispatchRetainCommandQueue
+#define clReleaseCommandQueue cl12DispatchReleaseCommandQueue
+#define clGetCommandQueueInfo cl12DispatchGetCommandQueueInfo
+#define clFlush cl12DispatchFlush
+#define clFinish cl12DispatchFinish
+#define clCreateBuffer cl12DispatchCreateBuffer
+#define clCreateSubBuffer cl12DispatchCreateSubBuffer
+#define clCreateImage cl12DispatchCreateImage
+#define clRetainMemObject cl12DispatchRetainMemObject
+#define clReleaseMemObject cl12DispatchReleaseMemObject
+#define clGetSupportedImageFormats cl12DispatchGetSupportedImageFormats
+#define clGetMemObjectInfo cl12DispatchGetMemObjectInfo
+#define clGetImageInfo cl12DispatchGetImageInfo
+#define clSetMemObjectDestructorCallback cl12DispatchSetMemObjectDestructorCallback
+#define clCreateSampler cl12DispatchCreateSampler
+#define clRetainSampler cl12DispatchRetainSampler
+#define clReleaseSampler cl12DispatchReleaseSampler
+#define clGetSamplerInfo cl12DispatchGetSamplerInfo
+#define clCreateProgramWithSource cl12DispatchCreateProgramWithSource
+#define clCreateProgramWithBinary cl12DispatchCreateProgramWithBinary
+#define clCreateProgramWithBuiltInKernels cl12DispatchCreateProgramWithBuiltInKernels
+#define clRetainProgram cl12DispatchRetainProgram
+#define clReleaseProgram cl12DispatchReleaseProgram
+#define clBuildProgram cl12DispatchBuildProgram
+#define clCompileProgram cl12DispatchCompileProgram
+#define clLinkProgram cl12DispatchLinkProgram
+#define clGetProgramInfo cl12DispatchGetProgramInfo
+#define clGetProgramBuildInfo cl12DispatchGetProgramBuildInfo
+#define clCreateKernel cl12DispatchCreateKernel
+#define clCreateKernelsInProgram cl12DispatchCreateKernelsInProgram
+#define clRetainKernel cl12DispatchRetainKernel
+#define clReleaseKernel cl12DispatchReleaseKernel
+#define clSetKernelArg cl12DispatchSetKernelArg
+#define clGetKernelInfo cl12DispatchGetKernelInfo
+#define clGetKernelArgInfo cl12DispatchGetKernelArgInfo
+#define clGetKernelWorkGroupInfo cl12DispatchGetKernelWorkGroupInfo
+#define clWaitForEvents cl12DispatchWaitForEvents
+#define clGetEventInfo cl12DispatchGetEventInfo
+#define clCreateUserEvent cl12DispatchCreateUserEvent
+#define clRetainEvent cl12DispatchRetainEvent
+#define clReleaseEvent cl12DispatchReleaseEvent
+#define clSetUserEventStatus cl12DispatchSetUserEventStatus
+#define clSetEventCallback cl12DispatchSetEventCallback
+#define clGetEventProfilingInfo cl12DispatchGetEventProfilingInfo
+#define clEnqueueReadBuffer cl12DispatchEnqueueReadBuffer
+#define clEnqueueReadBufferRect cl12DispatchEnqueueReadBufferRect
+#define clEnqueueWriteBuffer cl12DispatchEnqueueWriteBuffer
+#define clEnqueueWriteBufferRect cl12DispatchEnqueueWriteBufferRect
+#define clEnqueueFillBuffer cl12DispatchEnqueueFillBuffer
+#define clEnqueueCopyBuffer cl12DispatchEnqueueCopyBuffer
+#define clEnqueueCopyBufferRect cl12DispatchEnqueueCopyBufferRect
+#define clEnqueueReadImage cl12DispatchEnqueueReadImage
+#define clEnqueueWriteImage cl12DispatchEnqueueWriteImage
+#define clEnqueueFillImage cl12DispatchEnqueueFillImage
+#define clEnqueueCopyImage cl12DispatchEnqueueCopyImage
+#define clEnqueueCopyImageToBuffer cl12DispatchEnqueueCopyImageToBuffer
+#define clEnqueueCopyBufferToImage cl12DispatchEnqueueCopyBufferToImage
+#define clEnqueueMapBuffer cl12DispatchEnqueueMapBuffer
+#define clEnqueueMapImage cl12DispatchEnqueueMapImage
+#define clEnqueueUnmapMemObject cl12DispatchEnqueueUnmapMemObject
+#define clEnqueueMigrateMemObjects cl12DispatchEnqueueMigrateMemObjects
+#define clEnqueueNDRangeKernel cl12DispatchEnqueueNDRangeKernel
+#define clEnqueueTask cl12DispatchEnqueueTask
+#define clEnqueueNativeKernel cl12DispatchEnqueueNativeKernel
+#define clEnqueueMarkerWithWaitList cl12DispatchEnqueueMarkerWithWaitList
+#define clEnqueueBarrierWithWaitList cl12DispatchEnqueueBarrierWithWaitList
@@ -0,0 +1,42 @@
+package managed
+
+import (
+	"io"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// CommandQueue owns a cl.CommandQueue and references its context.
+type CommandQueue struct {
+	object
+	handle  cl.CommandQueue
+	context *Context
+}
+
+var _ io.Closer = (*CommandQueue)(nil)
+
+// CreateCommandQueue creates a command-queue on a device of the context, see cl.CreateCommandQueue().
+func CreateCommandQueue(context *Context, deviceID cl.DeviceID, properties cl.CommandQueuePropertiesFlags) (*CommandQueue, error) {
+	handle, err := cl.CreateCommandQueue(context.Handle(), deviceID, properties)
+	if err != nil {
+		return nil, err
+	}
+	queue := &CommandQueue{handle: handle, context: context}
+	initObject(queue, "CommandQueue", handle, func() error { return cl.ReleaseCommandQueue(handle) })
+	return queue, nil
+}
+
+// Handle returns the underlying handle. The handle is invalid after the command-queue was closed.
+func (queue *CommandQueue) Handle() cl.CommandQueue {
+	return queue.handle
+}
+
+// Context returns the context the command-queue was created for.
+func (queue *CommandQueue) Context() *Context {
+	return queue.context
+}
+
+// Close releases the command-queue. Only the first call has an effect.
+func (queue *CommandQueue) Close() error {
+	return queue.close()
+}
@@ -0,0 +1,54 @@
+package managed
+
+import (
+	"io"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// Context owns a cl.Context.
+type Context struct {
+	object
+	handle cl.Context
+}
+
+var _ io.Closer = (*Context)(nil)
+
+// CreateContext creates a context for the given devices, see cl.CreateContext().
+//
+// The callback is not owned by the context. It must stay valid until all objects of the context are closed.
+func CreateContext(deviceIDs []cl.DeviceID, callback *cl.ContextErrorCallback, properties ...cl.ContextProperty) (*Context, error) {
+	handle, err := cl.CreateContext(deviceIDs, callback, properties...)
+	if err != nil {
+		return nil, err
+	}
+	return newContext(handle), nil
+}
+
+// CreateContextFromType creates a context for devices of the given type, see cl.CreateContextFromType().
+//
+// The callback is not owned by the context. It must stay valid until all objects of the context are closed.
+func CreateContextFromType(deviceType cl.DeviceTypeFlags, callback *cl.ContextErrorCallback,
+	properties ...cl.ContextProperty) (*Context, error) {
+	handle, err := cl.CreateContextFromType(deviceType, callback, properties...)
+	if err != nil {
+		return nil, err
+	}
+	return newContext(handle), nil
+}
+
+func newContext(handle cl.Context) *Context {
+	context := &Context{handle: handle}
+	initObject(context, "Context", handle, func() error { return cl.ReleaseContext(handle) })
+	return context
+}
+
+// Handle returns the underlying handle. The handle is invalid after the context was closed.
+func (context *Context) Handle() cl.Context {
+	return context.handle
+}
+
+// Close releases the context. Only the first call has an effect.
+func (context *Context) Close() error {
+	return context.close()
+}
@@ -0,0 +1,23 @@
+//go:build cl12debug
+
+package managed
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// LeakDetectionEnabled is true in debug builds, which report objects that were not closed.
+const LeakDetectionEnabled = true
+
+func trackAllocation(res resource) {
+	res.base().stack = debug.Stack()
+	runtime.SetFinalizer(res, finalizeResource)
+}
+
+func finalizeResource(res resource) {
+	obj := res.base()
+	if !obj.isClosed() {
+		reportLeak(Leak{Object: obj.name, Stack: string(obj.stack)})
+	}
+}
@@ -0,0 +1,57 @@
+package managed
+
+import (
+	"io"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// Event owns a cl.Event. Events of commands reference their command-queue, user events reference their context.
+type Event struct {
+	object
+	handle  cl.Event
+	queue   *CommandQueue
+	context *Context
+}
+
+var _ io.Closer = (*Event)(nil)
+
+// CreateUserEvent creates a user event object, see cl.CreateUserEvent().
+func CreateUserEvent(context *Context) (*Event, error) {
+	handle, err := cl.CreateUserEvent(context.Handle())
+	if err != nil {
+		return nil, err
+	}
+	return newEvent(handle, nil, context), nil
+}
+
+// NewEvent takes ownership of an event that an enqueue function returned for a command of the given queue.
+func NewEvent(queue *CommandQueue, handle cl.Event) *Event {
+	return newEvent(handle, queue, queue.Context())
+}
+
+func newEvent(handle cl.Event, queue *CommandQueue, context *Context) *Event {
+	event := &Event{handle: handle, queue: queue, context: context}
+	initObject(event, "Event", handle, func() error { return cl.ReleaseEvent(handle) })
+	return event
+}
+
+// Handle returns the underlying handle. The handle is invalid after the event was closed.
+func (event *Event) Handle() cl.Event {
+	return event.handle
+}
+
+// CommandQueue returns the command-queue of the command the event belongs to, or nil for user events.
+func (event *Event) CommandQueue() *CommandQueue {
+	return event.queue
+}
+
+// Context returns the context of the event.
+func (event *Event) Context() *Context {
+	return event.context
+}
+
+// Close releases the event. Only the first call has an effect.
+func (event *Event) Close() error {
+	return event.close()
+}
@@ -0,0 +1,60 @@
+package managed
+
+import (
+	"io"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// Kernel owns a cl.Kernel and references the program it was created from.
+type Kernel struct {
+	object
+	handle  cl.Kernel
+	program *Program
+}
+
+var _ io.Closer = (*Kernel)(nil)
+
+// CreateKernel creates a kernel object, see cl.CreateKernel().
+func CreateKernel(program *Program, name string) (*Kernel, error) {
+	handle, err := cl.CreateKernel(program.Handle(), name)
+	if err != nil {
+		return nil, err
+	}
+	return newKernel(handle, program), nil
+}
+
+// CreateKernelsInProgram creates kernel objects for all kernel functions of a program,
+// see cl.CreateKernelsInProgram().
+func CreateKernelsInProgram(program *Program) ([]*Kernel, error) {
+	handles, err := cl.CreateKernelsInProgram(program.Handle())
+	if err != nil {
+		return nil, err
+	}
+	kernels := make([]*Kernel, len(handles))
+	for i, handle := range handles {
+		kernels[i] = newKernel(handle, program)
+	}
+	return kernels, nil
+}
+
+func newKernel(handle cl.Kernel, program *Program) *Kernel {
+	kernel := &Kernel{handle: handle, program: program}
+	initObject(kernel, "Kernel", handle, func() error { return cl.ReleaseKernel(handle) })
+	return kernel
+}
+
+// Handle returns the underlying handle. The handle is invalid after the kernel was closed.
+func (kernel *Kernel) Handle() cl.Kernel {
+	return kernel.handle
+}
+
+// Program returns the program the kernel was created from.
+func (kernel *Kernel) Program() *Program {
+	return kernel.program
+}
+
+// Close releases the kernel. Only the first call has an effect.
+func (kernel *Kernel) Close() error {
+	return kernel.close()
+}
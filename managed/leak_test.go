@@ -0,0 +1,46 @@
+//go:build cl12debug
+
+package managed_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	cl "github.com/opencl-go/cl12"
+	"github.com/opencl-go/cl12/managed"
+)
+
+func TestLeakReport(t *testing.T) {
+	context, _ := stubContext(t)
+	leaks := make(chan managed.Leak, 16)
+	managed.SetLeakHandler(func(leak managed.Leak) { leaks <- leak })
+	defer managed.SetLeakHandler(nil)
+	leakBuffer(t, context)
+	deadline := time.After(5 * time.Second)
+	for {
+		runtime.GC()
+		select {
+		case leak := <-leaks:
+			if !strings.HasPrefix(leak.Object, "MemObject 0x") {
+				t.Errorf("unexpected object: %q", leak.Object)
+			}
+			if !strings.Contains(leak.Stack, "leakBuffer") {
+				t.Errorf("allocation stack does not contain the allocating function:\n%s", leak.Stack)
+			}
+			return
+		case <-deadline:
+			t.Fatalf("leak not reported")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func leakBuffer(t *testing.T, context *managed.Context) {
+	t.Helper()
+	_, err := managed.CreateBuffer(context, cl.MemReadWriteFlag, 64, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer() failed: %v", err)
+	}
+}
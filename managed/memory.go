@@ -0,0 +1,81 @@
+package managed
+
+import (
+	"io"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// MemObject owns a cl.MemObject, which is either a buffer or an image, and references its context.
+// Sub-buffers also reference the buffer they were created from.
+type MemObject struct {
+	object
+	handle  cl.MemObject
+	context *Context
+	parent  *MemObject
+}
+
+var _ io.Closer = (*MemObject)(nil)
+
+// CreateBuffer creates a buffer object, see cl.CreateBuffer().
+func CreateBuffer(context *Context, flags cl.MemFlags, size int, hostPtr unsafe.Pointer) (*MemObject, error) {
+	handle, err := cl.CreateBuffer(context.Handle(), flags, size, hostPtr)
+	if err != nil {
+		return nil, err
+	}
+	return newMemObject(handle, context, nil), nil
+}
+
+// CreateSubBuffer creates a buffer object from an existing buffer object, see cl.CreateSubBuffer().
+func CreateSubBuffer(buffer *MemObject, flags cl.MemFlags, createType cl.BufferCreateType, createInfo unsafe.Pointer) (*MemObject, error) {
+	handle, err := cl.CreateSubBuffer(buffer.Handle(), flags, createType, createInfo)
+	if err != nil {
+		return nil, err
+	}
+	return newMemObject(handle, buffer.context, buffer), nil
+}
+
+// CreateImage creates an image object, see cl.CreateImage().
+func CreateImage(context *Context, flags cl.MemFlags, format cl.ImageFormat, desc cl.ImageDesc, hostPtr unsafe.Pointer) (*MemObject, error) {
+	handle, err := cl.CreateImage(context.Handle(), flags, format, desc, hostPtr)
+	if err != nil {
+		return nil, err
+	}
+	return newMemObject(handle, context, nil), nil
+}
+
+func newMemObject(handle cl.MemObject, context *Context, parent *MemObject) *MemObject {
+	mem := &MemObject{handle: handle, context: context, parent: parent}
+	initObject(mem, "MemObject", handle, func() error { return cl.ReleaseMemObject(handle) })
+	return mem
+}
+
+// Handle returns the underlying handle. The handle is invalid after the memory object was closed.
+func (mem *MemObject) Handle() cl.MemObject {
+	return mem.handle
+}
+
+// MemObject returns the underlying handle, or zero for a nil memory object. With this method, memory objects can be
+// used for kernel arguments with cl.BindKernelArgs() and cl.SetKernelArgChecked().
+func (mem *MemObject) MemObject() cl.MemObject {
+	if mem == nil {
+		return 0
+	}
+	return mem.handle
+}
+
+// Context returns the context the memory object was created for.
+func (mem *MemObject) Context() *Context {
+	return mem.context
+}
+
+// Parent returns the buffer a sub-buffer was created from, or nil for other memory objects.
+func (mem *MemObject) Parent() *MemObject {
+	return mem.parent
+}
+
+// Close releases the memory object. Only the first call has an effect.
+func (mem *MemObject) Close() error {
+	return mem.close()
+}
@@ -0,0 +1,100 @@
+// Package managed provides resource-owning wrappers for the objects of the cl12 package.
+//
+// The types of this package hold the handle of an OpenCL object together with references to their parent objects.
+// For example, a Kernel keeps the Program it was created from reachable. All types implement io.Closer, which releases
+// the handle. Closing an object a second time has no effect.
+//
+// Build with the tag "cl12debug" to detect objects that are garbage collected without having been closed.
+// In such a debug build, each object records the stack of its allocation, and a finalizer reports unclosed objects
+// to the handler set with SetLeakHandler().
+package managed
+
+import (
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Leak describes an object that was garbage collected without having been closed.
+type Leak struct {
+	// Object identifies the leaked object by its type and handle, such as "Kernel 0x7F3A10".
+	Object string
+	// Stack is the stack trace of the goroutine that allocated the object.
+	Stack string
+}
+
+// LeakHandler is called for every detected Leak. It is called from the finalizer goroutine.
+type LeakHandler func(Leak)
+
+var leakHandler = struct {
+	mutex   sync.Mutex
+	handler LeakHandler
+}{
+	handler: logLeak,
+}
+
+// SetLeakHandler sets the function that is called for objects that were not closed.
+// The default handler writes the leak to the standard logger. A nil handler ignores leaks.
+//
+// Leaks are only detected in debug builds, see LeakDetectionEnabled.
+func SetLeakHandler(handler LeakHandler) {
+	leakHandler.mutex.Lock()
+	defer leakHandler.mutex.Unlock()
+	leakHandler.handler = handler
+}
+
+func reportLeak(leak Leak) {
+	leakHandler.mutex.Lock()
+	handler := leakHandler.handler
+	leakHandler.mutex.Unlock()
+	if handler != nil {
+		handler(leak)
+	}
+}
+
+func logLeak(leak Leak) {
+	log.Printf("cl12/managed: %s was not closed, allocated at:\n%s", leak.Object, leak.Stack)
+}
+
+// resource is implemented by all types of this package.
+type resource interface {
+	base() *object
+}
+
+// object holds the state that is common to all resource-owning types.
+type object struct {
+	mutex   sync.Mutex
+	closed  bool
+	name    string
+	release func() error
+	stack   []byte
+}
+
+func (obj *object) base() *object {
+	return obj
+}
+
+// initObject prepares the common state of a new resource and starts tracking it in debug builds.
+func initObject(res resource, kind string, handle fmt.Stringer, release func() error) {
+	obj := res.base()
+	obj.name = kind + " " + handle.String()
+	obj.release = release
+	trackAllocation(res)
+}
+
+// close releases the object once. Later calls return nil.
+func (obj *object) close() error {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	if obj.closed {
+		return nil
+	}
+	obj.closed = true
+	return obj.release()
+}
+
+func (obj *object) isClosed() bool {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+	return obj.closed
+}
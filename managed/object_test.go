@@ -0,0 +1,107 @@
+package managed_test
+
+import (
+	"errors"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+	"github.com/opencl-go/cl12/managed"
+)
+
+func TestKernelReferencesProgram(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := managed.CreateProgramWithSource(context, []string{"__kernel void add(__global float *a) {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	if err = cl.BuildProgram(program.Handle(), []cl.DeviceID{device}, "", nil); err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	kernel, err := managed.CreateKernel(program, "add")
+	if err != nil {
+		t.Fatalf("CreateKernel() failed: %v", err)
+	}
+	if (kernel.Program() != program) || (kernel.Program().Context() != context) {
+		t.Errorf("kernel does not reference its parents")
+	}
+	if err = program.Close(); err != nil {
+		t.Errorf("Close() of program failed: %v", err)
+	}
+	if err = kernel.Close(); err != nil {
+		t.Errorf("Close() of kernel failed: %v", err)
+	}
+}
+
+func TestCloseTwice(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	buffer, err := managed.CreateBuffer(context, cl.MemReadWriteFlag, 64, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer() failed: %v", err)
+	}
+	if err = buffer.Close(); err != nil {
+		t.Errorf("first Close() failed: %v", err)
+	}
+	if err = buffer.Close(); err != nil {
+		t.Errorf("second Close() failed: %v", err)
+	}
+}
+
+func TestEventOfCommand(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue, err := managed.CreateCommandQueue(context, device, 0)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue() failed: %v", err)
+	}
+	defer func() { _ = queue.Close() }()
+	var handle cl.Event
+	if err = cl.EnqueueMarkerWithWaitList(queue.Handle(), nil, &handle); err != nil {
+		t.Fatalf("EnqueueMarkerWithWaitList() failed: %v", err)
+	}
+	event := managed.NewEvent(queue, handle)
+	defer func() { _ = event.Close() }()
+	if (event.CommandQueue() != queue) || (event.Context() != context) {
+		t.Errorf("event does not reference its parents")
+	}
+	if err = event.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	_, err = cl.EventInfo(event.Handle(), cl.EventReferenceCountInfo, 0, nil)
+	if !errors.Is(err, cl.ErrInvalidEvent) {
+		t.Errorf("expected ErrInvalidEvent after Close(), got: %v", err)
+	}
+}
+
+func TestBindKernelArgsWithMemObject(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := managed.CreateProgramWithSource(context, []string{"__kernel void add(__global float *a) {}"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = program.Close() }()
+	if err = cl.BuildProgram(program.Handle(), []cl.DeviceID{device}, "-cl-kernel-arg-info", nil); err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	kernel, err := managed.CreateKernel(program, "add")
+	if err != nil {
+		t.Fatalf("CreateKernel() failed: %v", err)
+	}
+	defer func() { _ = kernel.Close() }()
+	buffer, err := managed.CreateBuffer(context, cl.MemReadWriteFlag, 64, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer() failed: %v", err)
+	}
+	defer func() { _ = buffer.Close() }()
+	args := struct {
+		A *managed.MemObject `cl:"a"`
+	}{A: buffer}
+	if err = cl.BindKernelArgs(kernel.Handle(), args); err != nil {
+		t.Errorf("BindKernelArgs() failed: %v", err)
+	}
+	if err = cl.SetKernelArgChecked(kernel.Handle(), 0, buffer); err != nil {
+		t.Errorf("SetKernelArgChecked() failed: %v", err)
+	}
+}
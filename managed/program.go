@@ -0,0 +1,80 @@
+package managed
+
+import (
+	"io"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// Program owns a cl.Program and references its context.
+type Program struct {
+	object
+	handle  cl.Program
+	context *Context
+}
+
+var _ io.Closer = (*Program)(nil)
+
+// CreateProgramWithSource creates a program object from source code, see cl.CreateProgramWithSource().
+func CreateProgramWithSource(context *Context, sources []string) (*Program, error) {
+	handle, err := cl.CreateProgramWithSource(context.Handle(), sources)
+	if err != nil {
+		return nil, err
+	}
+	return newProgram(handle, context), nil
+}
+
+// CreateProgramWithBinary creates a program object from binaries, see cl.CreateProgramWithBinary().
+func CreateProgramWithBinary(context *Context, devices []cl.DeviceID, binaries [][]byte) (*Program, []error, error) {
+	handle, binaryErr, err := cl.CreateProgramWithBinary(context.Handle(), devices, binaries)
+	if err != nil {
+		return nil, binaryErr, err
+	}
+	return newProgram(handle, context), binaryErr, nil
+}
+
+// CreateProgramWithBuiltInKernels creates a program object from built-in kernels,
+// see cl.CreateProgramWithBuiltInKernels().
+func CreateProgramWithBuiltInKernels(context *Context, devices []cl.DeviceID, kernelNames string) (*Program, error) {
+	handle, err := cl.CreateProgramWithBuiltInKernels(context.Handle(), devices, kernelNames)
+	if err != nil {
+		return nil, err
+	}
+	return newProgram(handle, context), nil
+}
+
+// LinkProgram links compiled programs into a new program object, see cl.LinkProgram().
+// The function waits for the link to complete. If the link fails, the error is a *cl.ProgramBuildError with the
+// build logs of the failing devices, as returned by cl.LinkProgram().
+func LinkProgram(context *Context, devices []cl.DeviceID, options string, programs []*Program) (*Program, error) {
+	handles := make([]cl.Program, len(programs))
+	for i, program := range programs {
+		handles[i] = program.Handle()
+	}
+	handle, err := cl.LinkProgram(context.Handle(), devices, options, handles, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newProgram(handle, context), nil
+}
+
+func newProgram(handle cl.Program, context *Context) *Program {
+	program := &Program{handle: handle, context: context}
+	initObject(program, "Program", handle, func() error { return cl.ReleaseProgram(handle) })
+	return program
+}
+
+// Handle returns the underlying handle. The handle is invalid after the program was closed.
+func (program *Program) Handle() cl.Program {
+	return program.handle
+}
+
+// Context returns the context the program was created for.
+func (program *Program) Context() *Context {
+	return program.context
+}
+
+// Close releases the program. Only the first call has an effect.
+func (program *Program) Close() error {
+	return program.close()
+}
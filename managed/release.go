@@ -0,0 +1,8 @@
+//go:build !cl12debug
+
+package managed
+
+// LeakDetectionEnabled is true in debug builds, which report objects that were not closed.
+const LeakDetectionEnabled = false
+
+func trackAllocation(resource) {}
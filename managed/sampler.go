@@ -0,0 +1,43 @@
+package managed
+
+import (
+	"io"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// Sampler owns a cl.Sampler and references its context.
+type Sampler struct {
+	object
+	handle  cl.Sampler
+	context *Context
+}
+
+var _ io.Closer = (*Sampler)(nil)
+
+// CreateSampler creates a sampler object, see cl.CreateSampler().
+func CreateSampler(context *Context, normalizedCoords bool, addressingMode cl.SamplerAddressingMode,
+	filterMode cl.SamplerFilterMode) (*Sampler, error) {
+	handle, err := cl.CreateSampler(context.Handle(), normalizedCoords, addressingMode, filterMode)
+	if err != nil {
+		return nil, err
+	}
+	sampler := &Sampler{handle: handle, context: context}
+	initObject(sampler, "Sampler", handle, func() error { return cl.ReleaseSampler(handle) })
+	return sampler, nil
+}
+
+// Handle returns the underlying handle. The handle is invalid after the sampler was closed.
+func (sampler *Sampler) Handle() cl.Sampler {
+	return sampler.handle
+}
+
+// Context returns the context the sampler was created for.
+func (sampler *Sampler) Context() *Context {
+	return sampler.context
+}
+
+// Close releases the sampler. Only the first call has an effect.
+func (sampler *Sampler) Close() error {
+	return sampler.close()
+}
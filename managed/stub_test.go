@@ -0,0 +1,59 @@
+package managed_test
+
+import (
+	"os"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+	"github.com/opencl-go/cl12/internal/stubicd"
+	"github.com/opencl-go/cl12/managed"
+)
+
+var stubLibrary struct {
+	err error
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "cl12-managed-stubicd-")
+	if err != nil {
+		stubLibrary.err = err
+		return m.Run()
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	path, err := stubicd.Build(dir)
+	if err == nil {
+		err = cl.LoadLibrary(path)
+	}
+	stubLibrary.err = err
+	return m.Run()
+}
+
+// stubContext creates a context on the device of the stub library, which is closed at the end of the test.
+// Without the stub library, the test is skipped, or fails in CI.
+func stubContext(t *testing.T) (*managed.Context, cl.DeviceID) {
+	t.Helper()
+	if stubLibrary.err != nil {
+		if os.Getenv("CI") != "" {
+			t.Fatalf("stub library not available: %v", stubLibrary.err)
+		}
+		t.Skipf("stub library not available: %v", stubLibrary.err)
+	}
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs() failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs() failed: %v", err)
+	}
+	context, err := managed.CreateContext(devices[:1], nil, cl.OnPlatform(platforms[0]))
+	if err != nil {
+		t.Fatalf("CreateContext() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = context.Close() })
+	return context, devices[0]
+}
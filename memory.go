@@ -29,8 +29,9 @@ func (mem MemObject) String() string {
 func RetainMemObject(mem MemObject) error {
 	status := C.clRetainMemObject(mem.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainMemObject", StatusError(status), mem)
 	}
+	trackRetained("clRetainMemObject", mem)
 	return nil
 }
 
@@ -44,8 +45,9 @@ func RetainMemObject(mem MemObject) error {
 func ReleaseMemObject(mem MemObject) error {
 	status := C.clReleaseMemObject(mem.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseMemObject", StatusError(status), mem)
 	}
+	trackReleased("clReleaseMemObject", mem)
 	return nil
 }
 
@@ -59,12 +61,12 @@ func ReleaseMemObject(mem MemObject) error {
 func SetMemObjectDestructorCallback(mem MemObject, callback func()) error {
 	callbackUserData, err := userDataFor(callback)
 	if err != nil {
-		return err
+		return newOpError("clSetMemObjectDestructorCallback", err, mem)
 	}
 	status := C.cl12SetMemObjectDestructorCallback(mem.handle(), callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
 		callbackUserData.Delete()
-		return StatusError(status)
+		return newOpError("clSetMemObjectDestructorCallback", StatusError(status), mem)
 	}
 	return nil
 }
@@ -182,11 +184,22 @@ func MemObjectInfo(mem MemObject, paramName MemObjectInfoName, paramSize uintptr
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetMemObjectInfo", StatusError(status), mem)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// MemObjectInfoQuery returns the query of MemObjectInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func MemObjectInfoQuery(mem MemObject, paramName MemObjectInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetMemObjectInfo",
+		handles:  []any{mem},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return MemObjectInfo(mem, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // MapFlags describe how a memory object shall be mapped into host memory.
 type MapFlags C.cl_map_flags
 
@@ -224,8 +237,9 @@ func EnqueueUnmapMemObject(commandQueue CommandQueue, mem MemObject, mappedPtr u
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueUnmapMemObject", StatusError(status), commandQueue, mem)
 	}
+	trackEnqueuedEvent("clEnqueueUnmapMemObject", event)
 	return nil
 }
 
@@ -276,7 +290,8 @@ func EnqueueMigrateMemObjects(commandQueue CommandQueue, memObjects []MemObject,
 		(*C.cl_event)(rawWaitList),
 		(*C.cl_event)(unsafe.Pointer(event)))
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clEnqueueMigrateMemObjects", StatusError(status), commandQueue)
 	}
+	trackEnqueuedEvent("clEnqueueMigrateMemObjects", event)
 	return nil
 }
@@ -1,7 +1,5 @@
 package cl12
 
-// #cgo !darwin LDFLAGS: -lOpenCL
-// #cgo darwin LDFLAGS: -framework OpenCL
 // #include "api.h"
 import "C"
 import (
@@ -27,12 +25,14 @@ func (id PlatformID) String() string {
 
 // PlatformIDs returns the list of available platforms on the system.
 //
+// If the OpenCL library is not available, the function returns ErrLibraryNotAvailable. See LoadLibrary().
+//
 // See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clGetPlatformIDs.html
 func PlatformIDs() ([]PlatformID, error) {
 	count := C.cl_uint(0)
 	status := C.clGetPlatformIDs(0, nil, &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clGetPlatformIDs", StatusError(status))
 	}
 	if count == 0 {
 		return nil, nil
@@ -40,7 +40,7 @@ func PlatformIDs() ([]PlatformID, error) {
 	ids := make([]PlatformID, count)
 	status = C.clGetPlatformIDs(count, (*C.cl_platform_id)(unsafe.Pointer(&ids[0])), &count)
 	if status != C.CL_SUCCESS {
-		return nil, StatusError(status)
+		return nil, newOpError("clGetPlatformIDs", StatusError(status))
 	}
 	return ids[:count], nil
 }
@@ -103,19 +103,28 @@ func PlatformInfo(id PlatformID, paramName PlatformInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetPlatformInfo", StatusError(status), id)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// PlatformInfoQuery returns the query of PlatformInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func PlatformInfoQuery(id PlatformID, paramName PlatformInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetPlatformInfo",
+		handles:  []any{id},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return PlatformInfo(id, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // PlatformInfoString is a convenience method for PlatformInfo() to query information values that are string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func PlatformInfoString(id PlatformID, paramName PlatformInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return PlatformInfo(id, paramName, paramSize, paramValue)
-	})
+	return queryString(PlatformInfoQuery(id, paramName))
 }
 
 // ExtensionFunctionAddressForPlatform returns the address of the extension function named by functionName
@@ -152,7 +161,7 @@ func ExtensionFunctionAddressForPlatform(id PlatformID, functionName string) uns
 func UnloadPlatformCompiler(id PlatformID) error {
 	status := C.clUnloadPlatformCompiler(id.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clUnloadPlatformCompiler", StatusError(status), id)
 	}
 	return nil
 }
@@ -0,0 +1,19 @@
+package cl12_test
+
+import (
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestPlatformInfoString(t *testing.T) {
+	t.Parallel()
+	platform, _ := stubDevice(t)
+	name, err := cl.PlatformInfoString(platform, cl.PlatformNameInfo)
+	if err != nil {
+		t.Fatalf("PlatformInfoString() failed: %v", err)
+	}
+	if name != "cl12 stub platform" {
+		t.Errorf("unexpected platform name: %q", name)
+	}
+}
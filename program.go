@@ -19,6 +19,7 @@ package cl12
 import "C"
 import (
 	"fmt"
+	"sort"
 	"unsafe"
 )
 
@@ -57,9 +58,11 @@ func CreateProgramWithSource(context Context, sources []string) (Program, error)
 		nil,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateProgramWithSource", StatusError(status), context)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	created := Program(*((*uintptr)(unsafe.Pointer(&program))))
+	trackCreated("clCreateProgramWithSource", created)
+	return created, nil
 }
 
 // CreateProgramWithBinary creates a program object for a context, and loads binary bits into the program object.
@@ -68,10 +71,21 @@ func CreateProgramWithSource(context Context, sources []string) (Program, error)
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clCreateProgramWithBinary.html
 func CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]byte) (Program, []error, error) {
-	rawBinaries := make([]*C.uchar, len(binaries))
+	// The binaries are copied to C memory, as the array of pointers must not point to Go memory.
+	rawPointers := C.calloc(C.size_t(len(binaries)), C.size_t(unsafe.Sizeof(uintptr(0))))
+	if rawPointers == nil {
+		return 0, nil, newOpError("clCreateProgramWithBinary", ErrOutOfMemory, context)
+	}
+	defer C.free(rawPointers)
+	rawBinaries := unsafe.Slice((*unsafe.Pointer)(rawPointers), len(binaries))
+	defer func() {
+		for _, rawBinary := range rawBinaries {
+			C.free(rawBinary)
+		}
+	}()
 	binaryLengths := make([]C.size_t, len(binaries))
 	for i := 0; i < len(binaries); i++ {
-		rawBinaries[i] = (*C.uchar)(unsafe.Pointer(&binaries[i][0]))
+		rawBinaries[i] = C.CBytes(binaries[i])
 		binaryLengths[i] = C.size_t(len(binaries[i]))
 	}
 	binaryStatus := make([]C.cl_int, len(devices))
@@ -81,7 +95,7 @@ func CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]b
 		C.cl_uint(len(devices)),
 		(*C.cl_device_id)(unsafe.Pointer(&devices[0])),
 		(*C.size_t)(unsafe.Pointer(&binaryLengths[0])),
-		(**C.uchar)(unsafe.Pointer(&rawBinaries[0])),
+		(**C.uchar)(rawPointers),
 		(*C.cl_int)(unsafe.Pointer(&binaryStatus[0])),
 		&status)
 	binaryErr := make([]error, len(devices))
@@ -91,9 +105,11 @@ func CreateProgramWithBinary(context Context, devices []DeviceID, binaries [][]b
 		}
 	}
 	if status != C.CL_SUCCESS {
-		return 0, binaryErr, StatusError(status)
+		return 0, binaryErr, newOpError("clCreateProgramWithBinary", StatusError(status), context)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), binaryErr, nil
+	created := Program(*((*uintptr)(unsafe.Pointer(&program))))
+	trackCreated("clCreateProgramWithBinary", created)
+	return created, binaryErr, nil
 }
 
 // CreateProgramWithBuiltInKernels creates a program object for a context, and loads the information related to the
@@ -112,9 +128,11 @@ func CreateProgramWithBuiltInKernels(context Context, devices []DeviceID, kernel
 		rawKernelNames,
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateProgramWithBuiltInKernels", StatusError(status), context, kernelNames)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	created := Program(*((*uintptr)(unsafe.Pointer(&program))))
+	trackCreated("clCreateProgramWithBuiltInKernels", created)
+	return created, nil
 }
 
 // RetainProgram increments the program reference count.
@@ -123,8 +141,9 @@ func CreateProgramWithBuiltInKernels(context Context, devices []DeviceID, kernel
 func RetainProgram(program Program) error {
 	status := C.clRetainProgram(program.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainProgram", StatusError(status), program)
 	}
+	trackRetained("clRetainProgram", program)
 	return nil
 }
 
@@ -137,8 +156,9 @@ func RetainProgram(program Program) error {
 func ReleaseProgram(program Program) error {
 	status := C.clReleaseProgram(program.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseProgram", StatusError(status), program)
 	}
+	trackReleased("clReleaseProgram", program)
 	return nil
 }
 
@@ -154,6 +174,8 @@ func ReleaseProgram(program Program) error {
 // If callback is nil, BuildProgram() does not return until the build has completed.
 // This callback function may be called asynchronously by the OpenCL implementation. It is the applications
 // responsibility to ensure that the callback function is thread-safe.
+// The callback is called for a build that began, even if BuildProgram() returns ErrBuildProgramFailure; it is not
+// called for errors that prevent the build from beginning.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clBuildProgram.html
 func BuildProgram(program Program, devices []DeviceID, options string, callback func()) error {
@@ -168,7 +190,7 @@ func BuildProgram(program Program, devices []DeviceID, options string, callback
 		var err error
 		callbackUserData, err = userDataFor(callback)
 		if err != nil {
-			return err
+			return newOpError("clBuildProgram", err, program)
 		}
 	}
 	status := C.cl12BuildProgram(
@@ -178,8 +200,12 @@ func BuildProgram(program Program, devices []DeviceID, options string, callback
 		rawOptions,
 		callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
-		callbackUserData.Delete()
-		return StatusError(status)
+		if status != C.CL_BUILD_PROGRAM_FAILURE {
+			// The build did not begin, so the callback is not called, and the user data must be deleted here.
+			// Once the build began, the callback owns the user data, and may be called on another thread.
+			callbackUserData.Delete()
+		}
+		return newOpError("clBuildProgram", StatusError(status), program)
 	}
 	return nil
 }
@@ -215,7 +241,7 @@ func CompileProgram(program Program, devices []DeviceID, options string, headers
 		var err error
 		callbackUserData, err = userDataFor(callback)
 		if err != nil {
-			return err
+			return newOpError("clCompileProgram", err, program)
 		}
 	}
 	var rawHeaderProgramsPtr unsafe.Pointer
@@ -245,8 +271,11 @@ func CompileProgram(program Program, devices []DeviceID, options string, headers
 		(**C.char)(rawHeaderNamesPtr),
 		callbackUserData.ptr)
 	if status != C.CL_SUCCESS {
-		callbackUserData.Delete()
-		return StatusError(status)
+		if status != C.CL_COMPILE_PROGRAM_FAILURE {
+			// The compilation did not begin, so the callback is not called; see BuildProgram().
+			callbackUserData.Delete()
+		}
+		return newOpError("clCompileProgram", StatusError(status), program)
 	}
 	return nil
 }
@@ -267,6 +296,15 @@ func cl12GoProgramCompileCallback(_ Program, userData *C.uintptr_t) {
 // If callback is not nil, LinkProgram() does not have to wait until the linker to complete and can return
 // if the linking operation can begin.
 //
+// LinkProgram() never returns a program together with an error. This is the convention for all functions of this
+// package that create and build a program, such as ProgramCache.BuildProgram() and LinkProgramFS():
+// If the link fails after the program object was created, such as with ErrLinkProgramFailure, the program is
+// released. The returned error is then a *ProgramBuildError with the build logs of the failing devices,
+// see CollectBuildErrors().
+// If callback is not nil and the link fails with ErrLinkProgramFailure, the link began, and the program is passed to
+// the callback instead, which may be called before LinkProgram() returns. The callback then owns the program and
+// must release it; the returned error carries no build logs, which the callback can query instead.
+//
 // Since: 1.2
 // See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clLinkProgram.html
 func LinkProgram(context Context, devices []DeviceID, options string, programs []Program, callback func(Program)) (Program, error) {
@@ -281,7 +319,7 @@ func LinkProgram(context Context, devices []DeviceID, options string, programs [
 		var err error
 		callbackUserData, err = userDataFor(callback)
 		if err != nil {
-			return 0, err
+			return 0, newOpError("clLinkProgram", err, context)
 		}
 	}
 	var status C.cl_int
@@ -294,11 +332,25 @@ func LinkProgram(context Context, devices []DeviceID, options string, programs [
 		(*C.cl_program)(unsafe.Pointer(&programs[0])),
 		callbackUserData.ptr,
 		&status)
-	if status != C.CL_SUCCESS {
+	if (status != C.CL_SUCCESS) && (status != C.CL_LINK_PROGRAM_FAILURE) {
+		// The link did not begin, so the callback is not called; see BuildProgram().
 		callbackUserData.Delete()
-		return 0, StatusError(status)
 	}
-	return Program(*((*uintptr)(unsafe.Pointer(&program)))), nil
+	if (status != C.CL_SUCCESS) && (program == nil) {
+		return 0, newOpError("clLinkProgram", StatusError(status), context)
+	}
+	if (status == C.CL_LINK_PROGRAM_FAILURE) && (callback != nil) {
+		// The program belongs to the callback, which may have released it already. It is therefore not tracked.
+		return 0, newOpError("clLinkProgram", StatusError(status), context)
+	}
+	created := Program(*((*uintptr)(unsafe.Pointer(&program))))
+	trackCreated("clLinkProgram", created)
+	if status != C.CL_SUCCESS {
+		err := CollectBuildErrors(created, devices, newOpError("clLinkProgram", StatusError(status), context))
+		_ = ReleaseProgram(created)
+		return 0, err
+	}
+	return created, nil
 }
 
 //export cl12GoProgramLinkCallback
@@ -399,20 +451,29 @@ func ProgramBuildInfo(program Program, device DeviceID, paramName ProgramBuildIn
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetProgramBuildInfo", StatusError(status), program, device)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// ProgramBuildInfoQuery returns the query of ProgramBuildInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func ProgramBuildInfoQuery(program Program, device DeviceID, paramName ProgramBuildInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetProgramBuildInfo",
+		handles:  []any{program, device},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ProgramBuildInfo(program, device, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // ProgramBuildInfoString is a convenience method for ProgramBuildInfo() to query information values that are
 // string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func ProgramBuildInfoString(program Program, device DeviceID, paramName ProgramBuildInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return ProgramBuildInfo(program, device, paramName, paramSize, paramValue)
-	})
+	return queryString(ProgramBuildInfoQuery(program, device, paramName))
 }
 
 // ProgramInfoName identifies properties of a program, which can be queried with ProgramInfo().
@@ -516,17 +577,127 @@ func ProgramInfo(program Program, paramName ProgramInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetProgramInfo", StatusError(status), program)
 	}
 	return uintptr(sizeReturn), nil
 }
 
+// ProgramInfoQuery returns the query of ProgramInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func ProgramInfoQuery(program Program, paramName ProgramInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetProgramInfo",
+		handles:  []any{program},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return ProgramInfo(program, paramName, paramSize, paramValue)
+		},
+	}
+}
+
 // ProgramInfoString is a convenience method for ProgramInfo() to query information values that are string-based.
 //
 // This function does not verify the queried information is indeed of type string. It assumes the information is
 // a NUL terminated raw string and will extract the bytes as characters before that.
 func ProgramInfoString(program Program, paramName ProgramInfoName) (string, error) {
-	return queryString(func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
-		return ProgramInfo(program, paramName, paramSize, paramValue)
-	})
+	return queryString(ProgramInfoQuery(program, paramName))
+}
+
+// ProgramBinaries returns the binaries of the program, for each associated device that has one.
+//
+// The function queries the sizes with ProgramBinarySizesInfo, provides one buffer per device for ProgramBinariesInfo,
+// and pairs the binaries with the devices of ProgramDevicesInfo.
+// Use CreateProgramWithBinaries() to create a program from the returned map.
+func ProgramBinaries(program Program) (map[DeviceID][]byte, error) {
+	devices, binaries, err := programBinaries(program)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[DeviceID][]byte, len(devices))
+	for i, device := range devices {
+		if len(binaries[i]) > 0 {
+			result[device] = binaries[i]
+		}
+	}
+	return result, nil
+}
+
+// CreateProgramWithBinaries creates a program object for a context with the binaries of the given devices, such as
+// those returned by ProgramBinaries(). See CreateProgramWithBinary().
+//
+// The returned map contains the load-status of each device that failed to load its binary.
+func CreateProgramWithBinaries(context Context, binaries map[DeviceID][]byte) (Program, map[DeviceID]error, error) {
+	devices := make([]DeviceID, 0, len(binaries))
+	for device := range binaries {
+		devices = append(devices, device)
+	}
+	if len(devices) == 0 {
+		return 0, nil, newOpError("clCreateProgramWithBinary", ErrInvalidValue, context)
+	}
+	sort.Slice(devices, func(a, b int) bool { return devices[a] < devices[b] })
+	deviceBinaries := make([][]byte, len(devices))
+	for i, device := range devices {
+		deviceBinaries[i] = binaries[device]
+	}
+	program, binaryErrors, err := CreateProgramWithBinary(context, devices, deviceBinaries)
+	var deviceErrors map[DeviceID]error
+	for i, binaryErr := range binaryErrors {
+		if binaryErr == nil {
+			continue
+		}
+		if deviceErrors == nil {
+			deviceErrors = make(map[DeviceID]error)
+		}
+		deviceErrors[devices[i]] = binaryErr
+	}
+	return program, deviceErrors, err
+}
+
+// programBinaries returns the devices associated with the program, and the binary for each of them.
+// The binary is nil for devices without one.
+func programBinaries(program Program) ([]DeviceID, [][]byte, error) {
+	devices, err := InfoSlice[DeviceID](ProgramInfoQuery(program, ProgramDevicesInfo))
+	if err != nil {
+		return nil, nil, err
+	}
+	sizes, err := InfoSlice[uintptr](ProgramInfoQuery(program, ProgramBinarySizesInfo))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(sizes) != len(devices) {
+		return nil, nil, newOpError("clGetProgramInfo", ErrInfoSizeMismatch, program)
+	}
+	if len(sizes) == 0 {
+		return devices, nil, nil
+	}
+	// The binaries are written to memory that the library allocates, as the pointers are passed in an array.
+	rawPointers := C.calloc(C.size_t(len(sizes)), C.size_t(unsafe.Sizeof(uintptr(0))))
+	if rawPointers == nil {
+		return nil, nil, newOpError("clGetProgramInfo", ErrOutOfMemory, program)
+	}
+	defer C.free(rawPointers)
+	rawBinaries := unsafe.Slice((*unsafe.Pointer)(rawPointers), len(sizes))
+	defer func() {
+		for _, rawBinary := range rawBinaries {
+			C.free(rawBinary)
+		}
+	}()
+	for i, size := range sizes {
+		if size == 0 {
+			continue
+		}
+		rawBinaries[i] = C.malloc(C.size_t(size))
+		if rawBinaries[i] == nil {
+			return nil, nil, newOpError("clGetProgramInfo", ErrOutOfMemory, program)
+		}
+	}
+	_, err = ProgramInfo(program, ProgramBinariesInfo, uintptr(len(sizes))*unsafe.Sizeof(uintptr(0)), rawPointers)
+	if err != nil {
+		return nil, nil, err
+	}
+	binaries := make([][]byte, len(sizes))
+	for i, size := range sizes {
+		if rawBinaries[i] != nil {
+			binaries[i] = C.GoBytes(rawBinaries[i], C.int(size))
+		}
+	}
+	return devices, binaries, nil
 }
@@ -0,0 +1,215 @@
+package cl12
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// programCacheMagic starts every entry of a ProgramCache. It is part of the keys as well, so that a change
+// of the format results in new entries.
+const programCacheMagic = "CL12BIN1"
+
+// ProgramCache keeps the binaries of built programs in a directory, so that later runs can skip building them
+// from source.
+//
+// The cache stores one entry per device. The key of an entry combines the hash of the sources, the build options,
+// the name, driver version, and OpenCL version of the device, as well as the name, vendor, and version of its
+// platform. An update of the driver therefore results in new entries; old entries are not removed.
+//
+// Entries are written to a temporary file first, which is then renamed. Concurrent processes that store the same
+// entry replace it as a whole. Each entry carries a checksum, and entries that do not match their checksum are
+// ignored.
+type ProgramCache struct {
+	// Dir is the directory of the entries. It is created when the first entry is stored.
+	Dir string
+}
+
+// BuildProgram returns a program for the sources that is built for the devices. If the devices are empty, the program
+// is built for all devices of the context.
+//
+// If the cache has an entry for every device, the program is created with CreateProgramWithBinaries(). If this fails,
+// or if the binary status of any device is an error, or if the build of the binaries fails, the program is built from
+// source and the entries are replaced.
+// Failures to read or write the cache are not reported, as the program is built from source instead.
+//
+// If the build from source fails, the program is released, and the error is the one of CollectBuildErrors(),
+// which includes the build logs. See LinkProgram() for this convention.
+func (cache ProgramCache) BuildProgram(context Context, devices []DeviceID, sources []string, options string) (Program, error) {
+	if len(devices) == 0 {
+		var err error
+		devices, err = InfoSlice[DeviceID](ContextInfoQuery(context, ContextDevicesInfo))
+		if err != nil {
+			return 0, err
+		}
+	}
+	keys := make([]string, len(devices))
+	sourceHash := programSourceHash(sources)
+	for i, device := range devices {
+		key, err := programCacheKey(device, sourceHash, options)
+		if err != nil {
+			return 0, err
+		}
+		keys[i] = key
+	}
+	if program, loaded := cache.load(context, devices, keys, options); loaded {
+		return program, nil
+	}
+	program, err := CreateProgramWithSource(context, sources)
+	if err != nil {
+		return 0, err
+	}
+	if err = BuildProgram(program, devices, options, nil); err != nil {
+		err = CollectBuildErrors(program, devices, err)
+		_ = ReleaseProgram(program)
+		return 0, err
+	}
+	cache.storeAll(program, devices, keys)
+	return program, nil
+}
+
+// load creates and builds the program from the entries of all devices.
+func (cache ProgramCache) load(context Context, devices []DeviceID, keys []string, options string) (Program, bool) {
+	binaries := make(map[DeviceID][]byte, len(keys))
+	for i, key := range keys {
+		entry, found := cache.read(key)
+		if !found {
+			return 0, false
+		}
+		binaries[devices[i]] = entry
+	}
+	program, binaryErrors, err := CreateProgramWithBinaries(context, binaries)
+	if err != nil {
+		return 0, false
+	}
+	if len(binaryErrors) > 0 {
+		_ = ReleaseProgram(program)
+		return 0, false
+	}
+	if err = BuildProgram(program, devices, options, nil); err != nil {
+		_ = ReleaseProgram(program)
+		return 0, false
+	}
+	return program, true
+}
+
+// storeAll writes the binaries of the program for the devices.
+func (cache ProgramCache) storeAll(program Program, devices []DeviceID, keys []string) {
+	binaries, err := ProgramBinaries(program)
+	if err != nil {
+		return
+	}
+	for i, device := range devices {
+		if binary, exists := binaries[device]; exists {
+			_ = cache.write(keys[i], binary)
+		}
+	}
+}
+
+func (cache ProgramCache) path(key string) string {
+	return filepath.Join(cache.Dir, key+".clbin")
+}
+
+// read returns the binary of an entry, if the entry exists and is intact.
+func (cache ProgramCache) read(key string) ([]byte, bool) {
+	data, err := os.ReadFile(cache.path(key))
+	if err != nil {
+		return nil, false
+	}
+	headerSize := len(programCacheMagic) + sha256.Size
+	if (len(data) <= headerSize) || !bytes.HasPrefix(data, []byte(programCacheMagic)) {
+		return nil, false
+	}
+	payload := data[headerSize:]
+	checksum := sha256.Sum256(payload)
+	if !bytes.Equal(checksum[:], data[len(programCacheMagic):headerSize]) {
+		return nil, false
+	}
+	return payload, true
+}
+
+// write stores the entry through a temporary file that replaces an existing entry.
+func (cache ProgramCache) write(key string, payload []byte) (err error) {
+	if err = os.MkdirAll(cache.Dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(cache.Dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+	}()
+	checksum := sha256.Sum256(payload)
+	for _, part := range [][]byte{[]byte(programCacheMagic), checksum[:], payload} {
+		if _, err = file.Write(part); err != nil {
+			return err
+		}
+	}
+	if err = file.Sync(); err != nil {
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(file.Name(), cache.path(key)); err != nil {
+		// Some systems do not replace files that are open; another process wrote the same entry.
+		_ = os.Remove(file.Name())
+		if _, statErr := os.Stat(cache.path(key)); statErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func programSourceHash(sources []string) []byte {
+	hash := sha256.New()
+	for _, source := range sources {
+		writeProgramCacheKeyPart(hash, source)
+	}
+	return hash.Sum(nil)
+}
+
+// programCacheKey returns the key of the entry for the device.
+func programCacheKey(device DeviceID, sourceHash []byte, options string) (string, error) {
+	hash := sha256.New()
+	writeProgramCacheKeyPart(hash, programCacheMagic)
+	writeProgramCacheKeyPart(hash, string(sourceHash))
+	writeProgramCacheKeyPart(hash, options)
+	for _, name := range []DeviceInfoName{DeviceNameInfo, DriverVersionInfo, DeviceVersionInfo} {
+		value, err := DeviceInfoString(device, name)
+		if err != nil {
+			return "", err
+		}
+		writeProgramCacheKeyPart(hash, value)
+	}
+	platform, err := InfoValue[PlatformID](DeviceInfoQuery(device, DevicePlatformInfo))
+	if err != nil {
+		return "", err
+	}
+	for _, name := range []PlatformInfoName{PlatformNameInfo, PlatformVendorInfo, PlatformVersionInfo} {
+		value, err := PlatformInfoString(platform, name)
+		if err != nil {
+			return "", err
+		}
+		writeProgramCacheKeyPart(hash, value)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeProgramCacheKeyPart writes the length of the value before the value, so that the parts of a key
+// can not be shifted against each other.
+func writeProgramCacheKeyPart(hash io.Writer, value string) {
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(value)))
+	_, _ = hash.Write(length[:])
+	_, _ = hash.Write([]byte(value))
+}
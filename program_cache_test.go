@@ -0,0 +1,140 @@
+package cl12_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+const cachedProgramSource = `__kernel void add(__global float *a, __global const float *b) {}`
+
+// buildCached builds the program through the cache, and returns whether it was loaded from a binary.
+func buildCached(t *testing.T, cache cl.ProgramCache, context cl.Context, options string) bool {
+	t.Helper()
+	program, err := cache.BuildProgram(context, nil, []string{cachedProgramSource}, options)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	source, err := cl.ProgramInfoString(program, cl.ProgramSourceInfo)
+	if err != nil {
+		t.Fatalf("ProgramInfoString() failed: %v", err)
+	}
+	return source == ""
+}
+
+// cacheEntries returns the paths of all files in the cache directory.
+func cacheEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatalf("Glob() failed: %v", err)
+	}
+	return entries
+}
+
+func TestProgramCache(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	cache := cl.ProgramCache{Dir: filepath.Join(t.TempDir(), "cache")}
+	if buildCached(t, cache, context, "") {
+		t.Errorf("first build loaded from empty cache")
+	}
+	if entries := cacheEntries(t, cache.Dir); len(entries) != 1 {
+		t.Fatalf("unexpected entries after first build: %v", entries)
+	}
+	if !buildCached(t, cache, context, "") {
+		t.Errorf("second build did not load from cache")
+	}
+	if buildCached(t, cache, context, "-cl-fast-relaxed-math") {
+		t.Errorf("build with other options loaded from cache")
+	}
+	if entries := cacheEntries(t, cache.Dir); len(entries) != 2 {
+		t.Errorf("unexpected entries after build with other options: %v", entries)
+	}
+}
+
+func TestProgramCacheFallback(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	cache := cl.ProgramCache{Dir: t.TempDir()}
+	buildCached(t, cache, context, "")
+	entry := cacheEntries(t, cache.Dir)[0]
+	original, err := os.ReadFile(entry)
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	// An intact entry that the driver rejects with an error in the binary status.
+	payload := []byte("not a binary")
+	checksum := sha256.Sum256(payload)
+	invalid := append(append([]byte("CL12BIN1"), checksum[:]...), payload...)
+	tt := []struct {
+		name string
+		data []byte
+	}{
+		{name: "truncated", data: original[:len(original)-1]},
+		{name: "invalid binary", data: invalid},
+	}
+	for _, tc := range tt {
+		if err = os.WriteFile(entry, tc.data, 0o600); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+		if buildCached(t, cache, context, "") {
+			t.Errorf("%s: loaded from cache", tc.name)
+		}
+		if !buildCached(t, cache, context, "") {
+			t.Errorf("%s: entry was not replaced", tc.name)
+		}
+	}
+}
+
+func TestProgramCacheConcurrentWrites(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	cache := cl.ProgramCache{Dir: t.TempDir()}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			program, err := cache.BuildProgram(context, nil, []string{cachedProgramSource}, "")
+			if err != nil {
+				t.Errorf("BuildProgram() failed: %v", err)
+				return
+			}
+			_ = cl.ReleaseProgram(program)
+		}()
+	}
+	wg.Wait()
+	entries := cacheEntries(t, cache.Dir)
+	if (len(entries) != 1) || strings.HasSuffix(entries[0], ".tmp") {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+	if !buildCached(t, cache, context, "") {
+		t.Errorf("build did not load from cache")
+	}
+}
+
+func TestProgramCacheBuildFailure(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	cache := cl.ProgramCache{Dir: t.TempDir()}
+	program, err := cache.BuildProgram(context, nil, []string{"// stub:build-fail\n// stub:build-log <kernel>:1:1: error: broken\n"}, "")
+	if program != 0 {
+		_ = cl.ReleaseProgram(program)
+		t.Errorf("BuildProgram() returned a program for a failed build")
+	}
+	var buildErr *cl.BuildError
+	if !errors.Is(err, cl.ErrBuildProgramFailure) || !errors.As(err, &buildErr) {
+		t.Errorf("expected a build error, got: %v", err)
+	}
+	if entries := cacheEntries(t, cache.Dir); len(entries) != 0 {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+}
@@ -0,0 +1,243 @@
+package cl12
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// includeDirective matches an #include directive with a quoted name. Includes with angle brackets refer to headers
+// of the implementation and are left to the compiler.
+var includeDirective = regexp.MustCompile(`^\s*#\s*include\s*"([^"]+)"`)
+
+// programFSFile is a source file of LinkProgramFS(), with the files it includes directly.
+type programFSFile struct {
+	source   string
+	includes []programFSInclude
+}
+
+type programFSInclude struct {
+	name string
+	path string
+	// position is the file and line of the directive, such as "kernels/main.cl:3".
+	position string
+}
+
+// programFSScan resolves the #include directives of the entry files and the files they include.
+type programFSScan struct {
+	fsys  fs.FS
+	files map[string]*programFSFile
+}
+
+// LinkProgramFS compiles the entry files of a file system and links them into a program executable for the devices.
+// If devices is empty, the program is built for all devices of the context.
+// The file system is typically an embed.FS, and paths are slash-separated, as described by fs.ValidPath().
+//
+// Files that are included with quotes, as in `#include "common.h"`, are looked up relative to the directory of the
+// including file first, and relative to the root of the file system second. The function creates one header program
+// per included file, and passes the headers of each entry file to CompileProgram(), under the name used in the
+// directive as well as the path in the file system. Conditional compilation is not evaluated; every directive
+// must refer to an existing file.
+//
+// Errors of the directives are reported with the position of the directive, such as
+// "kernels/main.cl:3: include file not found: \"missing.h\"". They wrap ErrIncludeNotFound for missing files,
+// ErrIncludeCycle if a file includes itself through other files, and ErrIncludeConflict if a name refers to
+// different files within the includes of one entry file. Entry files are separate compile units, so the same name
+// may refer to different files for different entries.
+// If a compilation or the link fails, the error is the one of CollectBuildErrors(), which includes the build logs.
+//
+// The compileOptions are passed to CompileProgram(), the linkOptions to LinkProgram().
+// The intermediate programs are released before the function returns.
+func LinkProgramFS(context Context, devices []DeviceID, fsys fs.FS, entries []string, compileOptions, linkOptions string) (Program, error) {
+	scan := programFSScan{fsys: fsys, files: make(map[string]*programFSFile)}
+	for _, entry := range entries {
+		if err := scan.visit(entry, nil); err != nil {
+			return 0, err
+		}
+	}
+	unitIncludes := make([][]programFSInclude, len(entries))
+	for i, entry := range entries {
+		includes, err := scan.unitIncludes(entry)
+		if err != nil {
+			return 0, err
+		}
+		unitIncludes[i] = includes
+	}
+	headerPrograms := make(map[string]Program)
+	defer func() {
+		for _, program := range headerPrograms {
+			_ = ReleaseProgram(program)
+		}
+	}()
+	for _, file := range scan.files {
+		for _, include := range file.includes {
+			if _, created := headerPrograms[include.path]; created {
+				continue
+			}
+			program, err := CreateProgramWithSource(context, []string{scan.files[include.path].source})
+			if err != nil {
+				return 0, err
+			}
+			headerPrograms[include.path] = program
+		}
+	}
+	var units []Program
+	defer func() {
+		for _, unit := range units {
+			_ = ReleaseProgram(unit)
+		}
+	}()
+	for i, entry := range entries {
+		unit, err := CreateProgramWithSource(context, []string{scan.files[entry].source})
+		if err != nil {
+			return 0, err
+		}
+		units = append(units, unit)
+		headers := make([]IncludeHeader, 0, len(unitIncludes[i]))
+		for _, include := range unitIncludes[i] {
+			headers = append(headers, IncludeHeader{Name: include.name, Program: headerPrograms[include.path]})
+		}
+		if err = CompileProgram(unit, devices, compileOptions, headers, nil); err != nil {
+			return 0, fmt.Errorf("%s: %w", entry, CollectBuildErrors(unit, devices, err))
+		}
+	}
+	return LinkProgram(context, devices, linkOptions, units, nil)
+}
+
+// visit reads the file and the files it includes. The stack holds the paths of the including files.
+func (scan *programFSScan) visit(filePath string, stack []string) error {
+	if _, visited := scan.files[filePath]; visited {
+		return nil
+	}
+	data, err := fs.ReadFile(scan.fsys, filePath)
+	if err != nil {
+		return err
+	}
+	file := &programFSFile{source: string(data)}
+	scan.files[filePath] = file
+	stack = append(stack, filePath)
+	for index, line := range strings.Split(stripComments(file.source), "\n") {
+		match := includeDirective.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		position := fmt.Sprintf("%s:%d", filePath, index+1)
+		include, err := scan.resolve(filePath, match[1], position)
+		if err != nil {
+			return fmt.Errorf("%s: %w", position, err)
+		}
+		for i, including := range stack {
+			if including == include.path {
+				cycle := append(append([]string{}, stack[i:]...), include.path)
+				return fmt.Errorf("%s: %w: %s", position, ErrIncludeCycle, strings.Join(cycle, " -> "))
+			}
+		}
+		file.includes = append(file.includes, include)
+		if err := scan.visit(include.path, stack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve looks up an included file relative to the including file, then relative to the root.
+func (scan *programFSScan) resolve(including, name, position string) (programFSInclude, error) {
+	for _, candidate := range []string{path.Join(path.Dir(including), name), path.Clean(name)} {
+		if !fs.ValidPath(candidate) {
+			continue
+		}
+		info, err := fs.Stat(scan.fsys, candidate)
+		if (err != nil) || info.IsDir() {
+			continue
+		}
+		return programFSInclude{name: name, path: candidate, position: position}, nil
+	}
+	return programFSInclude{}, fmt.Errorf("%w: %q", ErrIncludeNotFound, name)
+}
+
+// unitIncludes returns the headers of the compile unit of the entry: all files the entry includes, directly or
+// indirectly, under the name used in the directive as well as under their path. Within the unit, each name must
+// refer to the same file.
+func (scan *programFSScan) unitIncludes(entry string) ([]programFSInclude, error) {
+	var includes []programFSInclude
+	paths := make(map[string]string)
+	visited := map[string]bool{entry: true}
+	var add func(filePath string) error
+	add = func(filePath string) error {
+		for _, include := range scan.files[filePath].includes {
+			for _, name := range []string{include.name, include.path} {
+				other, exists := paths[name]
+				if exists && (other != include.path) {
+					return fmt.Errorf("%s: %w: %q refers to %s and %s", include.position, ErrIncludeConflict, name, other, include.path)
+				}
+				if !exists {
+					paths[name] = include.path
+					includes = append(includes, programFSInclude{name: name, path: include.path, position: include.position})
+				}
+			}
+			if !visited[include.path] {
+				visited[include.path] = true
+				if err := add(include.path); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := add(entry); err != nil {
+		return nil, err
+	}
+	return includes, nil
+}
+
+// stripComments replaces the comments of an OpenCL C source with spaces, keeping the line breaks, so that
+// commented directives are ignored and line numbers stay the same.
+func stripComments(source string) string {
+	var builder strings.Builder
+	builder.Grow(len(source))
+	inLine, inBlock, inString := false, false, false
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case inLine:
+			if c == '\n' {
+				inLine = false
+				builder.WriteByte(c)
+				continue
+			}
+			c = ' '
+		case inBlock:
+			if (c == '*') && (i+1 < len(source)) && (source[i+1] == '/') {
+				inBlock = false
+				builder.WriteString("  ")
+				i++
+				continue
+			}
+			if c != '\n' {
+				c = ' '
+			}
+		case inString:
+			if (c == '\\') && (i+1 < len(source)) {
+				builder.WriteByte(c)
+				i++
+				c = source[i]
+			} else if (c == '"') || (c == '\n') {
+				inString = false
+			}
+		case (c == '/') && (i+1 < len(source)) && (source[i+1] == '/'):
+			inLine = true
+			c = ' '
+		case (c == '/') && (i+1 < len(source)) && (source[i+1] == '*'):
+			inBlock = true
+			builder.WriteString("  ")
+			i++
+			continue
+		case c == '"':
+			inString = true
+		}
+		builder.WriteByte(c)
+	}
+	return builder.String()
+}
@@ -0,0 +1,137 @@
+package cl12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestLinkProgramFS(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	fsys := fstest.MapFS{
+		"kernels/main.cl": {Data: []byte(`// #include "commented.h"
+#include "common/math.h"
+#include <opencl-c.h>
+__kernel void scale(__global float *values, float factor) {}
+`)},
+		"kernels/reduce.cl": {Data: []byte(`#include "common/math.h"
+#include "config.h"
+__kernel void reduce(__global float *values, __local float *partial) {}
+`)},
+		"kernels/common/math.h": {Data: []byte(`#include "types.h"
+/* #include "missing.h" */
+`)},
+		"kernels/common/types.h": {Data: []byte(`typedef float real;`)},
+		"config.h":               {Data: []byte(`#define WIDTH 64`)},
+	}
+	program, err := cl.LinkProgramFS(context, nil, fsys, []string{"kernels/main.cl", "kernels/reduce.cl"}, "-cl-kernel-arg-info", "")
+	if err != nil {
+		t.Fatalf("LinkProgramFS() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	names, err := cl.ProgramInfoString(program, cl.ProgramKernelNamesInfo)
+	if err != nil {
+		t.Fatalf("ProgramInfoString() failed: %v", err)
+	}
+	if names != "scale;reduce" {
+		t.Errorf("unexpected kernel names: %q", names)
+	}
+}
+
+func TestLinkProgramFSSameNameInUnits(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	fsys := fstest.MapFS{
+		"a/x.cl":     {Data: []byte("#include \"common.h\"\n__kernel void x(__global float *values) {}\n")},
+		"a/common.h": {Data: []byte("typedef float real;")},
+		"b/y.cl":     {Data: []byte("#include \"common.h\"\n__kernel void y(__global int *values) {}\n")},
+		"b/common.h": {Data: []byte("typedef int real;")},
+	}
+	program, err := cl.LinkProgramFS(context, nil, fsys, []string{"a/x.cl", "b/y.cl"}, "", "")
+	if err != nil {
+		t.Fatalf("LinkProgramFS() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	names, err := cl.ProgramInfoString(program, cl.ProgramKernelNamesInfo)
+	if err != nil {
+		t.Fatalf("ProgramInfoString() failed: %v", err)
+	}
+	if names != "x;y" {
+		t.Errorf("unexpected kernel names: %q", names)
+	}
+}
+
+func TestLinkProgramFSErrors(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		expected error
+		text     string
+	}{
+		{
+			name: "missing",
+			fsys: fstest.MapFS{
+				"main.cl": {Data: []byte("#include \"a.h\"\n")},
+				"a.h":     {Data: []byte("\n\n  #  include \"missing.h\"\n")},
+			},
+			expected: cl.ErrIncludeNotFound,
+			text:     `a.h:3: include file not found: "missing.h"`,
+		},
+		{
+			name: "conflict",
+			fsys: fstest.MapFS{
+				"main.cl":     {Data: []byte("#include \"inc/a.h\"\n")},
+				"inc/a.h":     {Data: []byte("#include \"b.h\"\n")},
+				"inc/b.h":     {Data: []byte("// guard\n#include \"inc/a.h\"\n")},
+				"inc/inc/a.h": {Data: []byte("")},
+			},
+			expected: cl.ErrIncludeConflict,
+			text:     `inc/b.h:2: include name refers to different files: "inc/a.h" refers to inc/a.h and inc/inc/a.h`,
+		},
+		{
+			name: "cycle",
+			fsys: fstest.MapFS{
+				"main.cl": {Data: []byte("#include \"a.h\"\n")},
+				"a.h":     {Data: []byte("#include \"b.h\"\n")},
+				"b.h":     {Data: []byte("\n#include \"a.h\"\n")},
+			},
+			expected: cl.ErrIncludeCycle,
+			text:     `b.h:2: include cycle: a.h -> b.h -> a.h`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := cl.LinkProgramFS(context, nil, tc.fsys, []string{"main.cl"}, "", "")
+			if !errors.Is(err, tc.expected) {
+				t.Fatalf("expected %v, got: %v", tc.expected, err)
+			}
+			if err.Error() != tc.text {
+				t.Errorf("unexpected error text: %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestLinkProgramFSCompileFailure(t *testing.T) {
+	t.Parallel()
+	context, _ := stubContext(t)
+	fsys := fstest.MapFS{
+		"broken.cl": {Data: []byte("// stub:build-fail\n// stub:build-log <kernel>:3:1: error: expected expression\n")},
+	}
+	_, err := cl.LinkProgramFS(context, nil, fsys, []string{"broken.cl"}, "", "")
+	var buildErr *cl.BuildError
+	if !errors.As(err, &buildErr) || !errors.Is(err, cl.ErrCompileProgramFailure) {
+		t.Fatalf("expected a build error, got: %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "broken.cl: clCompileProgram(") || (len(buildErr.Errors()) != 1) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
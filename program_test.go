@@ -0,0 +1,144 @@
+package cl12_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestBuildProgram(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{`
+// stub:build-log warning: unused variable 'x'
+__kernel void add(__global float *a, __global const float *b) {}
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	called := make(chan struct{}, 1)
+	err = cl.BuildProgram(program, []cl.DeviceID{device}, "-cl-kernel-arg-info", func() { called <- struct{}{} })
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	<-called
+	log, err := cl.ProgramBuildInfoString(program, device, cl.ProgramBuildLogInfo)
+	if err != nil {
+		t.Fatalf("ProgramBuildInfoString() failed: %v", err)
+	}
+	if !strings.Contains(log, "unused variable 'x'") {
+		t.Errorf("unexpected build log: %q", log)
+	}
+	names, err := cl.ProgramInfoString(program, cl.ProgramKernelNamesInfo)
+	if err != nil {
+		t.Fatalf("ProgramInfoString() failed: %v", err)
+	}
+	if names != "add" {
+		t.Errorf("unexpected kernel names: %q", names)
+	}
+}
+
+func TestBuildProgramFailure(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{`
+// stub:build-fail
+// stub:build-log <source>:2:10: error: use of undeclared identifier 'y'
+__kernel void broken() { y; }
+`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgram(program, []cl.DeviceID{device}, "", nil)
+	if !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Fatalf("expected ErrBuildProgramFailure, got: %v", err)
+	}
+	var status cl.BuildStatus
+	_, err = cl.ProgramBuildInfo(program, device, cl.ProgramBuildStatusInfo, unsafe.Sizeof(status), unsafe.Pointer(&status))
+	if err != nil {
+		t.Fatalf("ProgramBuildInfo() failed: %v", err)
+	}
+	if status != cl.BuildErrorStatus {
+		t.Errorf("unexpected build status: %v", status)
+	}
+}
+
+func TestBuildProgramFailureCallbackOnThread(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{"// stub:build-fail\n// stub:notify-thread\n"})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	called := make(chan struct{}, 2)
+	err = cl.BuildProgram(program, []cl.DeviceID{device}, "", func() { called <- struct{}{} })
+	if !errors.Is(err, cl.ErrBuildProgramFailure) {
+		t.Fatalf("expected ErrBuildProgramFailure, got: %v", err)
+	}
+	<-called
+	err = cl.CompileProgram(program, []cl.DeviceID{device}, "", nil, func() { called <- struct{}{} })
+	if !errors.Is(err, cl.ErrCompileProgramFailure) {
+		t.Fatalf("expected ErrCompileProgramFailure, got: %v", err)
+	}
+	<-called
+	err = cl.BuildProgram(program, []cl.DeviceID{device}, "-invalid-option", func() { called <- struct{}{} })
+	if !errors.Is(err, cl.ErrInvalidBuildOptions) {
+		t.Fatalf("expected ErrInvalidBuildOptions, got: %v", err)
+	}
+	select {
+	case <-called:
+		t.Errorf("callback called for a build that did not begin")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestProgramBinaries(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	program, err := cl.CreateProgramWithSource(context, []string{`__kernel void copy(__global int *values) {}`})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(program) }()
+	err = cl.BuildProgram(program, nil, "", nil)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	binaries, err := cl.ProgramBinaries(program)
+	if err != nil {
+		t.Fatalf("ProgramBinaries() failed: %v", err)
+	}
+	if (len(binaries) != 1) || (len(binaries[device]) == 0) {
+		t.Fatalf("unexpected binaries: %v", binaries)
+	}
+	loaded, binaryErrors, err := cl.CreateProgramWithBinaries(context, binaries)
+	if err != nil {
+		t.Fatalf("CreateProgramWithBinaries() failed: %v", err)
+	}
+	defer func() { _ = cl.ReleaseProgram(loaded) }()
+	if len(binaryErrors) != 0 {
+		t.Errorf("unexpected binary errors: %v", binaryErrors)
+	}
+	err = cl.BuildProgram(loaded, nil, "", nil)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	names, err := cl.ProgramInfoString(loaded, cl.ProgramKernelNamesInfo)
+	if err != nil {
+		t.Fatalf("ProgramInfoString() failed: %v", err)
+	}
+	if names != "copy" {
+		t.Errorf("unexpected kernel names: %q", names)
+	}
+	_, binaryErrors, err = cl.CreateProgramWithBinaries(context, map[cl.DeviceID][]byte{device: []byte("invalid")})
+	if !errors.Is(err, cl.ErrInvalidBinary) || !errors.Is(binaryErrors[device], cl.ErrInvalidBinary) {
+		t.Errorf("expected ErrInvalidBinary, got: %v, %v", err, binaryErrors)
+	}
+}
@@ -62,9 +62,11 @@ func CreateSampler(context Context, normalizedCoords bool, addressingMode Sample
 		C.cl_filter_mode(filterMode),
 		&status)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clCreateSampler", StatusError(status), context)
 	}
-	return Sampler(*((*uintptr)(unsafe.Pointer(&sampler)))), nil
+	created := Sampler(*((*uintptr)(unsafe.Pointer(&sampler))))
+	trackCreated("clCreateSampler", created)
+	return created, nil
 }
 
 // RetainSampler increments the sampler reference count.
@@ -75,8 +77,9 @@ func CreateSampler(context Context, normalizedCoords bool, addressingMode Sample
 func RetainSampler(sampler Sampler) error {
 	status := C.clRetainSampler(sampler.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clRetainSampler", StatusError(status), sampler)
 	}
+	trackRetained("clRetainSampler", sampler)
 	return nil
 }
 
@@ -89,8 +92,9 @@ func RetainSampler(sampler Sampler) error {
 func ReleaseSampler(sampler Sampler) error {
 	status := C.clReleaseSampler(sampler.handle())
 	if status != C.CL_SUCCESS {
-		return StatusError(status)
+		return newOpError("clReleaseSampler", StatusError(status), sampler)
 	}
+	trackReleased("clReleaseSampler", sampler)
 	return nil
 }
 
@@ -134,7 +138,7 @@ const (
 // Raw strings are with a terminating NUL character.
 //
 // See also: https://registry.khronos.org/OpenCL/sdk/1.2/docs/man/xhtml/clGetSamplerInfo.html
-func SamplerInfo(sampler Sampler, paramName ContextInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+func SamplerInfo(sampler Sampler, paramName SamplerInfoName, paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
 	sizeReturn := C.size_t(0)
 	status := C.clGetSamplerInfo(
 		sampler.handle(),
@@ -143,7 +147,18 @@ func SamplerInfo(sampler Sampler, paramName ContextInfoName, paramSize uintptr,
 		paramValue,
 		&sizeReturn)
 	if status != C.CL_SUCCESS {
-		return 0, StatusError(status)
+		return 0, newOpError("clGetSamplerInfo", StatusError(status), sampler)
 	}
 	return uintptr(sizeReturn), nil
 }
+
+// SamplerInfoQuery returns the query of SamplerInfo() for the given parameter, to be used with InfoValue() or InfoSlice().
+func SamplerInfoQuery(sampler Sampler, paramName SamplerInfoName) InfoQuery {
+	return InfoQuery{
+		function: "clGetSamplerInfo",
+		handles:  []any{sampler},
+		load: func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error) {
+			return SamplerInfo(sampler, paramName, paramSize, paramValue)
+		},
+	}
+}
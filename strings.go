@@ -2,31 +2,28 @@ package cl12
 
 // #include "api.h"
 import "C"
-import (
-	"unsafe"
-)
 
-// queryString extracts a string with the help of a load function.
-// The load function shall return the required number of bytes for the string, including the terminating NUL byte.
-// The load function is called twice, once with zero/nil to query the needed size, then a second time to retrieve
+// queryString extracts a string with the help of a query.
+// The query shall return the required number of bytes for the string, including the terminating NUL byte.
+// The query is loaded twice, once with zero/nil to query the needed size, then a second time to retrieve
 // the value.
-func queryString(load func(paramSize uintptr, paramValue unsafe.Pointer) (uintptr, error)) (string, error) {
-	requiredSize, err := load(0, nil)
+func queryString(query InfoQuery) (string, error) {
+	requiredSize, err := query.load(0, nil)
 	if err != nil {
 		return "", err
 	}
-	if requiredSize > 1024*1024*10 {
-		return "", ErrDataSizeLimitExceeded
+	if requiredSize > infoSizeLimit {
+		return "", newOpError(query.function, ErrDataSizeLimitExceeded, query.handles...)
 	}
 	if requiredSize == 0 {
 		return "", nil
 	}
 	raw := C.calloc(C.size_t(requiredSize), 1)
 	if raw == nil {
-		return "", ErrOutOfMemory
+		return "", newOpError(query.function, ErrOutOfMemory, query.handles...)
 	}
 	defer C.free(raw)
-	returnedSize, err := load(requiredSize, raw)
+	returnedSize, err := query.load(requiredSize, raw)
 	if err != nil {
 		return "", err
 	}
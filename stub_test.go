@@ -0,0 +1,120 @@
+package cl12_test
+
+import (
+	"os"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+	"github.com/opencl-go/cl12/internal/stubicd"
+)
+
+// stubLibrary holds the result of preparing the stub OpenCL implementation from testdata/stubicd.
+// The tests of this package run against this stub, if it could be built and loaded.
+var stubLibrary struct {
+	err error
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "cl12-stubicd-")
+	if err != nil {
+		stubLibrary.err = err
+		return m.Run()
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	stubLibrary.err = loadStubLibrary(dir)
+	return m.Run()
+}
+
+// loadStubLibrary compiles the stub and loads it as OpenCL library.
+func loadStubLibrary(dir string) error {
+	path, err := stubicd.Build(dir)
+	if err != nil {
+		return err
+	}
+	return cl.LoadLibrary(path)
+}
+
+// requireStub skips the calling test if the stub library is not available. In CI, where the environment variable
+// CI is set, the test fails instead, so that a broken stub does not pass as a green run.
+func requireStub(t *testing.T) {
+	t.Helper()
+	if stubLibrary.err != nil {
+		if os.Getenv("CI") != "" {
+			t.Fatalf("stub library not available: %v", stubLibrary.err)
+		}
+		t.Skipf("stub library not available: %v", stubLibrary.err)
+	}
+}
+
+// stubDevice returns the platform and the single device of the stub library.
+func stubDevice(t *testing.T) (cl.PlatformID, cl.DeviceID) {
+	t.Helper()
+	requireStub(t)
+	platforms, err := cl.PlatformIDs()
+	if err != nil {
+		t.Fatalf("PlatformIDs() failed: %v", err)
+	}
+	devices, err := cl.DeviceIDs(platforms[0], cl.DeviceTypeAll)
+	if err != nil {
+		t.Fatalf("DeviceIDs() failed: %v", err)
+	}
+	return platforms[0], devices[0]
+}
+
+// stubContext creates a context on the stub device, which is released at the end of the test.
+func stubContext(t *testing.T) (cl.Context, cl.DeviceID) {
+	t.Helper()
+	platform, device := stubDevice(t)
+	context, err := cl.CreateContext([]cl.DeviceID{device}, nil, cl.OnPlatform(platform))
+	if err != nil {
+		t.Fatalf("CreateContext() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cl.ReleaseContext(context) })
+	return context, device
+}
+
+// stubCommandQueue creates a command queue on the stub device, which is released at the end of the test.
+func stubCommandQueue(t *testing.T, context cl.Context, device cl.DeviceID, properties cl.CommandQueuePropertiesFlags) cl.CommandQueue {
+	t.Helper()
+	queue, err := cl.CreateCommandQueue(context, device, properties)
+	if err != nil {
+		t.Fatalf("CreateCommandQueue() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cl.ReleaseCommandQueue(queue) })
+	return queue
+}
+
+// stubKernel builds the source with the given options and creates the named kernel.
+// Program and kernel are released at the end of the test.
+func stubKernel(t *testing.T, context cl.Context, source, options, name string) cl.Kernel {
+	t.Helper()
+	program, err := cl.CreateProgramWithSource(context, []string{source})
+	if err != nil {
+		t.Fatalf("CreateProgramWithSource() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cl.ReleaseProgram(program) })
+	err = cl.BuildProgram(program, nil, options, nil)
+	if err != nil {
+		t.Fatalf("BuildProgram() failed: %v", err)
+	}
+	kernel, err := cl.CreateKernel(program, name)
+	if err != nil {
+		t.Fatalf("CreateKernel() failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cl.ReleaseKernel(kernel) })
+	return kernel
+}
+
+// stubUserEvent creates a user event in the context. The caller releases it.
+func stubUserEvent(t *testing.T, context cl.Context) cl.Event {
+	t.Helper()
+	event, err := cl.CreateUserEvent(context)
+	if err != nil {
+		t.Fatalf("CreateUserEvent() failed: %v", err)
+	}
+	return event
+}
@@ -0,0 +1,3567 @@
+// stubicd is a minimal, fake OpenCL 1.2 implementation that is used by the tests of this module.
+//
+// It provides one platform with one CPU device. All commands are executed on the host, in the order they were
+// enqueued. Kernels written in OpenCL C are not executed; their signatures are parsed from the source so that
+// argument handling and information queries behave like in a real implementation.
+//
+// The behavior of programs can be scripted with directives in the program source:
+//
+//	// stub:build-log <text>              adds a line to the build log.
+//	// stub:build-fail                    lets the build, compilation, or link fail.
+//	// stub:link-fail                     lets only the link fail.
+//	// stub:kernel-status <name> <status> completes commands that execute the named kernel with the given status.
+//	// stub:notify-thread                 calls the notification of a build, compilation, or link on a separate
+//	//                                    thread, shortly after the call returned.
+//
+// Objects are never freed. Objects that have been released are only marked as such, so that any further use
+// is reported with the appropriate error.
+
+#define CL_TARGET_OPENCL_VERSION 120
+#ifdef __APPLE__
+#include <OpenCL/cl.h>
+#else
+#include <CL/cl.h>
+#endif
+
+#include <ctype.h>
+#include <pthread.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+#include <time.h>
+
+#define STUB_MAGIC_PLATFORM 0x504C4154u
+#define STUB_MAGIC_DEVICE 0x44455649u
+#define STUB_MAGIC_CONTEXT 0x434F4E54u
+#define STUB_MAGIC_QUEUE 0x51554555u
+#define STUB_MAGIC_MEM 0x4D454D4Fu
+#define STUB_MAGIC_SAMPLER 0x53414D50u
+#define STUB_MAGIC_PROGRAM 0x50524F47u
+#define STUB_MAGIC_KERNEL 0x4B45524Eu
+#define STUB_MAGIC_EVENT 0x4556454Eu
+
+#define STUB_BINARY_PREFIX "CL12STUB\n"
+#define STUB_MAX_ARGS 64
+
+struct _cl_platform_id
+{
+    cl_uint magic;
+};
+
+struct _cl_device_id
+{
+    cl_uint magic;
+};
+
+struct _cl_context
+{
+    cl_uint magic;
+    cl_uint refCount;
+    cl_context_properties *properties;
+    size_t propertiesCount;
+    void(CL_CALLBACK *notify)(char const *, void const *, size_t, void *);
+    void *notifyUserData;
+};
+
+struct _cl_command_queue
+{
+    cl_uint magic;
+    cl_uint refCount;
+    cl_context context;
+    cl_command_queue_properties properties;
+    cl_event lastEvent;
+    cl_uint pendingCount;
+};
+
+typedef struct stubDestructor
+{
+    void(CL_CALLBACK *notify)(cl_mem, void *);
+    void *userData;
+    struct stubDestructor *next;
+} stubDestructor;
+
+struct _cl_mem
+{
+    cl_uint magic;
+    cl_uint refCount;
+    cl_context context;
+    cl_mem_flags flags;
+    size_t size;
+    unsigned char *data;
+    void *hostPtr;
+    cl_mem parent;
+    size_t offset;
+    cl_uint mapCount;
+    stubDestructor *destructors;
+};
+
+struct _cl_sampler
+{
+    cl_uint magic;
+};
+
+typedef struct
+{
+    char name[128];
+    char typeName[128];
+    cl_kernel_arg_address_qualifier addressQualifier;
+    cl_kernel_arg_access_qualifier accessQualifier;
+    cl_kernel_arg_type_qualifier typeQualifier;
+    int isPointer;
+    int isMemObject;
+    int isSampler;
+    size_t size;
+} stubArgDef;
+
+typedef struct
+{
+    char name[128];
+    char attributes[256];
+    size_t requiredWorkGroupSize[3];
+    cl_uint argCount;
+    stubArgDef args[STUB_MAX_ARGS];
+    cl_int executionStatus;
+} stubKernelDef;
+
+struct _cl_program
+{
+    cl_uint magic;
+    cl_uint refCount;
+    cl_context context;
+    char *source;
+    int fromBinary;
+    cl_build_status buildStatus;
+    char *buildOptions;
+    char *buildLog;
+    cl_program_binary_type binaryType;
+    stubKernelDef *kernels;
+    cl_uint kernelCount;
+    cl_uint attachedKernels;
+    int argInfo;
+};
+
+struct _cl_kernel
+{
+    cl_uint magic;
+    cl_uint refCount;
+    cl_program program;
+    stubKernelDef *def;
+    int argInfo;
+    int argSet[STUB_MAX_ARGS];
+    size_t localSize[STUB_MAX_ARGS];
+};
+
+typedef struct stubEventCallback
+{
+    cl_int callbackType;
+    void(CL_CALLBACK *notify)(cl_event, cl_int, void *);
+    void *userData;
+    struct stubEventCallback *next;
+} stubEventCallback;
+
+typedef struct stubCommand stubCommand;
+
+struct _cl_event
+{
+    cl_uint magic;
+    cl_uint refCount;
+    cl_context context;
+    cl_command_queue queue;
+    cl_command_type commandType;
+    cl_int status;
+    cl_ulong times[4];
+    stubEventCallback *callbacks;
+};
+
+struct stubCommand
+{
+    cl_event event;
+    cl_uint waitCount;
+    cl_event *waitList;
+    cl_int (*run)(stubCommand *command);
+    cl_kernel kernel;
+    cl_mem src;
+    cl_mem dst;
+    size_t srcOffset;
+    size_t dstOffset;
+    size_t size;
+    void *ptr;
+    void const *constPtr;
+    size_t srcOrigin[3];
+    size_t dstOrigin[3];
+    size_t region[3];
+    size_t srcRowPitch;
+    size_t srcSlicePitch;
+    size_t dstRowPitch;
+    size_t dstSlicePitch;
+    unsigned char pattern[128];
+    size_t patternSize;
+    void(CL_CALLBACK *userFunc)(void *);
+    void *args;
+    stubCommand *next;
+};
+
+static struct _cl_platform_id stubPlatform = {STUB_MAGIC_PLATFORM};
+static struct _cl_device_id stubDevice = {STUB_MAGIC_DEVICE};
+
+static pthread_mutex_t stubMutex = PTHREAD_MUTEX_INITIALIZER;
+static pthread_cond_t stubCond = PTHREAD_COND_INITIALIZER;
+static stubCommand *stubPending;
+static int stubScheduling;
+
+static char const stubPlatformExtensions[] = "";
+static char const stubDeviceExtensions[] = "cl_khr_fp64 cl_khr_byte_addressable_store";
+
+// Helpers
+
+static cl_ulong stubNow(void)
+{
+    struct timespec now;
+    clock_gettime(CLOCK_MONOTONIC, &now);
+    return ((cl_ulong)(now.tv_sec) * 1000000000u) + (cl_ulong)(now.tv_nsec);
+}
+
+static cl_int stubInfo(void const *src, size_t size, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (paramValue != NULL)
+    {
+        if (paramValueSize < size)
+        {
+            return CL_INVALID_VALUE;
+        }
+        memcpy(paramValue, src, size);
+    }
+    if (paramValueSizeRet != NULL)
+    {
+        *paramValueSizeRet = size;
+    }
+    return CL_SUCCESS;
+}
+
+static cl_int stubInfoString(char const *text, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (text == NULL)
+    {
+        text = "";
+    }
+    return stubInfo(text, strlen(text) + 1, paramValueSize, paramValue, paramValueSizeRet);
+}
+
+#define STUB_INFO_VALUE(type, value) \
+    do \
+    { \
+        type stubValue = (value); \
+        return stubInfo(&stubValue, sizeof(stubValue), paramValueSize, paramValue, paramValueSizeRet); \
+    } while (0)
+
+static char *stubStrdup(char const *text)
+{
+    size_t length = strlen(text);
+    char *copy = malloc(length + 1);
+    memcpy(copy, text, length + 1);
+    return copy;
+}
+
+static void stubAppend(char **target, char const *text)
+{
+    size_t oldLength = (*target != NULL) ? strlen(*target) : 0;
+    size_t addLength = strlen(text);
+    char *result = realloc(*target, oldLength + addLength + 1);
+    memcpy(result + oldLength, text, addLength + 1);
+    *target = result;
+}
+
+static int stubValidContext(cl_context context)
+{
+    return (context != NULL) && (context->magic == STUB_MAGIC_CONTEXT) && (context->refCount > 0);
+}
+
+static int stubValidQueue(cl_command_queue queue)
+{
+    return (queue != NULL) && (queue->magic == STUB_MAGIC_QUEUE) && (queue->refCount > 0);
+}
+
+static int stubValidMem(cl_mem mem)
+{
+    return (mem != NULL) && (mem->magic == STUB_MAGIC_MEM) && (mem->refCount > 0);
+}
+
+static int stubValidProgram(cl_program program)
+{
+    return (program != NULL) && (program->magic == STUB_MAGIC_PROGRAM) && (program->refCount > 0);
+}
+
+static int stubValidKernel(cl_kernel kernel)
+{
+    return (kernel != NULL) && (kernel->magic == STUB_MAGIC_KERNEL) && (kernel->refCount > 0);
+}
+
+static int stubValidEvent(cl_event event)
+{
+    return (event != NULL) && (event->magic == STUB_MAGIC_EVENT) && (event->refCount > 0);
+}
+
+static cl_int stubCheckDevices(cl_uint numDevices, cl_device_id const *devices)
+{
+    cl_uint i;
+    if ((numDevices == 0) != (devices == NULL))
+    {
+        return CL_INVALID_VALUE;
+    }
+    for (i = 0; i < numDevices; i++)
+    {
+        if (devices[i] != &stubDevice)
+        {
+            return CL_INVALID_DEVICE;
+        }
+    }
+    return CL_SUCCESS;
+}
+
+static cl_int stubCheckWaitList(cl_context context, cl_uint numEvents, cl_event const *events)
+{
+    cl_uint i;
+    if ((numEvents == 0) != (events == NULL))
+    {
+        return CL_INVALID_EVENT_WAIT_LIST;
+    }
+    for (i = 0; i < numEvents; i++)
+    {
+        if (!stubValidEvent(events[i]))
+        {
+            return CL_INVALID_EVENT_WAIT_LIST;
+        }
+        if (events[i]->context != context)
+        {
+            return CL_INVALID_CONTEXT;
+        }
+    }
+    return CL_SUCCESS;
+}
+
+// Events and command execution
+
+static cl_event stubNewEvent(cl_context context, cl_command_queue queue, cl_command_type commandType, cl_int status)
+{
+    cl_event event = calloc(1, sizeof(struct _cl_event));
+    event->magic = STUB_MAGIC_EVENT;
+    event->refCount = 1;
+    event->context = context;
+    event->queue = queue;
+    event->commandType = commandType;
+    event->status = status;
+    return event;
+}
+
+static void stubRunReady(void);
+
+// stubSetEventStatus must be called without holding the mutex.
+static void stubSetEventStatus(cl_event event, cl_int status)
+{
+    stubEventCallback *due = NULL;
+    stubEventCallback **entry;
+    cl_ulong now = stubNow();
+
+    pthread_mutex_lock(&stubMutex);
+    event->status = status;
+    if (status <= CL_SUBMITTED)
+    {
+        event->times[1] = (event->times[1] == 0) ? now : event->times[1];
+    }
+    if (status <= CL_RUNNING)
+    {
+        event->times[2] = (event->times[2] == 0) ? now : event->times[2];
+    }
+    if (status <= CL_COMPLETE)
+    {
+        event->times[3] = now;
+    }
+    entry = &event->callbacks;
+    while (*entry != NULL)
+    {
+        stubEventCallback *callback = *entry;
+        if (status <= callback->callbackType)
+        {
+            *entry = callback->next;
+            callback->next = due;
+            due = callback;
+        }
+        else
+        {
+            entry = &callback->next;
+        }
+    }
+    pthread_cond_broadcast(&stubCond);
+    pthread_mutex_unlock(&stubMutex);
+
+    while (due != NULL)
+    {
+        stubEventCallback *callback = due;
+        due = callback->next;
+        callback->notify(event, (status < 0) ? status : callback->callbackType, callback->userData);
+        free(callback);
+    }
+    if ((status < 0) && (event->queue != NULL) && (event->context->notify != NULL))
+    {
+        char const *info = "stub: command terminated with an error";
+        event->context->notify(info, &status, sizeof(status), event->context->notifyUserData);
+    }
+    if (status <= CL_COMPLETE)
+    {
+        stubRunReady();
+    }
+}
+
+static int stubCommandReady(stubCommand *command, cl_int *failure)
+{
+    cl_uint i;
+    *failure = CL_SUCCESS;
+    for (i = 0; i < command->waitCount; i++)
+    {
+        cl_int status = command->waitList[i]->status;
+        if (status > CL_COMPLETE)
+        {
+            return 0;
+        }
+        if (status < 0)
+        {
+            *failure = CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST;
+        }
+    }
+    return 1;
+}
+
+static void stubRunReady(void)
+{
+    pthread_mutex_lock(&stubMutex);
+    if (stubScheduling)
+    {
+        pthread_mutex_unlock(&stubMutex);
+        return;
+    }
+    stubScheduling = 1;
+    for (;;)
+    {
+        stubCommand **entry = &stubPending;
+        stubCommand *command = NULL;
+        cl_int failure = CL_SUCCESS;
+        cl_int status;
+
+        while (*entry != NULL)
+        {
+            if (stubCommandReady(*entry, &failure))
+            {
+                command = *entry;
+                *entry = command->next;
+                break;
+            }
+            entry = &(*entry)->next;
+        }
+        if (command == NULL)
+        {
+            stubScheduling = 0;
+            pthread_mutex_unlock(&stubMutex);
+            return;
+        }
+        pthread_mutex_unlock(&stubMutex);
+
+        if (failure == CL_SUCCESS)
+        {
+            stubSetEventStatus(command->event, CL_RUNNING);
+            status = command->run(command);
+        }
+        else
+        {
+            status = failure;
+        }
+        free(command->waitList);
+        free(command->args);
+        stubSetEventStatus(command->event, status);
+
+        pthread_mutex_lock(&stubMutex);
+        command->event->queue->pendingCount--;
+        pthread_cond_broadcast(&stubCond);
+        free(command);
+    }
+}
+
+static stubCommand *stubNewCommand(void)
+{
+    return calloc(1, sizeof(stubCommand));
+}
+
+// stubEnqueue schedules the command on the queue. If blocking is set, the function waits for the command
+// to complete.
+static cl_int stubEnqueue(cl_command_queue queue, stubCommand *command, cl_command_type commandType,
+    cl_uint numEventsInWaitList, cl_event const *eventWaitList, cl_event *event, cl_bool blocking)
+{
+    cl_event created;
+    cl_int status;
+    cl_uint i;
+
+    status = stubCheckWaitList(queue->context, numEventsInWaitList, eventWaitList);
+    if (status != CL_SUCCESS)
+    {
+        free(command->args);
+        free(command);
+        return status;
+    }
+    created = stubNewEvent(queue->context, queue, commandType, CL_QUEUED);
+    created->times[0] = stubNow();
+    command->event = created;
+    command->waitList = calloc(numEventsInWaitList + 1, sizeof(cl_event));
+    for (i = 0; i < numEventsInWaitList; i++)
+    {
+        command->waitList[command->waitCount++] = eventWaitList[i];
+    }
+
+    pthread_mutex_lock(&stubMutex);
+    if (queue->lastEvent != NULL)
+    {
+        command->waitList[command->waitCount++] = queue->lastEvent;
+    }
+    queue->lastEvent = created;
+    queue->pendingCount++;
+    {
+        stubCommand **tail = &stubPending;
+        while (*tail != NULL)
+        {
+            tail = &(*tail)->next;
+        }
+        *tail = command;
+    }
+    pthread_mutex_unlock(&stubMutex);
+
+    stubSetEventStatus(created, CL_SUBMITTED);
+    stubRunReady();
+
+    if (blocking)
+    {
+        pthread_mutex_lock(&stubMutex);
+        while (created->status > CL_COMPLETE)
+        {
+            pthread_cond_wait(&stubCond, &stubMutex);
+        }
+        status = (created->status < 0) ? CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST : CL_SUCCESS;
+        pthread_mutex_unlock(&stubMutex);
+    }
+    if (event != NULL)
+    {
+        *event = created;
+    }
+    else
+    {
+        created->refCount = 0;
+    }
+    return status;
+}
+
+// Platform
+
+CL_API_ENTRY cl_int CL_API_CALL clGetPlatformIDs(cl_uint num_entries, cl_platform_id *platforms, cl_uint *num_platforms)
+{
+    if (((num_entries == 0) && (platforms != NULL)) || ((platforms == NULL) && (num_platforms == NULL)))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (platforms != NULL)
+    {
+        platforms[0] = &stubPlatform;
+    }
+    if (num_platforms != NULL)
+    {
+        *num_platforms = 1;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetPlatformInfo(cl_platform_id platform, cl_platform_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if ((platform != NULL) && (platform != &stubPlatform))
+    {
+        return CL_INVALID_PLATFORM;
+    }
+    switch (param_name)
+    {
+    case CL_PLATFORM_PROFILE:
+        return stubInfoString("FULL_PROFILE", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PLATFORM_VERSION:
+        return stubInfoString("OpenCL 1.2 stub", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PLATFORM_NAME:
+        return stubInfoString("cl12 stub platform", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PLATFORM_VENDOR:
+        return stubInfoString("opencl-go", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PLATFORM_EXTENSIONS:
+        return stubInfoString(stubPlatformExtensions, paramValueSize, paramValue, paramValueSizeRet);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY void *CL_API_CALL clGetExtensionFunctionAddressForPlatform(cl_platform_id platform, char const *func_name)
+{
+    (void)(platform);
+    (void)(func_name);
+    return NULL;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clUnloadPlatformCompiler(cl_platform_id platform)
+{
+    return (platform == &stubPlatform) ? CL_SUCCESS : CL_INVALID_PLATFORM;
+}
+
+// Device
+
+CL_API_ENTRY cl_int CL_API_CALL clGetDeviceIDs(cl_platform_id platform, cl_device_type device_type,
+    cl_uint num_entries, cl_device_id *devices, cl_uint *num_devices)
+{
+    cl_device_type const knownTypes = CL_DEVICE_TYPE_DEFAULT | CL_DEVICE_TYPE_CPU | CL_DEVICE_TYPE_GPU |
+        CL_DEVICE_TYPE_ACCELERATOR | CL_DEVICE_TYPE_CUSTOM;
+    if ((platform != NULL) && (platform != &stubPlatform))
+    {
+        return CL_INVALID_PLATFORM;
+    }
+    if ((device_type != CL_DEVICE_TYPE_ALL) && ((device_type & ~knownTypes) != 0))
+    {
+        return CL_INVALID_DEVICE_TYPE;
+    }
+    if (((num_entries == 0) && (devices != NULL)) || ((devices == NULL) && (num_devices == NULL)))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if ((device_type & (CL_DEVICE_TYPE_DEFAULT | CL_DEVICE_TYPE_CPU)) == 0)
+    {
+        return CL_DEVICE_NOT_FOUND;
+    }
+    if (devices != NULL)
+    {
+        devices[0] = &stubDevice;
+    }
+    if (num_devices != NULL)
+    {
+        *num_devices = 1;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetDeviceInfo(cl_device_id device, cl_device_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (device != &stubDevice)
+    {
+        return CL_INVALID_DEVICE;
+    }
+    switch (param_name)
+    {
+    case CL_DEVICE_TYPE:
+        STUB_INFO_VALUE(cl_device_type, CL_DEVICE_TYPE_CPU);
+    case CL_DEVICE_VENDOR_ID:
+        STUB_INFO_VALUE(cl_uint, 0x10DE5);
+    case CL_DEVICE_MAX_COMPUTE_UNITS:
+        STUB_INFO_VALUE(cl_uint, 4);
+    case CL_DEVICE_MAX_WORK_ITEM_DIMENSIONS:
+        STUB_INFO_VALUE(cl_uint, 3);
+    case CL_DEVICE_MAX_WORK_ITEM_SIZES:
+    {
+        size_t sizes[3] = {1024, 1024, 64};
+        return stubInfo(sizes, sizeof(sizes), paramValueSize, paramValue, paramValueSizeRet);
+    }
+    case CL_DEVICE_MAX_WORK_GROUP_SIZE:
+        STUB_INFO_VALUE(size_t, 1024);
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_CHAR:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_CHAR:
+        STUB_INFO_VALUE(cl_uint, 16);
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_SHORT:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_SHORT:
+        STUB_INFO_VALUE(cl_uint, 8);
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_INT:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_INT:
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_FLOAT:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_FLOAT:
+        STUB_INFO_VALUE(cl_uint, 4);
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_LONG:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_LONG:
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_DOUBLE:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_DOUBLE:
+        STUB_INFO_VALUE(cl_uint, 2);
+    case CL_DEVICE_PREFERRED_VECTOR_WIDTH_HALF:
+    case CL_DEVICE_NATIVE_VECTOR_WIDTH_HALF:
+        STUB_INFO_VALUE(cl_uint, 0);
+    case CL_DEVICE_MAX_CLOCK_FREQUENCY:
+        STUB_INFO_VALUE(cl_uint, 1000);
+    case CL_DEVICE_ADDRESS_BITS:
+        STUB_INFO_VALUE(cl_uint, (cl_uint)(sizeof(void *) * 8));
+    case CL_DEVICE_MAX_READ_IMAGE_ARGS:
+    case CL_DEVICE_MAX_WRITE_IMAGE_ARGS:
+    case CL_DEVICE_MAX_SAMPLERS:
+        STUB_INFO_VALUE(cl_uint, 0);
+    case CL_DEVICE_MAX_MEM_ALLOC_SIZE:
+        STUB_INFO_VALUE(cl_ulong, 256u * 1024u * 1024u);
+    case CL_DEVICE_IMAGE2D_MAX_WIDTH:
+    case CL_DEVICE_IMAGE2D_MAX_HEIGHT:
+    case CL_DEVICE_IMAGE3D_MAX_WIDTH:
+    case CL_DEVICE_IMAGE3D_MAX_HEIGHT:
+    case CL_DEVICE_IMAGE3D_MAX_DEPTH:
+    case CL_DEVICE_IMAGE_MAX_BUFFER_SIZE:
+    case CL_DEVICE_IMAGE_MAX_ARRAY_SIZE:
+        STUB_INFO_VALUE(size_t, 0);
+    case CL_DEVICE_IMAGE_SUPPORT:
+        STUB_INFO_VALUE(cl_bool, CL_FALSE);
+    case CL_DEVICE_MAX_PARAMETER_SIZE:
+        STUB_INFO_VALUE(size_t, 1024);
+    case CL_DEVICE_MEM_BASE_ADDR_ALIGN:
+        STUB_INFO_VALUE(cl_uint, 1024);
+    case CL_DEVICE_SINGLE_FP_CONFIG:
+        STUB_INFO_VALUE(cl_device_fp_config, CL_FP_DENORM | CL_FP_INF_NAN | CL_FP_ROUND_TO_NEAREST | CL_FP_FMA);
+    case CL_DEVICE_DOUBLE_FP_CONFIG:
+        STUB_INFO_VALUE(cl_device_fp_config, CL_FP_DENORM | CL_FP_INF_NAN | CL_FP_ROUND_TO_NEAREST |
+            CL_FP_ROUND_TO_ZERO | CL_FP_ROUND_TO_INF | CL_FP_FMA);
+    case CL_DEVICE_GLOBAL_MEM_CACHE_TYPE:
+        STUB_INFO_VALUE(cl_device_mem_cache_type, CL_READ_WRITE_CACHE);
+    case CL_DEVICE_GLOBAL_MEM_CACHELINE_SIZE:
+        STUB_INFO_VALUE(cl_uint, 64);
+    case CL_DEVICE_GLOBAL_MEM_CACHE_SIZE:
+        STUB_INFO_VALUE(cl_ulong, 32u * 1024u);
+    case CL_DEVICE_GLOBAL_MEM_SIZE:
+        STUB_INFO_VALUE(cl_ulong, 1024u * 1024u * 1024u);
+    case CL_DEVICE_MAX_CONSTANT_BUFFER_SIZE:
+        STUB_INFO_VALUE(cl_ulong, 64u * 1024u);
+    case CL_DEVICE_MAX_CONSTANT_ARGS:
+        STUB_INFO_VALUE(cl_uint, 8);
+    case CL_DEVICE_LOCAL_MEM_TYPE:
+        STUB_INFO_VALUE(cl_device_local_mem_type, CL_GLOBAL);
+    case CL_DEVICE_LOCAL_MEM_SIZE:
+        STUB_INFO_VALUE(cl_ulong, 32u * 1024u);
+    case CL_DEVICE_ERROR_CORRECTION_SUPPORT:
+    case CL_DEVICE_PREFERRED_INTEROP_USER_SYNC:
+        STUB_INFO_VALUE(cl_bool, CL_FALSE);
+    case CL_DEVICE_PROFILING_TIMER_RESOLUTION:
+        STUB_INFO_VALUE(size_t, 1);
+    case CL_DEVICE_ENDIAN_LITTLE:
+    case CL_DEVICE_AVAILABLE:
+    case CL_DEVICE_COMPILER_AVAILABLE:
+    case CL_DEVICE_LINKER_AVAILABLE:
+    case CL_DEVICE_HOST_UNIFIED_MEMORY:
+        STUB_INFO_VALUE(cl_bool, CL_TRUE);
+    case CL_DEVICE_EXECUTION_CAPABILITIES:
+        STUB_INFO_VALUE(cl_device_exec_capabilities, CL_EXEC_KERNEL | CL_EXEC_NATIVE_KERNEL);
+    case CL_DEVICE_QUEUE_PROPERTIES:
+        STUB_INFO_VALUE(cl_command_queue_properties, CL_QUEUE_OUT_OF_ORDER_EXEC_MODE_ENABLE | CL_QUEUE_PROFILING_ENABLE);
+    case CL_DEVICE_NAME:
+        return stubInfoString("cl12 stub CPU", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_VENDOR:
+        return stubInfoString("opencl-go", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DRIVER_VERSION:
+        return stubInfoString("1.0", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_PROFILE:
+        return stubInfoString("FULL_PROFILE", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_VERSION:
+        return stubInfoString("OpenCL 1.2 stub", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_OPENCL_C_VERSION:
+        return stubInfoString("OpenCL C 1.2 stub", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_EXTENSIONS:
+        return stubInfoString(stubDeviceExtensions, paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_BUILT_IN_KERNELS:
+        return stubInfoString("", paramValueSize, paramValue, paramValueSizeRet);
+    case CL_DEVICE_PLATFORM:
+        STUB_INFO_VALUE(cl_platform_id, &stubPlatform);
+    case CL_DEVICE_PARENT_DEVICE:
+        STUB_INFO_VALUE(cl_device_id, NULL);
+    case CL_DEVICE_PARTITION_MAX_SUB_DEVICES:
+        STUB_INFO_VALUE(cl_uint, 0);
+    case CL_DEVICE_PARTITION_PROPERTIES:
+    case CL_DEVICE_PARTITION_TYPE:
+        STUB_INFO_VALUE(cl_device_partition_property, 0);
+    case CL_DEVICE_PARTITION_AFFINITY_DOMAIN:
+        STUB_INFO_VALUE(cl_device_affinity_domain, 0);
+    case CL_DEVICE_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, 1);
+    default:
+        // CL_DEVICE_PRINTF_BUFFER_SIZE is deliberately not reported, as some drivers do, to cover incomplete devices.
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clCreateSubDevices(cl_device_id in_device, cl_device_partition_property const *properties,
+    cl_uint num_devices, cl_device_id *out_devices, cl_uint *num_devices_ret)
+{
+    (void)(properties);
+    (void)(num_devices);
+    (void)(out_devices);
+    (void)(num_devices_ret);
+    if (in_device != &stubDevice)
+    {
+        return CL_INVALID_DEVICE;
+    }
+    return CL_INVALID_VALUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainDevice(cl_device_id device)
+{
+    return (device == &stubDevice) ? CL_SUCCESS : CL_INVALID_DEVICE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseDevice(cl_device_id device)
+{
+    return (device == &stubDevice) ? CL_SUCCESS : CL_INVALID_DEVICE;
+}
+
+// Context
+
+static cl_context stubNewContext(cl_context_properties const *properties,
+    void(CL_CALLBACK *notify)(char const *, void const *, size_t, void *), void *userData, cl_int *errcodeRet)
+{
+    cl_context context;
+    size_t count = 0;
+    cl_int status = CL_SUCCESS;
+
+    if ((notify == NULL) && (userData != NULL))
+    {
+        status = CL_INVALID_VALUE;
+    }
+    while ((status == CL_SUCCESS) && (properties != NULL) && (properties[count] != 0))
+    {
+        switch (properties[count])
+        {
+        case CL_CONTEXT_PLATFORM:
+            if ((cl_platform_id)(properties[count + 1]) != &stubPlatform)
+            {
+                status = CL_INVALID_PLATFORM;
+            }
+            break;
+        case CL_CONTEXT_INTEROP_USER_SYNC:
+            break;
+        default:
+            status = CL_INVALID_PROPERTY;
+        }
+        count += 2;
+    }
+    if (status != CL_SUCCESS)
+    {
+        if (errcodeRet != NULL)
+        {
+            *errcodeRet = status;
+        }
+        return NULL;
+    }
+    context = calloc(1, sizeof(struct _cl_context));
+    context->magic = STUB_MAGIC_CONTEXT;
+    context->refCount = 1;
+    context->notify = notify;
+    context->notifyUserData = userData;
+    if (properties != NULL)
+    {
+        context->propertiesCount = count + 1;
+        context->properties = calloc(count + 1, sizeof(cl_context_properties));
+        memcpy(context->properties, properties, count * sizeof(cl_context_properties));
+    }
+    if (errcodeRet != NULL)
+    {
+        *errcodeRet = CL_SUCCESS;
+    }
+    return context;
+}
+
+CL_API_ENTRY cl_context CL_API_CALL clCreateContext(cl_context_properties const *properties,
+    cl_uint num_devices, cl_device_id const *devices,
+    void(CL_CALLBACK *pfn_notify)(char const *errinfo, void const *private_info, size_t cb, void *user_data),
+    void *user_data, cl_int *errcode_ret)
+{
+    cl_int status = ((num_devices == 0) || (devices == NULL)) ? CL_INVALID_VALUE : stubCheckDevices(num_devices, devices);
+    if (status != CL_SUCCESS)
+    {
+        if (errcode_ret != NULL)
+        {
+            *errcode_ret = status;
+        }
+        return NULL;
+    }
+    return stubNewContext(properties, pfn_notify, user_data, errcode_ret);
+}
+
+CL_API_ENTRY cl_context CL_API_CALL clCreateContextFromType(cl_context_properties const *properties,
+    cl_device_type device_type,
+    void(CL_CALLBACK *pfn_notify)(char const *errinfo, void const *private_info, size_t cb, void *user_data),
+    void *user_data, cl_int *errcode_ret)
+{
+    if ((device_type & (CL_DEVICE_TYPE_DEFAULT | CL_DEVICE_TYPE_CPU)) == 0)
+    {
+        if (errcode_ret != NULL)
+        {
+            *errcode_ret = CL_DEVICE_NOT_FOUND;
+        }
+        return NULL;
+    }
+    return stubNewContext(properties, pfn_notify, user_data, errcode_ret);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainContext(cl_context context)
+{
+    if (!stubValidContext(context))
+    {
+        return CL_INVALID_CONTEXT;
+    }
+    __atomic_add_fetch(&context->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseContext(cl_context context)
+{
+    if (!stubValidContext(context))
+    {
+        return CL_INVALID_CONTEXT;
+    }
+    __atomic_sub_fetch(&context->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetContextInfo(cl_context context, cl_context_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidContext(context))
+    {
+        return CL_INVALID_CONTEXT;
+    }
+    switch (param_name)
+    {
+    case CL_CONTEXT_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, context->refCount);
+    case CL_CONTEXT_DEVICES:
+        STUB_INFO_VALUE(cl_device_id, &stubDevice);
+    case CL_CONTEXT_NUM_DEVICES:
+        STUB_INFO_VALUE(cl_uint, 1);
+    case CL_CONTEXT_PROPERTIES:
+        return stubInfo(context->properties, context->propertiesCount * sizeof(cl_context_properties),
+            paramValueSize, paramValue, paramValueSizeRet);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+// Command queue
+
+CL_API_ENTRY cl_command_queue CL_API_CALL clCreateCommandQueue(cl_context context, cl_device_id device,
+    cl_command_queue_properties properties, cl_int *errcode_ret)
+{
+    cl_command_queue queue;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidContext(context))
+    {
+        status = CL_INVALID_CONTEXT;
+    }
+    else if (device != &stubDevice)
+    {
+        status = CL_INVALID_DEVICE;
+    }
+    else if ((properties & ~(cl_command_queue_properties)(CL_QUEUE_OUT_OF_ORDER_EXEC_MODE_ENABLE | CL_QUEUE_PROFILING_ENABLE)) != 0)
+    {
+        status = CL_INVALID_VALUE;
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    if (status != CL_SUCCESS)
+    {
+        return NULL;
+    }
+    queue = calloc(1, sizeof(struct _cl_command_queue));
+    queue->magic = STUB_MAGIC_QUEUE;
+    queue->refCount = 1;
+    queue->context = context;
+    queue->properties = properties;
+    return queue;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainCommandQueue(cl_command_queue command_queue)
+{
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    __atomic_add_fetch(&command_queue->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseCommandQueue(cl_command_queue command_queue)
+{
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    __atomic_sub_fetch(&command_queue->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetCommandQueueInfo(cl_command_queue command_queue, cl_command_queue_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    switch (param_name)
+    {
+    case CL_QUEUE_CONTEXT:
+        STUB_INFO_VALUE(cl_context, command_queue->context);
+    case CL_QUEUE_DEVICE:
+        STUB_INFO_VALUE(cl_device_id, &stubDevice);
+    case CL_QUEUE_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, command_queue->refCount);
+    case CL_QUEUE_PROPERTIES:
+        STUB_INFO_VALUE(cl_command_queue_properties, command_queue->properties);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clFlush(cl_command_queue command_queue)
+{
+    return stubValidQueue(command_queue) ? CL_SUCCESS : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clFinish(cl_command_queue command_queue)
+{
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    pthread_mutex_lock(&stubMutex);
+    while (command_queue->pendingCount > 0)
+    {
+        pthread_cond_wait(&stubCond, &stubMutex);
+    }
+    pthread_mutex_unlock(&stubMutex);
+    return CL_SUCCESS;
+}
+
+// Memory objects
+
+static cl_int stubCheckMemFlags(cl_mem_flags flags, void *hostPtr)
+{
+    cl_mem_flags const access = flags & (CL_MEM_READ_WRITE | CL_MEM_WRITE_ONLY | CL_MEM_READ_ONLY);
+    cl_mem_flags const hostAccess = flags & (CL_MEM_HOST_WRITE_ONLY | CL_MEM_HOST_READ_ONLY | CL_MEM_HOST_NO_ACCESS);
+    cl_mem_flags const known = CL_MEM_READ_WRITE | CL_MEM_WRITE_ONLY | CL_MEM_READ_ONLY | CL_MEM_USE_HOST_PTR |
+        CL_MEM_ALLOC_HOST_PTR | CL_MEM_COPY_HOST_PTR | CL_MEM_HOST_WRITE_ONLY | CL_MEM_HOST_READ_ONLY | CL_MEM_HOST_NO_ACCESS;
+    if (((flags & ~known) != 0) || ((access & (access - 1)) != 0) || ((hostAccess & (hostAccess - 1)) != 0))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (((flags & CL_MEM_USE_HOST_PTR) != 0) && ((flags & (CL_MEM_ALLOC_HOST_PTR | CL_MEM_COPY_HOST_PTR)) != 0))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (((flags & (CL_MEM_USE_HOST_PTR | CL_MEM_COPY_HOST_PTR)) != 0) != (hostPtr != NULL))
+    {
+        return CL_INVALID_HOST_PTR;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_mem CL_API_CALL clCreateBuffer(cl_context context, cl_mem_flags flags, size_t size, void *host_ptr,
+    cl_int *errcode_ret)
+{
+    cl_mem mem;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidContext(context))
+    {
+        status = CL_INVALID_CONTEXT;
+    }
+    else if ((size == 0) || (size > 256u * 1024u * 1024u))
+    {
+        status = CL_INVALID_BUFFER_SIZE;
+    }
+    else
+    {
+        status = stubCheckMemFlags(flags, host_ptr);
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    if (status != CL_SUCCESS)
+    {
+        return NULL;
+    }
+    mem = calloc(1, sizeof(struct _cl_mem));
+    mem->magic = STUB_MAGIC_MEM;
+    mem->refCount = 1;
+    mem->context = context;
+    mem->flags = ((flags & (CL_MEM_READ_WRITE | CL_MEM_WRITE_ONLY | CL_MEM_READ_ONLY)) == 0) ? (flags | CL_MEM_READ_WRITE) : flags;
+    mem->size = size;
+    if ((flags & CL_MEM_USE_HOST_PTR) != 0)
+    {
+        mem->data = host_ptr;
+        mem->hostPtr = host_ptr;
+    }
+    else
+    {
+        mem->data = calloc(1, size);
+        if ((flags & CL_MEM_COPY_HOST_PTR) != 0)
+        {
+            memcpy(mem->data, host_ptr, size);
+        }
+    }
+    return mem;
+}
+
+CL_API_ENTRY cl_mem CL_API_CALL clCreateSubBuffer(cl_mem buffer, cl_mem_flags flags,
+    cl_buffer_create_type buffer_create_type, void const *buffer_create_info, cl_int *errcode_ret)
+{
+    cl_buffer_region const *region = buffer_create_info;
+    cl_mem mem;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidMem(buffer) || (buffer->parent != NULL))
+    {
+        status = CL_INVALID_MEM_OBJECT;
+    }
+    else if ((buffer_create_type != CL_BUFFER_CREATE_TYPE_REGION) || (region == NULL) ||
+        ((flags & (CL_MEM_USE_HOST_PTR | CL_MEM_ALLOC_HOST_PTR | CL_MEM_COPY_HOST_PTR)) != 0))
+    {
+        status = CL_INVALID_VALUE;
+    }
+    else if ((region->size == 0) || (region->origin + region->size > buffer->size))
+    {
+        status = (region->size == 0) ? CL_INVALID_BUFFER_SIZE : CL_INVALID_VALUE;
+    }
+    else if ((region->origin % 128) != 0)
+    {
+        status = CL_MISALIGNED_SUB_BUFFER_OFFSET;
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    if (status != CL_SUCCESS)
+    {
+        return NULL;
+    }
+    mem = calloc(1, sizeof(struct _cl_mem));
+    mem->magic = STUB_MAGIC_MEM;
+    mem->refCount = 1;
+    mem->context = buffer->context;
+    mem->flags = (flags != 0) ? flags : buffer->flags;
+    mem->size = region->size;
+    mem->data = buffer->data + region->origin;
+    mem->hostPtr = (buffer->hostPtr != NULL) ? (unsigned char *)(buffer->hostPtr) + region->origin : NULL;
+    mem->parent = buffer;
+    mem->offset = region->origin;
+    return mem;
+}
+
+CL_API_ENTRY cl_mem CL_API_CALL clCreateImage(cl_context context, cl_mem_flags flags, cl_image_format const *image_format,
+    cl_image_desc const *image_desc, void *host_ptr, cl_int *errcode_ret)
+{
+    (void)(flags);
+    (void)(image_format);
+    (void)(image_desc);
+    (void)(host_ptr);
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = stubValidContext(context) ? CL_INVALID_OPERATION : CL_INVALID_CONTEXT;
+    }
+    return NULL;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainMemObject(cl_mem memobj)
+{
+    if (!stubValidMem(memobj))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    __atomic_add_fetch(&memobj->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseMemObject(cl_mem memobj)
+{
+    stubDestructor *destructor;
+    if (!stubValidMem(memobj))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    if (__atomic_sub_fetch(&memobj->refCount, 1, __ATOMIC_SEQ_CST) > 0)
+    {
+        return CL_SUCCESS;
+    }
+    pthread_mutex_lock(&stubMutex);
+    destructor = memobj->destructors;
+    memobj->destructors = NULL;
+    pthread_mutex_unlock(&stubMutex);
+    while (destructor != NULL)
+    {
+        stubDestructor *next = destructor->next;
+        destructor->notify(memobj, destructor->userData);
+        free(destructor);
+        destructor = next;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetSupportedImageFormats(cl_context context, cl_mem_flags flags,
+    cl_mem_object_type image_type, cl_uint num_entries, cl_image_format *image_formats, cl_uint *num_image_formats)
+{
+    (void)(flags);
+    (void)(image_type);
+    if (!stubValidContext(context))
+    {
+        return CL_INVALID_CONTEXT;
+    }
+    if ((num_entries == 0) && (image_formats != NULL))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (num_image_formats != NULL)
+    {
+        *num_image_formats = 0;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetMemObjectInfo(cl_mem memobj, cl_mem_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidMem(memobj))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    switch (param_name)
+    {
+    case CL_MEM_TYPE:
+        STUB_INFO_VALUE(cl_mem_object_type, CL_MEM_OBJECT_BUFFER);
+    case CL_MEM_FLAGS:
+        STUB_INFO_VALUE(cl_mem_flags, memobj->flags);
+    case CL_MEM_SIZE:
+        STUB_INFO_VALUE(size_t, memobj->size);
+    case CL_MEM_HOST_PTR:
+        STUB_INFO_VALUE(void *, memobj->hostPtr);
+    case CL_MEM_MAP_COUNT:
+        STUB_INFO_VALUE(cl_uint, memobj->mapCount);
+    case CL_MEM_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, memobj->refCount);
+    case CL_MEM_CONTEXT:
+        STUB_INFO_VALUE(cl_context, memobj->context);
+    case CL_MEM_ASSOCIATED_MEMOBJECT:
+        STUB_INFO_VALUE(cl_mem, memobj->parent);
+    case CL_MEM_OFFSET:
+        STUB_INFO_VALUE(size_t, memobj->offset);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetImageInfo(cl_mem image, cl_image_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    (void)(image);
+    (void)(param_name);
+    (void)(paramValueSize);
+    (void)(paramValue);
+    (void)(paramValueSizeRet);
+    return CL_INVALID_MEM_OBJECT;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clSetMemObjectDestructorCallback(cl_mem memobj,
+    void(CL_CALLBACK *pfn_notify)(cl_mem memobj, void *user_data), void *user_data)
+{
+    stubDestructor *destructor;
+    if (!stubValidMem(memobj))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    if (pfn_notify == NULL)
+    {
+        return CL_INVALID_VALUE;
+    }
+    destructor = calloc(1, sizeof(stubDestructor));
+    destructor->notify = pfn_notify;
+    destructor->userData = user_data;
+    pthread_mutex_lock(&stubMutex);
+    destructor->next = memobj->destructors;
+    memobj->destructors = destructor;
+    pthread_mutex_unlock(&stubMutex);
+    return CL_SUCCESS;
+}
+
+// Sampler
+
+CL_API_ENTRY cl_sampler CL_API_CALL clCreateSampler(cl_context context, cl_bool normalized_coords,
+    cl_addressing_mode addressing_mode, cl_filter_mode filter_mode, cl_int *errcode_ret)
+{
+    (void)(normalized_coords);
+    (void)(addressing_mode);
+    (void)(filter_mode);
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = stubValidContext(context) ? CL_INVALID_OPERATION : CL_INVALID_CONTEXT;
+    }
+    return NULL;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainSampler(cl_sampler sampler)
+{
+    (void)(sampler);
+    return CL_INVALID_SAMPLER;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseSampler(cl_sampler sampler)
+{
+    (void)(sampler);
+    return CL_INVALID_SAMPLER;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetSamplerInfo(cl_sampler sampler, cl_sampler_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    (void)(sampler);
+    (void)(param_name);
+    (void)(paramValueSize);
+    (void)(paramValue);
+    (void)(paramValueSizeRet);
+    return CL_INVALID_SAMPLER;
+}
+
+// Program source processing
+
+// stubStripComments returns a copy of the source without comments and without preprocessor lines.
+static char *stubStripComments(char const *source)
+{
+    size_t length = strlen(source);
+    char *result = malloc(length + 1);
+    size_t in = 0;
+    size_t out = 0;
+    int lineStart = 1;
+    while (in < length)
+    {
+        if ((source[in] == '/') && (source[in + 1] == '/'))
+        {
+            while ((in < length) && (source[in] != '\n'))
+            {
+                in++;
+            }
+        }
+        else if ((source[in] == '/') && (source[in + 1] == '*'))
+        {
+            in += 2;
+            while ((in < length) && !((source[in] == '*') && (source[in + 1] == '/')))
+            {
+                in++;
+            }
+            in = (in < length) ? in + 2 : in;
+            result[out++] = ' ';
+        }
+        else if (lineStart && (source[in] == '#'))
+        {
+            while ((in < length) && (source[in] != '\n'))
+            {
+                if ((source[in] == '\\') && (source[in + 1] == '\n'))
+                {
+                    in++;
+                }
+                in++;
+            }
+        }
+        else
+        {
+            if (source[in] == '\n')
+            {
+                lineStart = 1;
+            }
+            else if (!isspace((unsigned char)(source[in])))
+            {
+                lineStart = 0;
+            }
+            result[out++] = source[in++];
+        }
+    }
+    result[out] = 0;
+    return result;
+}
+
+static size_t stubScalarSize(char const *typeName)
+{
+    static struct
+    {
+        char const *name;
+        size_t size;
+    } const scalars[] = {
+        {"char", 1}, {"uchar", 1}, {"short", 2}, {"ushort", 2}, {"half", 2},
+        {"int", 4}, {"uint", 4}, {"float", 4}, {"long", 8}, {"ulong", 8}, {"double", 8},
+        {"size_t", sizeof(size_t)}, {"ptrdiff_t", sizeof(size_t)},
+        {"intptr_t", sizeof(size_t)}, {"uintptr_t", sizeof(size_t)},
+    };
+    size_t i;
+    for (i = 0; i < sizeof(scalars) / sizeof(scalars[0]); i++)
+    {
+        size_t nameLength = strlen(scalars[i].name);
+        if (strncmp(typeName, scalars[i].name, nameLength) == 0)
+        {
+            char const *suffix = typeName + nameLength;
+            int width;
+            if (*suffix == 0)
+            {
+                return scalars[i].size;
+            }
+            width = atoi(suffix);
+            if ((width == 2) || (width == 4) || (width == 8) || (width == 16))
+            {
+                return scalars[i].size * (size_t)(width);
+            }
+            if (width == 3)
+            {
+                return scalars[i].size * 4;
+            }
+        }
+    }
+    return 0;
+}
+
+static void stubParseArg(char const *text, size_t length, stubArgDef *arg)
+{
+    char tokens[32][128];
+    int tokenCount = 0;
+    int pointers = 0;
+    int unsignedSeen = 0;
+    size_t i = 0;
+    int t;
+
+    memset(arg, 0, sizeof(*arg));
+    arg->addressQualifier = CL_KERNEL_ARG_ADDRESS_PRIVATE;
+    arg->accessQualifier = CL_KERNEL_ARG_ACCESS_NONE;
+    arg->typeQualifier = CL_KERNEL_ARG_TYPE_NONE;
+    while (i < length)
+    {
+        if (isalnum((unsigned char)(text[i])) || (text[i] == '_'))
+        {
+            size_t start = i;
+            while ((i < length) && (isalnum((unsigned char)(text[i])) || (text[i] == '_')))
+            {
+                i++;
+            }
+            if ((tokenCount < 32) && (i - start < 128))
+            {
+                memcpy(tokens[tokenCount], text + start, i - start);
+                tokens[tokenCount][i - start] = 0;
+                tokenCount++;
+            }
+        }
+        else
+        {
+            if (text[i] == '*')
+            {
+                pointers++;
+            }
+            i++;
+        }
+    }
+    if (tokenCount == 0)
+    {
+        return;
+    }
+    strcpy(arg->name, tokens[tokenCount - 1]);
+    for (t = 0; t < tokenCount - 1; t++)
+    {
+        char const *token = tokens[t];
+        if ((strcmp(token, "__global") == 0) || (strcmp(token, "global") == 0))
+        {
+            arg->addressQualifier = CL_KERNEL_ARG_ADDRESS_GLOBAL;
+        }
+        else if ((strcmp(token, "__local") == 0) || (strcmp(token, "local") == 0))
+        {
+            arg->addressQualifier = CL_KERNEL_ARG_ADDRESS_LOCAL;
+        }
+        else if ((strcmp(token, "__constant") == 0) || (strcmp(token, "constant") == 0))
+        {
+            arg->addressQualifier = CL_KERNEL_ARG_ADDRESS_CONSTANT;
+        }
+        else if ((strcmp(token, "__private") == 0) || (strcmp(token, "private") == 0))
+        {
+            arg->addressQualifier = CL_KERNEL_ARG_ADDRESS_PRIVATE;
+        }
+        else if ((strcmp(token, "__read_only") == 0) || (strcmp(token, "read_only") == 0))
+        {
+            arg->accessQualifier = CL_KERNEL_ARG_ACCESS_READ_ONLY;
+        }
+        else if ((strcmp(token, "__write_only") == 0) || (strcmp(token, "write_only") == 0))
+        {
+            arg->accessQualifier = CL_KERNEL_ARG_ACCESS_WRITE_ONLY;
+        }
+        else if ((strcmp(token, "__read_write") == 0) || (strcmp(token, "read_write") == 0))
+        {
+            arg->accessQualifier = CL_KERNEL_ARG_ACCESS_READ_WRITE;
+        }
+        else if (strcmp(token, "const") == 0)
+        {
+            arg->typeQualifier |= CL_KERNEL_ARG_TYPE_CONST;
+        }
+        else if ((strcmp(token, "restrict") == 0) || (strcmp(token, "__restrict") == 0))
+        {
+            arg->typeQualifier |= CL_KERNEL_ARG_TYPE_RESTRICT;
+        }
+        else if (strcmp(token, "volatile") == 0)
+        {
+            arg->typeQualifier |= CL_KERNEL_ARG_TYPE_VOLATILE;
+        }
+        else if (strcmp(token, "unsigned") == 0)
+        {
+            unsignedSeen = 1;
+        }
+        else if ((strcmp(token, "signed") != 0) && (strcmp(token, "struct") != 0))
+        {
+            if (unsignedSeen)
+            {
+                strcat(arg->typeName, "u");
+            }
+            strncat(arg->typeName, token, sizeof(arg->typeName) - strlen(arg->typeName) - 1);
+        }
+    }
+    if (unsignedSeen && (arg->typeName[0] == 0))
+    {
+        strcpy(arg->typeName, "uint");
+    }
+    arg->isSampler = strcmp(arg->typeName, "sampler_t") == 0;
+    arg->isMemObject = (pointers > 0) || (strncmp(arg->typeName, "image", 5) == 0);
+    if (strncmp(arg->typeName, "image", 5) == 0)
+    {
+        arg->addressQualifier = CL_KERNEL_ARG_ADDRESS_GLOBAL;
+        if (arg->accessQualifier == CL_KERNEL_ARG_ACCESS_NONE)
+        {
+            arg->accessQualifier = CL_KERNEL_ARG_ACCESS_READ_ONLY;
+        }
+    }
+    for (; pointers > 0; pointers--)
+    {
+        strcat(arg->typeName, "*");
+        arg->isPointer = 1;
+    }
+    if (arg->isPointer && (arg->addressQualifier == CL_KERNEL_ARG_ADDRESS_LOCAL))
+    {
+        arg->isMemObject = 0;
+    }
+    else if (arg->isMemObject)
+    {
+        arg->size = sizeof(cl_mem);
+    }
+    else if (arg->isSampler)
+    {
+        arg->size = sizeof(cl_sampler);
+    }
+    else
+    {
+        arg->size = stubScalarSize(arg->typeName);
+    }
+}
+
+static int stubIsWordAt(char const *text, size_t pos, char const *word)
+{
+    size_t length = strlen(word);
+    if (strncmp(text + pos, word, length) != 0)
+    {
+        return 0;
+    }
+    if ((pos > 0) && (isalnum((unsigned char)(text[pos - 1])) || (text[pos - 1] == '_')))
+    {
+        return 0;
+    }
+    return !(isalnum((unsigned char)(text[pos + length])) || (text[pos + length] == '_'));
+}
+
+static void stubParseKernels(char const *source, stubKernelDef **kernels, cl_uint *kernelCount)
+{
+    char *text = stubStripComments(source);
+    size_t length = strlen(text);
+    size_t pos = 0;
+
+    *kernels = NULL;
+    *kernelCount = 0;
+    while (pos < length)
+    {
+        size_t keywordLength = 0;
+        stubKernelDef def;
+        size_t nameEnd;
+        size_t nameStart;
+        size_t paramStart;
+        size_t paramEnd;
+        int depth;
+        char *attr;
+
+        if (stubIsWordAt(text, pos, "__kernel"))
+        {
+            keywordLength = 8;
+        }
+        else if (stubIsWordAt(text, pos, "kernel"))
+        {
+            keywordLength = 6;
+        }
+        if (keywordLength == 0)
+        {
+            pos++;
+            continue;
+        }
+        memset(&def, 0, sizeof(def));
+        paramStart = pos + keywordLength;
+        while ((paramStart < length) && (text[paramStart] != '(') && (text[paramStart] != ';') && (text[paramStart] != '{'))
+        {
+            if (strncmp(text + paramStart, "__attribute__", 13) == 0)
+            {
+                size_t attrStart = paramStart;
+                paramStart += 13;
+                while ((paramStart < length) && (text[paramStart] != '('))
+                {
+                    paramStart++;
+                }
+                depth = 0;
+                do
+                {
+                    depth += (text[paramStart] == '(') ? 1 : ((text[paramStart] == ')') ? -1 : 0);
+                    paramStart++;
+                } while ((paramStart < length) && (depth > 0));
+                if (paramStart - attrStart < sizeof(def.attributes) - strlen(def.attributes) - 2)
+                {
+                    if (def.attributes[0] != 0)
+                    {
+                        strcat(def.attributes, " ");
+                    }
+                    strncat(def.attributes, text + attrStart, paramStart - attrStart);
+                }
+                continue;
+            }
+            paramStart++;
+        }
+        if ((paramStart >= length) || (text[paramStart] != '('))
+        {
+            pos = paramStart;
+            continue;
+        }
+        nameEnd = paramStart;
+        while ((nameEnd > pos) && isspace((unsigned char)(text[nameEnd - 1])))
+        {
+            nameEnd--;
+        }
+        nameStart = nameEnd;
+        while ((nameStart > pos) && (isalnum((unsigned char)(text[nameStart - 1])) || (text[nameStart - 1] == '_')))
+        {
+            nameStart--;
+        }
+        if ((nameEnd == nameStart) || (nameEnd - nameStart >= sizeof(def.name)))
+        {
+            pos = paramStart;
+            continue;
+        }
+        memcpy(def.name, text + nameStart, nameEnd - nameStart);
+        attr = strstr(def.attributes, "reqd_work_group_size");
+        if (attr != NULL)
+        {
+            unsigned long x = 0, y = 0, z = 0;
+            if (sscanf(attr, "reqd_work_group_size ( %lu , %lu , %lu )", &x, &y, &z) == 3)
+            {
+                def.requiredWorkGroupSize[0] = x;
+                def.requiredWorkGroupSize[1] = y;
+                def.requiredWorkGroupSize[2] = z;
+            }
+        }
+        paramEnd = paramStart + 1;
+        depth = 1;
+        {
+            size_t argStart = paramEnd;
+            while ((paramEnd < length) && (depth > 0))
+            {
+                char c = text[paramEnd];
+                if (c == '(')
+                {
+                    depth++;
+                }
+                else if (c == ')')
+                {
+                    depth--;
+                }
+                if (((depth == 1) && (c == ',')) || (depth == 0))
+                {
+                    stubArgDef arg;
+                    stubParseArg(text + argStart, paramEnd - argStart, &arg);
+                    if ((arg.name[0] != 0) && (strcmp(arg.name, "void") != 0) && (def.argCount < STUB_MAX_ARGS))
+                    {
+                        def.args[def.argCount++] = arg;
+                    }
+                    argStart = paramEnd + 1;
+                }
+                paramEnd++;
+            }
+        }
+        *kernels = realloc(*kernels, (*kernelCount + 1) * sizeof(stubKernelDef));
+        (*kernels)[(*kernelCount)++] = def;
+        pos = paramEnd;
+    }
+    free(text);
+}
+
+// stubApplyDirectives evaluates the stub directives of the source. It returns zero if a failure was requested.
+static int stubApplyDirectives(char const *source, char **log, stubKernelDef *kernels, cl_uint kernelCount)
+{
+    char const *pos = source;
+    int success = 1;
+    while ((pos = strstr(pos, "stub:")) != NULL)
+    {
+        char line[512];
+        size_t lineLength = strcspn(pos, "\n");
+        if (lineLength >= sizeof(line))
+        {
+            lineLength = sizeof(line) - 1;
+        }
+        memcpy(line, pos, lineLength);
+        line[lineLength] = 0;
+        pos += lineLength;
+        if (strncmp(line, "stub:build-log ", 15) == 0)
+        {
+            stubAppend(log, line + 15);
+            stubAppend(log, "\n");
+        }
+        else if (strncmp(line, "stub:build-fail", 15) == 0)
+        {
+            success = 0;
+        }
+        else if (strncmp(line, "stub:kernel-status ", 19) == 0)
+        {
+            char name[128];
+            int status;
+            cl_uint i;
+            if (sscanf(line + 19, "%127s %d", name, &status) == 2)
+            {
+                for (i = 0; i < kernelCount; i++)
+                {
+                    if (strcmp(kernels[i].name, name) == 0)
+                    {
+                        kernels[i].executionStatus = status;
+                    }
+                }
+            }
+        }
+    }
+    return success;
+}
+
+// stubCheckOptions verifies that all options are known compiler or linker options.
+static int stubCheckOptions(char const *options, int linker)
+{
+    static char const *const compilerOptions[] = {
+        "-cl-single-precision-constant", "-cl-denorms-are-zero", "-cl-fp32-correctly-rounded-divide-sqrt",
+        "-cl-opt-disable", "-cl-mad-enable",
+        "-cl-no-signed-zeros", "-cl-unsafe-math-optimizations", "-cl-finite-math-only", "-cl-fast-relaxed-math",
+        "-w", "-Werror", "-cl-kernel-arg-info", NULL,
+    };
+    static char const *const linkerOptions[] = {
+        "-create-library", "-enable-link-options", "-cl-denorms-are-zero", "-cl-no-signed-zeros",
+        "-cl-unsafe-math-optimizations", "-cl-finite-math-only", "-cl-fast-relaxed-math", NULL,
+    };
+    char const *pos = (options != NULL) ? options : "";
+    while (*pos != 0)
+    {
+        char token[1024];
+        size_t length = 0;
+        int quoted = 0;
+        int known = 0;
+        int i;
+        while (isspace((unsigned char)(*pos)))
+        {
+            pos++;
+        }
+        if (*pos == 0)
+        {
+            break;
+        }
+        while ((*pos != 0) && (quoted || !isspace((unsigned char)(*pos))))
+        {
+            if (*pos == '"')
+            {
+                quoted = !quoted;
+            }
+            else if ((*pos == '\\') && (pos[1] != 0))
+            {
+                pos++;
+                if (length < sizeof(token) - 1)
+                {
+                    token[length++] = *pos;
+                }
+            }
+            else if (length < sizeof(token) - 1)
+            {
+                token[length++] = *pos;
+            }
+            pos++;
+        }
+        token[length] = 0;
+        if (quoted)
+        {
+            return 0;
+        }
+        if (!linker && ((strncmp(token, "-D", 2) == 0) || (strncmp(token, "-I", 2) == 0)))
+        {
+            if (token[2] == 0)
+            {
+                while (isspace((unsigned char)(*pos)))
+                {
+                    pos++;
+                }
+                if (*pos == 0)
+                {
+                    return 0;
+                }
+                // The value is processed as the next token, which is skipped here.
+                while ((*pos != 0) && (quoted || !isspace((unsigned char)(*pos))))
+                {
+                    quoted = (*pos == '"') ? !quoted : quoted;
+                    pos += ((*pos == '\\') && (pos[1] != 0)) ? 2 : 1;
+                }
+            }
+            continue;
+        }
+        if (!linker && ((strcmp(token, "-cl-std=CL1.1") == 0) || (strcmp(token, "-cl-std=CL1.2") == 0)))
+        {
+            continue;
+        }
+        for (i = 0; (linker ? linkerOptions[i] : compilerOptions[i]) != NULL; i++)
+        {
+            if (strcmp(token, linker ? linkerOptions[i] : compilerOptions[i]) == 0)
+            {
+                known = 1;
+            }
+        }
+        if (!known)
+        {
+            return 0;
+        }
+    }
+    return 1;
+}
+
+static cl_program stubNewProgram(cl_context context, char const *source)
+{
+    cl_program program = calloc(1, sizeof(struct _cl_program));
+    program->magic = STUB_MAGIC_PROGRAM;
+    program->refCount = 1;
+    program->context = context;
+    program->source = stubStrdup(source);
+    program->buildStatus = CL_BUILD_NONE;
+    program->binaryType = CL_PROGRAM_BINARY_TYPE_NONE;
+    return program;
+}
+
+// stubCompile processes the program source with the given options and sets the build status, log, and kernels.
+static int stubCompile(cl_program program, char const *options, cl_program_binary_type resultType)
+{
+    int success;
+    free(program->buildOptions);
+    free(program->buildLog);
+    free(program->kernels);
+    program->buildOptions = stubStrdup((options != NULL) ? options : "");
+    program->buildLog = stubStrdup("");
+    stubParseKernels(program->source, &program->kernels, &program->kernelCount);
+    success = stubApplyDirectives(program->source, &program->buildLog, program->kernels, program->kernelCount);
+    program->argInfo = (options != NULL) && (strstr(options, "-cl-kernel-arg-info") != NULL);
+    program->buildStatus = success ? CL_BUILD_SUCCESS : CL_BUILD_ERROR;
+    program->binaryType = success ? resultType : CL_PROGRAM_BINARY_TYPE_NONE;
+    if (!success)
+    {
+        program->kernelCount = 0;
+    }
+    return success;
+}
+
+CL_API_ENTRY cl_program CL_API_CALL clCreateProgramWithSource(cl_context context, cl_uint count, char const **strings,
+    size_t const *lengths, cl_int *errcode_ret)
+{
+    char *source = NULL;
+    cl_program program;
+    cl_uint i;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidContext(context))
+    {
+        status = CL_INVALID_CONTEXT;
+    }
+    else if ((count == 0) || (strings == NULL))
+    {
+        status = CL_INVALID_VALUE;
+    }
+    for (i = 0; (status == CL_SUCCESS) && (i < count); i++)
+    {
+        if (strings[i] == NULL)
+        {
+            status = CL_INVALID_VALUE;
+        }
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    if (status != CL_SUCCESS)
+    {
+        return NULL;
+    }
+    source = stubStrdup("");
+    for (i = 0; i < count; i++)
+    {
+        if ((lengths != NULL) && (lengths[i] != 0))
+        {
+            char *part = calloc(1, lengths[i] + 1);
+            memcpy(part, strings[i], lengths[i]);
+            stubAppend(&source, part);
+            free(part);
+        }
+        else
+        {
+            stubAppend(&source, strings[i]);
+        }
+    }
+    program = stubNewProgram(context, source);
+    free(source);
+    return program;
+}
+
+CL_API_ENTRY cl_program CL_API_CALL clCreateProgramWithBinary(cl_context context, cl_uint num_devices,
+    cl_device_id const *device_list, size_t const *lengths, unsigned char const **binaries, cl_int *binary_status,
+    cl_int *errcode_ret)
+{
+    size_t const prefixLength = strlen(STUB_BINARY_PREFIX);
+    cl_program program;
+    char *source;
+    cl_uint i;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidContext(context))
+    {
+        status = CL_INVALID_CONTEXT;
+    }
+    else if ((num_devices == 0) || (device_list == NULL) || (lengths == NULL) || (binaries == NULL))
+    {
+        status = CL_INVALID_VALUE;
+    }
+    else
+    {
+        status = stubCheckDevices(num_devices, device_list);
+    }
+    for (i = 0; (status == CL_SUCCESS) && (i < num_devices); i++)
+    {
+        cl_int deviceStatus = CL_SUCCESS;
+        if ((binaries[i] == NULL) || (lengths[i] == 0))
+        {
+            status = CL_INVALID_VALUE;
+            deviceStatus = CL_INVALID_VALUE;
+        }
+        else if ((lengths[i] < prefixLength + 1) || (memcmp(binaries[i], STUB_BINARY_PREFIX, prefixLength) != 0))
+        {
+            status = CL_INVALID_BINARY;
+            deviceStatus = CL_INVALID_BINARY;
+        }
+        if (binary_status != NULL)
+        {
+            binary_status[i] = deviceStatus;
+        }
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    if (status != CL_SUCCESS)
+    {
+        return NULL;
+    }
+    source = calloc(1, lengths[0] - prefixLength);
+    memcpy(source, binaries[0] + prefixLength + 1, lengths[0] - prefixLength - 1);
+    program = stubNewProgram(context, source);
+    free(source);
+    program->fromBinary = 1;
+    switch (binaries[0][prefixLength])
+    {
+    case 'o':
+        program->binaryType = CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT;
+        break;
+    case 'l':
+        program->binaryType = CL_PROGRAM_BINARY_TYPE_LIBRARY;
+        break;
+    default:
+        program->binaryType = CL_PROGRAM_BINARY_TYPE_EXECUTABLE;
+    }
+    return program;
+}
+
+CL_API_ENTRY cl_program CL_API_CALL clCreateProgramWithBuiltInKernels(cl_context context, cl_uint num_devices,
+    cl_device_id const *device_list, char const *kernel_names, cl_int *errcode_ret)
+{
+    (void)(num_devices);
+    (void)(device_list);
+    (void)(kernel_names);
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = stubValidContext(context) ? CL_INVALID_VALUE : CL_INVALID_CONTEXT;
+    }
+    return NULL;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainProgram(cl_program program)
+{
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    __atomic_add_fetch(&program->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseProgram(cl_program program)
+{
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    __atomic_sub_fetch(&program->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+typedef struct
+{
+    void(CL_CALLBACK *notify)(cl_program program, void *user_data);
+    cl_program program;
+    void *userData;
+} stubProgramNotification;
+
+static void *stubProgramNotifyThread(void *arg)
+{
+    stubProgramNotification *notification = arg;
+    struct timespec delay = {0, 20 * 1000 * 1000};
+    nanosleep(&delay, NULL);
+    notification->notify(notification->program, notification->userData);
+    free(notification);
+    return NULL;
+}
+
+// stubNotifyProgram calls the notification of a build, compilation, or link. With the directive stub:notify-thread,
+// the notification is called on a separate thread instead.
+static void stubNotifyProgram(cl_program program, void(CL_CALLBACK *pfn_notify)(cl_program program, void *user_data),
+    void *user_data)
+{
+    pthread_t thread;
+    stubProgramNotification *notification;
+    if (pfn_notify == NULL)
+    {
+        return;
+    }
+    if (strstr(program->source, "stub:notify-thread") == NULL)
+    {
+        pfn_notify(program, user_data);
+        return;
+    }
+    notification = malloc(sizeof(stubProgramNotification));
+    notification->notify = pfn_notify;
+    notification->program = program;
+    notification->userData = user_data;
+    if (pthread_create(&thread, NULL, stubProgramNotifyThread, notification) != 0)
+    {
+        free(notification);
+        pfn_notify(program, user_data);
+        return;
+    }
+    pthread_detach(thread);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clBuildProgram(cl_program program, cl_uint num_devices, cl_device_id const *device_list,
+    char const *options, void(CL_CALLBACK *pfn_notify)(cl_program program, void *user_data), void *user_data)
+{
+    int success;
+    cl_int status;
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    status = stubCheckDevices(num_devices, device_list);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if ((pfn_notify == NULL) && (user_data != NULL))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (program->attachedKernels > 0)
+    {
+        return CL_INVALID_OPERATION;
+    }
+    if (!stubCheckOptions(options, 0))
+    {
+        return CL_INVALID_BUILD_OPTIONS;
+    }
+    if (program->fromBinary && (program->binaryType == CL_PROGRAM_BINARY_TYPE_LIBRARY))
+    {
+        return CL_INVALID_BINARY;
+    }
+    success = stubCompile(program, options, CL_PROGRAM_BINARY_TYPE_EXECUTABLE);
+    stubNotifyProgram(program, pfn_notify, user_data);
+    return success ? CL_SUCCESS : CL_BUILD_PROGRAM_FAILURE;
+}
+
+// stubCheckIncludes verifies that all headers included by the source are provided.
+static int stubCheckIncludes(char const *source, cl_uint numHeaders, cl_program const *headers,
+    char const **headerNames, char **log)
+{
+    char const *pos = source;
+    int success = 1;
+    while ((pos = strstr(pos, "#include")) != NULL)
+    {
+        char name[256];
+        char const *lineStart = pos;
+        cl_uint i;
+        int found = 0;
+        while ((lineStart > source) && ((lineStart[-1] == ' ') || (lineStart[-1] == '\t')))
+        {
+            lineStart--;
+        }
+        pos += 8;
+        // Directives must start a line; this skips those in comments.
+        if (((lineStart > source) && (lineStart[-1] != '\n')) || (sscanf(pos, " \"%255[^\"]\"", name) != 1))
+        {
+            continue;
+        }
+        for (i = 0; i < numHeaders; i++)
+        {
+            if (strcmp(headerNames[i], name) == 0)
+            {
+                found = 1;
+                if (!stubCheckIncludes(headers[i]->source, numHeaders, headers, headerNames, log))
+                {
+                    success = 0;
+                }
+            }
+        }
+        if (!found)
+        {
+            stubAppend(log, "fatal error: '");
+            stubAppend(log, name);
+            stubAppend(log, "' file not found\n");
+            success = 0;
+        }
+    }
+    return success;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clCompileProgram(cl_program program, cl_uint num_devices, cl_device_id const *device_list,
+    char const *options, cl_uint num_input_headers, cl_program const *input_headers, char const **header_include_names,
+    void(CL_CALLBACK *pfn_notify)(cl_program program, void *user_data), void *user_data)
+{
+    char *includeLog = NULL;
+    int success;
+    cl_uint i;
+    cl_int status;
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    status = stubCheckDevices(num_devices, device_list);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if (((pfn_notify == NULL) && (user_data != NULL)) || ((num_input_headers == 0) != (input_headers == NULL)) ||
+        ((num_input_headers == 0) != (header_include_names == NULL)))
+    {
+        return CL_INVALID_VALUE;
+    }
+    for (i = 0; i < num_input_headers; i++)
+    {
+        if (!stubValidProgram(input_headers[i]))
+        {
+            return CL_INVALID_PROGRAM;
+        }
+    }
+    if ((program->attachedKernels > 0) || program->fromBinary)
+    {
+        return CL_INVALID_OPERATION;
+    }
+    if (!stubCheckOptions(options, 0))
+    {
+        return CL_INVALID_COMPILER_OPTIONS;
+    }
+    success = stubCompile(program, options, CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT);
+    if (!stubCheckIncludes(program->source, num_input_headers, input_headers, header_include_names, &includeLog))
+    {
+        stubAppend(&program->buildLog, includeLog);
+        program->buildStatus = CL_BUILD_ERROR;
+        program->binaryType = CL_PROGRAM_BINARY_TYPE_NONE;
+        program->kernelCount = 0;
+        success = 0;
+    }
+    free(includeLog);
+    stubNotifyProgram(program, pfn_notify, user_data);
+    return success ? CL_SUCCESS : CL_COMPILE_PROGRAM_FAILURE;
+}
+
+CL_API_ENTRY cl_program CL_API_CALL clLinkProgram(cl_context context, cl_uint num_devices, cl_device_id const *device_list,
+    char const *options, cl_uint num_input_programs, cl_program const *input_programs,
+    void(CL_CALLBACK *pfn_notify)(cl_program program, void *user_data), void *user_data, cl_int *errcode_ret)
+{
+    cl_program program;
+    char *source;
+    int library;
+    int success;
+    cl_uint i;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidContext(context))
+    {
+        status = CL_INVALID_CONTEXT;
+    }
+    else if (((pfn_notify == NULL) && (user_data != NULL)) || (num_input_programs == 0) || (input_programs == NULL))
+    {
+        status = CL_INVALID_VALUE;
+    }
+    else if (!stubCheckOptions(options, 1))
+    {
+        status = CL_INVALID_LINKER_OPTIONS;
+    }
+    else
+    {
+        status = stubCheckDevices(num_devices, device_list);
+    }
+    for (i = 0; (status == CL_SUCCESS) && (i < num_input_programs); i++)
+    {
+        if (!stubValidProgram(input_programs[i]))
+        {
+            status = CL_INVALID_PROGRAM;
+        }
+        else if ((input_programs[i]->binaryType != CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT) &&
+            (input_programs[i]->binaryType != CL_PROGRAM_BINARY_TYPE_LIBRARY))
+        {
+            status = CL_INVALID_OPERATION;
+        }
+    }
+    if (status != CL_SUCCESS)
+    {
+        if (errcode_ret != NULL)
+        {
+            *errcode_ret = status;
+        }
+        return NULL;
+    }
+    source = stubStrdup("");
+    for (i = 0; i < num_input_programs; i++)
+    {
+        stubAppend(&source, input_programs[i]->source);
+        stubAppend(&source, "\n");
+    }
+    program = stubNewProgram(context, source);
+    free(source);
+    library = (options != NULL) && (strstr(options, "-create-library") != NULL);
+    success = stubCompile(program, options,
+        library ? CL_PROGRAM_BINARY_TYPE_LIBRARY : CL_PROGRAM_BINARY_TYPE_EXECUTABLE);
+    for (i = 0; i < num_input_programs; i++)
+    {
+        program->argInfo = program->argInfo || input_programs[i]->argInfo;
+    }
+    if (success && (strstr(program->source, "stub:link-fail") != NULL))
+    {
+        stubAppend(&program->buildLog, "error: linking failed\n");
+        program->buildStatus = CL_BUILD_ERROR;
+        program->binaryType = CL_PROGRAM_BINARY_TYPE_NONE;
+        program->kernelCount = 0;
+        success = 0;
+    }
+    stubNotifyProgram(program, pfn_notify, user_data);
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = success ? CL_SUCCESS : CL_LINK_PROGRAM_FAILURE;
+    }
+    return program;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetProgramInfo(cl_program program, cl_program_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    int hasBinary;
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    hasBinary = program->binaryType != CL_PROGRAM_BINARY_TYPE_NONE;
+    switch (param_name)
+    {
+    case CL_PROGRAM_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, program->refCount);
+    case CL_PROGRAM_CONTEXT:
+        STUB_INFO_VALUE(cl_context, program->context);
+    case CL_PROGRAM_NUM_DEVICES:
+        STUB_INFO_VALUE(cl_uint, 1);
+    case CL_PROGRAM_DEVICES:
+        STUB_INFO_VALUE(cl_device_id, &stubDevice);
+    case CL_PROGRAM_SOURCE:
+        return stubInfoString(program->fromBinary ? "" : program->source, paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PROGRAM_BINARY_SIZES:
+        STUB_INFO_VALUE(size_t, hasBinary ? strlen(STUB_BINARY_PREFIX) + 1 + strlen(program->source) : 0);
+    case CL_PROGRAM_BINARIES:
+    {
+        unsigned char *target;
+        if (paramValue == NULL)
+        {
+            return stubInfo(NULL, sizeof(unsigned char *), 0, NULL, paramValueSizeRet);
+        }
+        if (paramValueSize < sizeof(unsigned char *))
+        {
+            return CL_INVALID_VALUE;
+        }
+        target = *(unsigned char **)(paramValue);
+        if ((target != NULL) && hasBinary)
+        {
+            size_t prefixLength = strlen(STUB_BINARY_PREFIX);
+            memcpy(target, STUB_BINARY_PREFIX, prefixLength);
+            switch (program->binaryType)
+            {
+            case CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT:
+                target[prefixLength] = 'o';
+                break;
+            case CL_PROGRAM_BINARY_TYPE_LIBRARY:
+                target[prefixLength] = 'l';
+                break;
+            default:
+                target[prefixLength] = 'e';
+            }
+            memcpy(target + prefixLength + 1, program->source, strlen(program->source));
+        }
+        if (paramValueSizeRet != NULL)
+        {
+            *paramValueSizeRet = sizeof(unsigned char *);
+        }
+        return CL_SUCCESS;
+    }
+    case CL_PROGRAM_NUM_KERNELS:
+        if (program->binaryType != CL_PROGRAM_BINARY_TYPE_EXECUTABLE)
+        {
+            return CL_INVALID_PROGRAM_EXECUTABLE;
+        }
+        STUB_INFO_VALUE(size_t, program->kernelCount);
+    case CL_PROGRAM_KERNEL_NAMES:
+    {
+        char *names = stubStrdup("");
+        cl_int status;
+        cl_uint i;
+        if (program->binaryType != CL_PROGRAM_BINARY_TYPE_EXECUTABLE)
+        {
+            free(names);
+            return CL_INVALID_PROGRAM_EXECUTABLE;
+        }
+        for (i = 0; i < program->kernelCount; i++)
+        {
+            if (i > 0)
+            {
+                stubAppend(&names, ";");
+            }
+            stubAppend(&names, program->kernels[i].name);
+        }
+        status = stubInfoString(names, paramValueSize, paramValue, paramValueSizeRet);
+        free(names);
+        return status;
+    }
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetProgramBuildInfo(cl_program program, cl_device_id device,
+    cl_program_build_info param_name, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    if (device != &stubDevice)
+    {
+        return CL_INVALID_DEVICE;
+    }
+    switch (param_name)
+    {
+    case CL_PROGRAM_BUILD_STATUS:
+        STUB_INFO_VALUE(cl_build_status, program->buildStatus);
+    case CL_PROGRAM_BUILD_OPTIONS:
+        return stubInfoString(program->buildOptions, paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PROGRAM_BUILD_LOG:
+        return stubInfoString(program->buildLog, paramValueSize, paramValue, paramValueSizeRet);
+    case CL_PROGRAM_BINARY_TYPE:
+        STUB_INFO_VALUE(cl_program_binary_type, program->binaryType);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+// Kernel
+
+static cl_kernel stubNewKernel(cl_program program, stubKernelDef *def)
+{
+    cl_kernel kernel = calloc(1, sizeof(struct _cl_kernel));
+    kernel->magic = STUB_MAGIC_KERNEL;
+    kernel->refCount = 1;
+    kernel->program = program;
+    kernel->def = def;
+    kernel->argInfo = program->argInfo;
+    __atomic_add_fetch(&program->attachedKernels, 1, __ATOMIC_SEQ_CST);
+    return kernel;
+}
+
+CL_API_ENTRY cl_kernel CL_API_CALL clCreateKernel(cl_program program, char const *kernel_name, cl_int *errcode_ret)
+{
+    cl_uint i;
+    cl_int status = CL_SUCCESS;
+    if (!stubValidProgram(program))
+    {
+        status = CL_INVALID_PROGRAM;
+    }
+    else if (program->binaryType != CL_PROGRAM_BINARY_TYPE_EXECUTABLE)
+    {
+        status = CL_INVALID_PROGRAM_EXECUTABLE;
+    }
+    else if (kernel_name == NULL)
+    {
+        status = CL_INVALID_VALUE;
+    }
+    if (status == CL_SUCCESS)
+    {
+        for (i = 0; i < program->kernelCount; i++)
+        {
+            if (strcmp(program->kernels[i].name, kernel_name) == 0)
+            {
+                if (errcode_ret != NULL)
+                {
+                    *errcode_ret = CL_SUCCESS;
+                }
+                return stubNewKernel(program, &program->kernels[i]);
+            }
+        }
+        status = CL_INVALID_KERNEL_NAME;
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    return NULL;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clCreateKernelsInProgram(cl_program program, cl_uint num_kernels, cl_kernel *kernels,
+    cl_uint *num_kernels_ret)
+{
+    cl_uint i;
+    if (!stubValidProgram(program))
+    {
+        return CL_INVALID_PROGRAM;
+    }
+    if (program->binaryType != CL_PROGRAM_BINARY_TYPE_EXECUTABLE)
+    {
+        return CL_INVALID_PROGRAM_EXECUTABLE;
+    }
+    if ((kernels != NULL) && (num_kernels < program->kernelCount))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (kernels != NULL)
+    {
+        for (i = 0; i < program->kernelCount; i++)
+        {
+            kernels[i] = stubNewKernel(program, &program->kernels[i]);
+        }
+    }
+    if (num_kernels_ret != NULL)
+    {
+        *num_kernels_ret = program->kernelCount;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainKernel(cl_kernel kernel)
+{
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    __atomic_add_fetch(&kernel->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseKernel(cl_kernel kernel)
+{
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    if (__atomic_sub_fetch(&kernel->refCount, 1, __ATOMIC_SEQ_CST) == 0)
+    {
+        __atomic_sub_fetch(&kernel->program->attachedKernels, 1, __ATOMIC_SEQ_CST);
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clSetKernelArg(cl_kernel kernel, cl_uint arg_index, size_t arg_size, void const *arg_value)
+{
+    stubArgDef *arg;
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    if (arg_index >= kernel->def->argCount)
+    {
+        return CL_INVALID_ARG_INDEX;
+    }
+    arg = &kernel->def->args[arg_index];
+    if (arg->isPointer && (arg->addressQualifier == CL_KERNEL_ARG_ADDRESS_LOCAL))
+    {
+        if (arg_value != NULL)
+        {
+            return CL_INVALID_ARG_VALUE;
+        }
+        if (arg_size == 0)
+        {
+            return CL_INVALID_ARG_SIZE;
+        }
+        kernel->localSize[arg_index] = arg_size;
+    }
+    else if (arg->isMemObject)
+    {
+        if (arg_size != sizeof(cl_mem))
+        {
+            return CL_INVALID_ARG_SIZE;
+        }
+        if ((arg_value != NULL) && (*(cl_mem const *)(arg_value) != NULL) && !stubValidMem(*(cl_mem const *)(arg_value)))
+        {
+            return CL_INVALID_MEM_OBJECT;
+        }
+    }
+    else if (arg->isSampler)
+    {
+        if (arg_size != sizeof(cl_sampler))
+        {
+            return CL_INVALID_ARG_SIZE;
+        }
+        return CL_INVALID_SAMPLER;
+    }
+    else
+    {
+        if (arg_value == NULL)
+        {
+            return CL_INVALID_ARG_VALUE;
+        }
+        if ((arg->size != 0) && (arg_size != arg->size))
+        {
+            return CL_INVALID_ARG_SIZE;
+        }
+    }
+    kernel->argSet[arg_index] = 1;
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetKernelInfo(cl_kernel kernel, cl_kernel_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    switch (param_name)
+    {
+    case CL_KERNEL_FUNCTION_NAME:
+        return stubInfoString(kernel->def->name, paramValueSize, paramValue, paramValueSizeRet);
+    case CL_KERNEL_NUM_ARGS:
+        STUB_INFO_VALUE(cl_uint, kernel->def->argCount);
+    case CL_KERNEL_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, kernel->refCount);
+    case CL_KERNEL_CONTEXT:
+        STUB_INFO_VALUE(cl_context, kernel->program->context);
+    case CL_KERNEL_PROGRAM:
+        STUB_INFO_VALUE(cl_program, kernel->program);
+    case CL_KERNEL_ATTRIBUTES:
+        return stubInfoString(kernel->def->attributes, paramValueSize, paramValue, paramValueSizeRet);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetKernelArgInfo(cl_kernel kernel, cl_uint arg_indx, cl_kernel_arg_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    stubArgDef *arg;
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    if (arg_indx >= kernel->def->argCount)
+    {
+        return CL_INVALID_ARG_INDEX;
+    }
+    if (!kernel->argInfo)
+    {
+        return CL_KERNEL_ARG_INFO_NOT_AVAILABLE;
+    }
+    arg = &kernel->def->args[arg_indx];
+    switch (param_name)
+    {
+    case CL_KERNEL_ARG_ADDRESS_QUALIFIER:
+        STUB_INFO_VALUE(cl_kernel_arg_address_qualifier, arg->addressQualifier);
+    case CL_KERNEL_ARG_ACCESS_QUALIFIER:
+        STUB_INFO_VALUE(cl_kernel_arg_access_qualifier, arg->accessQualifier);
+    case CL_KERNEL_ARG_TYPE_NAME:
+        return stubInfoString(arg->typeName, paramValueSize, paramValue, paramValueSizeRet);
+    case CL_KERNEL_ARG_TYPE_QUALIFIER:
+        STUB_INFO_VALUE(cl_kernel_arg_type_qualifier, arg->typeQualifier);
+    case CL_KERNEL_ARG_NAME:
+        return stubInfoString(arg->name, paramValueSize, paramValue, paramValueSizeRet);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetKernelWorkGroupInfo(cl_kernel kernel, cl_device_id device,
+    cl_kernel_work_group_info param_name, size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    if ((device != NULL) && (device != &stubDevice))
+    {
+        return CL_INVALID_DEVICE;
+    }
+    switch (param_name)
+    {
+    case CL_KERNEL_WORK_GROUP_SIZE:
+        STUB_INFO_VALUE(size_t, 1024);
+    case CL_KERNEL_COMPILE_WORK_GROUP_SIZE:
+        return stubInfo(kernel->def->requiredWorkGroupSize, sizeof(kernel->def->requiredWorkGroupSize),
+            paramValueSize, paramValue, paramValueSizeRet);
+    case CL_KERNEL_LOCAL_MEM_SIZE:
+    {
+        cl_ulong total = 0;
+        cl_uint i;
+        for (i = 0; i < kernel->def->argCount; i++)
+        {
+            total += kernel->localSize[i];
+        }
+        STUB_INFO_VALUE(cl_ulong, total);
+    }
+    case CL_KERNEL_PREFERRED_WORK_GROUP_SIZE_MULTIPLE:
+        STUB_INFO_VALUE(size_t, 8);
+    case CL_KERNEL_PRIVATE_MEM_SIZE:
+        STUB_INFO_VALUE(cl_ulong, 0);
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+// Events
+
+CL_API_ENTRY cl_int CL_API_CALL clWaitForEvents(cl_uint num_events, cl_event const *event_list)
+{
+    cl_int status = CL_SUCCESS;
+    cl_uint i;
+    if ((num_events == 0) || (event_list == NULL))
+    {
+        return CL_INVALID_VALUE;
+    }
+    for (i = 0; i < num_events; i++)
+    {
+        if (!stubValidEvent(event_list[i]))
+        {
+            return CL_INVALID_EVENT;
+        }
+        if (event_list[i]->context != event_list[0]->context)
+        {
+            return CL_INVALID_CONTEXT;
+        }
+    }
+    pthread_mutex_lock(&stubMutex);
+    for (i = 0; i < num_events; i++)
+    {
+        while (event_list[i]->status > CL_COMPLETE)
+        {
+            pthread_cond_wait(&stubCond, &stubMutex);
+        }
+        if (event_list[i]->status < 0)
+        {
+            status = CL_EXEC_STATUS_ERROR_FOR_EVENTS_IN_WAIT_LIST;
+        }
+    }
+    pthread_mutex_unlock(&stubMutex);
+    return status;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetEventInfo(cl_event event, cl_event_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    if (!stubValidEvent(event))
+    {
+        return CL_INVALID_EVENT;
+    }
+    switch (param_name)
+    {
+    case CL_EVENT_COMMAND_QUEUE:
+        STUB_INFO_VALUE(cl_command_queue, event->queue);
+    case CL_EVENT_CONTEXT:
+        STUB_INFO_VALUE(cl_context, event->context);
+    case CL_EVENT_COMMAND_TYPE:
+        STUB_INFO_VALUE(cl_command_type, event->commandType);
+    case CL_EVENT_REFERENCE_COUNT:
+        STUB_INFO_VALUE(cl_uint, event->refCount);
+    case CL_EVENT_COMMAND_EXECUTION_STATUS:
+    {
+        cl_int status;
+        pthread_mutex_lock(&stubMutex);
+        status = event->status;
+        pthread_mutex_unlock(&stubMutex);
+        STUB_INFO_VALUE(cl_int, status);
+    }
+    default:
+        return CL_INVALID_VALUE;
+    }
+}
+
+CL_API_ENTRY cl_event CL_API_CALL clCreateUserEvent(cl_context context, cl_int *errcode_ret)
+{
+    if (!stubValidContext(context))
+    {
+        if (errcode_ret != NULL)
+        {
+            *errcode_ret = CL_INVALID_CONTEXT;
+        }
+        return NULL;
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = CL_SUCCESS;
+    }
+    return stubNewEvent(context, NULL, CL_COMMAND_USER, CL_SUBMITTED);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clRetainEvent(cl_event event)
+{
+    if (!stubValidEvent(event))
+    {
+        return CL_INVALID_EVENT;
+    }
+    __atomic_add_fetch(&event->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clReleaseEvent(cl_event event)
+{
+    if (!stubValidEvent(event))
+    {
+        return CL_INVALID_EVENT;
+    }
+    __atomic_sub_fetch(&event->refCount, 1, __ATOMIC_SEQ_CST);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clSetUserEventStatus(cl_event event, cl_int execution_status)
+{
+    if (!stubValidEvent(event) || (event->commandType != CL_COMMAND_USER))
+    {
+        return CL_INVALID_EVENT;
+    }
+    if (execution_status > CL_COMPLETE)
+    {
+        return CL_INVALID_VALUE;
+    }
+    pthread_mutex_lock(&stubMutex);
+    if (event->status <= CL_COMPLETE)
+    {
+        pthread_mutex_unlock(&stubMutex);
+        return CL_INVALID_OPERATION;
+    }
+    pthread_mutex_unlock(&stubMutex);
+    stubSetEventStatus(event, execution_status);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clSetEventCallback(cl_event event, cl_int command_exec_callback_type,
+    void(CL_CALLBACK *pfn_notify)(cl_event event, cl_int event_command_status, void *user_data), void *user_data)
+{
+    stubEventCallback *callback;
+    cl_int status;
+    if (!stubValidEvent(event))
+    {
+        return CL_INVALID_EVENT;
+    }
+    if ((pfn_notify == NULL) || ((command_exec_callback_type != CL_SUBMITTED) &&
+        (command_exec_callback_type != CL_RUNNING) && (command_exec_callback_type != CL_COMPLETE)))
+    {
+        return CL_INVALID_VALUE;
+    }
+    pthread_mutex_lock(&stubMutex);
+    status = event->status;
+    if (status > command_exec_callback_type)
+    {
+        callback = calloc(1, sizeof(stubEventCallback));
+        callback->callbackType = command_exec_callback_type;
+        callback->notify = pfn_notify;
+        callback->userData = user_data;
+        callback->next = event->callbacks;
+        event->callbacks = callback;
+        pthread_mutex_unlock(&stubMutex);
+        return CL_SUCCESS;
+    }
+    pthread_mutex_unlock(&stubMutex);
+    pfn_notify(event, (status < 0) ? status : command_exec_callback_type, user_data);
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clGetEventProfilingInfo(cl_event event, cl_profiling_info param_name,
+    size_t paramValueSize, void *paramValue, size_t *paramValueSizeRet)
+{
+    cl_ulong value;
+    if (!stubValidEvent(event))
+    {
+        return CL_INVALID_EVENT;
+    }
+    if ((event->queue == NULL) || ((event->queue->properties & CL_QUEUE_PROFILING_ENABLE) == 0))
+    {
+        return CL_PROFILING_INFO_NOT_AVAILABLE;
+    }
+    pthread_mutex_lock(&stubMutex);
+    if (event->status != CL_COMPLETE)
+    {
+        pthread_mutex_unlock(&stubMutex);
+        return CL_PROFILING_INFO_NOT_AVAILABLE;
+    }
+    switch (param_name)
+    {
+    case CL_PROFILING_COMMAND_QUEUED:
+        value = event->times[0];
+        break;
+    case CL_PROFILING_COMMAND_SUBMIT:
+        value = event->times[1];
+        break;
+    case CL_PROFILING_COMMAND_START:
+        value = event->times[2];
+        break;
+    case CL_PROFILING_COMMAND_END:
+        value = event->times[3];
+        break;
+    default:
+        pthread_mutex_unlock(&stubMutex);
+        return CL_INVALID_VALUE;
+    }
+    pthread_mutex_unlock(&stubMutex);
+    return stubInfo(&value, sizeof(value), paramValueSize, paramValue, paramValueSizeRet);
+}
+
+// Enqueued commands
+
+static cl_int stubRunNothing(stubCommand *command)
+{
+    (void)(command);
+    return CL_COMPLETE;
+}
+
+static cl_int stubRunRead(stubCommand *command)
+{
+    memcpy(command->ptr, command->src->data + command->srcOffset, command->size);
+    return CL_COMPLETE;
+}
+
+static cl_int stubRunWrite(stubCommand *command)
+{
+    memcpy(command->dst->data + command->dstOffset, command->constPtr, command->size);
+    return CL_COMPLETE;
+}
+
+static cl_int stubRunCopy(stubCommand *command)
+{
+    memmove(command->dst->data + command->dstOffset, command->src->data + command->srcOffset, command->size);
+    return CL_COMPLETE;
+}
+
+static cl_int stubRunFill(stubCommand *command)
+{
+    size_t offset;
+    for (offset = 0; offset < command->size; offset += command->patternSize)
+    {
+        memcpy(command->dst->data + command->dstOffset + offset, command->pattern, command->patternSize);
+    }
+    return CL_COMPLETE;
+}
+
+static cl_int stubRunRect(stubCommand *command)
+{
+    unsigned char const *src = (command->src != NULL) ? command->src->data : command->constPtr;
+    unsigned char *dst = (command->dst != NULL) ? command->dst->data : command->ptr;
+    size_t z;
+    size_t y;
+    for (z = 0; z < command->region[2]; z++)
+    {
+        for (y = 0; y < command->region[1]; y++)
+        {
+            size_t srcOffset = (command->srcOrigin[2] + z) * command->srcSlicePitch +
+                (command->srcOrigin[1] + y) * command->srcRowPitch + command->srcOrigin[0];
+            size_t dstOffset = (command->dstOrigin[2] + z) * command->dstSlicePitch +
+                (command->dstOrigin[1] + y) * command->dstRowPitch + command->dstOrigin[0];
+            memmove(dst + dstOffset, src + srcOffset, command->region[0]);
+        }
+    }
+    return CL_COMPLETE;
+}
+
+static cl_int stubRunKernel(stubCommand *command)
+{
+    return command->kernel->def->executionStatus;
+}
+
+static cl_int stubRunNativeKernel(stubCommand *command)
+{
+    command->userFunc(command->args);
+    return CL_COMPLETE;
+}
+
+static cl_int stubCheckBuffer(cl_command_queue queue, cl_mem mem, size_t offset, size_t size)
+{
+    if (!stubValidMem(mem))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    if (mem->context != queue->context)
+    {
+        return CL_INVALID_CONTEXT;
+    }
+    if ((size == 0) || (offset + size > mem->size))
+    {
+        return CL_INVALID_VALUE;
+    }
+    return CL_SUCCESS;
+}
+
+// stubRectExtent calculates the pitches of a rectangular region and verifies the extent against the limit.
+static cl_int stubRectExtent(size_t const *origin, size_t const *region, size_t *rowPitch, size_t *slicePitch,
+    size_t limit)
+{
+    if ((origin == NULL) || (region == NULL) || (region[0] == 0) || (region[1] == 0) || (region[2] == 0))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if (*rowPitch == 0)
+    {
+        *rowPitch = region[0];
+    }
+    if (*slicePitch == 0)
+    {
+        *slicePitch = region[1] * *rowPitch;
+    }
+    if ((*rowPitch < region[0]) || (*slicePitch < region[1] * *rowPitch))
+    {
+        return CL_INVALID_VALUE;
+    }
+    if ((limit != 0) && ((origin[2] + region[2] - 1) * *slicePitch + (origin[1] + region[1] - 1) * *rowPitch +
+        origin[0] + region[0] > limit))
+    {
+        return CL_INVALID_VALUE;
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueReadBuffer(cl_command_queue command_queue, cl_mem buffer, cl_bool blocking_read,
+    size_t offset, size_t size, void *ptr, cl_uint num_events_in_wait_list, cl_event const *event_wait_list,
+    cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    status = stubCheckBuffer(command_queue, buffer, offset, size);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if (ptr == NULL)
+    {
+        return CL_INVALID_VALUE;
+    }
+    command = stubNewCommand();
+    command->run = stubRunRead;
+    command->src = buffer;
+    command->srcOffset = offset;
+    command->size = size;
+    command->ptr = ptr;
+    return stubEnqueue(command_queue, command, CL_COMMAND_READ_BUFFER,
+        num_events_in_wait_list, event_wait_list, event, blocking_read);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueReadBufferRect(cl_command_queue command_queue, cl_mem buffer,
+    cl_bool blocking_read, size_t const *buffer_offset, size_t const *host_offset, size_t const *region,
+    size_t buffer_row_pitch, size_t buffer_slice_pitch, size_t host_row_pitch, size_t host_slice_pitch, void *ptr,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if (!stubValidMem(buffer))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    if (ptr == NULL)
+    {
+        return CL_INVALID_VALUE;
+    }
+    status = stubRectExtent(buffer_offset, region, &buffer_row_pitch, &buffer_slice_pitch, buffer->size);
+    if (status == CL_SUCCESS)
+    {
+        status = stubRectExtent(host_offset, region, &host_row_pitch, &host_slice_pitch, 0);
+    }
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    command = stubNewCommand();
+    command->run = stubRunRect;
+    command->src = buffer;
+    command->ptr = ptr;
+    memcpy(command->srcOrigin, buffer_offset, sizeof(command->srcOrigin));
+    memcpy(command->dstOrigin, host_offset, sizeof(command->dstOrigin));
+    memcpy(command->region, region, sizeof(command->region));
+    command->srcRowPitch = buffer_row_pitch;
+    command->srcSlicePitch = buffer_slice_pitch;
+    command->dstRowPitch = host_row_pitch;
+    command->dstSlicePitch = host_slice_pitch;
+    return stubEnqueue(command_queue, command, CL_COMMAND_READ_BUFFER_RECT,
+        num_events_in_wait_list, event_wait_list, event, blocking_read);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueWriteBuffer(cl_command_queue command_queue, cl_mem buffer,
+    cl_bool blocking_write, size_t offset, size_t size, void const *ptr, cl_uint num_events_in_wait_list,
+    cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    status = stubCheckBuffer(command_queue, buffer, offset, size);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if (ptr == NULL)
+    {
+        return CL_INVALID_VALUE;
+    }
+    command = stubNewCommand();
+    command->run = stubRunWrite;
+    command->dst = buffer;
+    command->dstOffset = offset;
+    command->size = size;
+    command->constPtr = ptr;
+    return stubEnqueue(command_queue, command, CL_COMMAND_WRITE_BUFFER,
+        num_events_in_wait_list, event_wait_list, event, blocking_write);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueWriteBufferRect(cl_command_queue command_queue, cl_mem buffer,
+    cl_bool blocking_write, size_t const *buffer_offset, size_t const *host_offset, size_t const *region,
+    size_t buffer_row_pitch, size_t buffer_slice_pitch, size_t host_row_pitch, size_t host_slice_pitch,
+    void const *ptr, cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if (!stubValidMem(buffer))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    if (ptr == NULL)
+    {
+        return CL_INVALID_VALUE;
+    }
+    status = stubRectExtent(buffer_offset, region, &buffer_row_pitch, &buffer_slice_pitch, buffer->size);
+    if (status == CL_SUCCESS)
+    {
+        status = stubRectExtent(host_offset, region, &host_row_pitch, &host_slice_pitch, 0);
+    }
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    command = stubNewCommand();
+    command->run = stubRunRect;
+    command->dst = buffer;
+    command->constPtr = ptr;
+    memcpy(command->srcOrigin, host_offset, sizeof(command->srcOrigin));
+    memcpy(command->dstOrigin, buffer_offset, sizeof(command->dstOrigin));
+    memcpy(command->region, region, sizeof(command->region));
+    command->srcRowPitch = host_row_pitch;
+    command->srcSlicePitch = host_slice_pitch;
+    command->dstRowPitch = buffer_row_pitch;
+    command->dstSlicePitch = buffer_slice_pitch;
+    return stubEnqueue(command_queue, command, CL_COMMAND_WRITE_BUFFER_RECT,
+        num_events_in_wait_list, event_wait_list, event, blocking_write);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueFillBuffer(cl_command_queue command_queue, cl_mem buffer, void const *pattern,
+    size_t pattern_size, size_t offset, size_t size, cl_uint num_events_in_wait_list, cl_event const *event_wait_list,
+    cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    status = stubCheckBuffer(command_queue, buffer, offset, size);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if ((pattern == NULL) || (pattern_size == 0) || (pattern_size > 128) || ((pattern_size & (pattern_size - 1)) != 0) ||
+        ((offset % pattern_size) != 0) || ((size % pattern_size) != 0))
+    {
+        return CL_INVALID_VALUE;
+    }
+    command = stubNewCommand();
+    command->run = stubRunFill;
+    command->dst = buffer;
+    command->dstOffset = offset;
+    command->size = size;
+    memcpy(command->pattern, pattern, pattern_size);
+    command->patternSize = pattern_size;
+    return stubEnqueue(command_queue, command, CL_COMMAND_FILL_BUFFER,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueCopyBuffer(cl_command_queue command_queue, cl_mem src_buffer,
+    cl_mem dst_buffer, size_t src_offset, size_t dst_offset, size_t size, cl_uint num_events_in_wait_list,
+    cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    status = stubCheckBuffer(command_queue, src_buffer, src_offset, size);
+    if (status == CL_SUCCESS)
+    {
+        status = stubCheckBuffer(command_queue, dst_buffer, dst_offset, size);
+    }
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if ((src_buffer->data + src_offset < dst_buffer->data + dst_offset + size) &&
+        (dst_buffer->data + dst_offset < src_buffer->data + src_offset + size))
+    {
+        return CL_MEM_COPY_OVERLAP;
+    }
+    command = stubNewCommand();
+    command->run = stubRunCopy;
+    command->src = src_buffer;
+    command->srcOffset = src_offset;
+    command->dst = dst_buffer;
+    command->dstOffset = dst_offset;
+    command->size = size;
+    return stubEnqueue(command_queue, command, CL_COMMAND_COPY_BUFFER,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueCopyBufferRect(cl_command_queue command_queue, cl_mem src_buffer,
+    cl_mem dst_buffer, size_t const *src_origin, size_t const *dst_origin, size_t const *region,
+    size_t src_row_pitch, size_t src_slice_pitch, size_t dst_row_pitch, size_t dst_slice_pitch,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if (!stubValidMem(src_buffer) || !stubValidMem(dst_buffer))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    status = stubRectExtent(src_origin, region, &src_row_pitch, &src_slice_pitch, src_buffer->size);
+    if (status == CL_SUCCESS)
+    {
+        status = stubRectExtent(dst_origin, region, &dst_row_pitch, &dst_slice_pitch, dst_buffer->size);
+    }
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    command = stubNewCommand();
+    command->run = stubRunRect;
+    command->src = src_buffer;
+    command->dst = dst_buffer;
+    memcpy(command->srcOrigin, src_origin, sizeof(command->srcOrigin));
+    memcpy(command->dstOrigin, dst_origin, sizeof(command->dstOrigin));
+    memcpy(command->region, region, sizeof(command->region));
+    command->srcRowPitch = src_row_pitch;
+    command->srcSlicePitch = src_slice_pitch;
+    command->dstRowPitch = dst_row_pitch;
+    command->dstSlicePitch = dst_slice_pitch;
+    return stubEnqueue(command_queue, command, CL_COMMAND_COPY_BUFFER_RECT,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueReadImage(cl_command_queue command_queue, cl_mem image, cl_bool blocking_read,
+    size_t const *origin, size_t const *region, size_t row_pitch, size_t slice_pitch, void *ptr,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    (void)(image);
+    (void)(blocking_read);
+    (void)(origin);
+    (void)(region);
+    (void)(row_pitch);
+    (void)(slice_pitch);
+    (void)(ptr);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    return stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueWriteImage(cl_command_queue command_queue, cl_mem image,
+    cl_bool blocking_write, size_t const *origin, size_t const *region, size_t input_row_pitch,
+    size_t input_slice_pitch, void const *ptr, cl_uint num_events_in_wait_list, cl_event const *event_wait_list,
+    cl_event *event)
+{
+    (void)(image);
+    (void)(blocking_write);
+    (void)(origin);
+    (void)(region);
+    (void)(input_row_pitch);
+    (void)(input_slice_pitch);
+    (void)(ptr);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    return stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueFillImage(cl_command_queue command_queue, cl_mem image, void const *fill_color,
+    size_t const *origin, size_t const *region, cl_uint num_events_in_wait_list, cl_event const *event_wait_list,
+    cl_event *event)
+{
+    (void)(image);
+    (void)(fill_color);
+    (void)(origin);
+    (void)(region);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    return stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueCopyImage(cl_command_queue command_queue, cl_mem src_image, cl_mem dst_image,
+    size_t const *src_origin, size_t const *dst_origin, size_t const *region, cl_uint num_events_in_wait_list,
+    cl_event const *event_wait_list, cl_event *event)
+{
+    (void)(src_image);
+    (void)(dst_image);
+    (void)(src_origin);
+    (void)(dst_origin);
+    (void)(region);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    return stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueCopyImageToBuffer(cl_command_queue command_queue, cl_mem src_image,
+    cl_mem dst_buffer, size_t const *src_origin, size_t const *region, size_t dst_offset,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    (void)(src_image);
+    (void)(dst_buffer);
+    (void)(src_origin);
+    (void)(region);
+    (void)(dst_offset);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    return stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueCopyBufferToImage(cl_command_queue command_queue, cl_mem src_buffer,
+    cl_mem dst_image, size_t src_offset, size_t const *dst_origin, size_t const *region,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    (void)(src_buffer);
+    (void)(dst_image);
+    (void)(src_offset);
+    (void)(dst_origin);
+    (void)(region);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    return stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+}
+
+CL_API_ENTRY void *CL_API_CALL clEnqueueMapBuffer(cl_command_queue command_queue, cl_mem buffer, cl_bool blocking_map,
+    cl_map_flags map_flags, size_t offset, size_t size, cl_uint num_events_in_wait_list,
+    cl_event const *event_wait_list, cl_event *event, cl_int *errcode_ret)
+{
+    cl_int status;
+    if (!stubValidQueue(command_queue))
+    {
+        status = CL_INVALID_COMMAND_QUEUE;
+    }
+    else
+    {
+        status = stubCheckBuffer(command_queue, buffer, offset, size);
+    }
+    if ((status == CL_SUCCESS) &&
+        ((map_flags & ~(cl_map_flags)(CL_MAP_READ | CL_MAP_WRITE | CL_MAP_WRITE_INVALIDATE_REGION)) != 0))
+    {
+        status = CL_INVALID_VALUE;
+    }
+    if (status == CL_SUCCESS)
+    {
+        stubCommand *command = stubNewCommand();
+        command->run = stubRunNothing;
+        status = stubEnqueue(command_queue, command, CL_COMMAND_MAP_BUFFER,
+            num_events_in_wait_list, event_wait_list, event, blocking_map);
+    }
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = status;
+    }
+    if (status != CL_SUCCESS)
+    {
+        return NULL;
+    }
+    __atomic_add_fetch(&buffer->mapCount, 1, __ATOMIC_SEQ_CST);
+    return buffer->data + offset;
+}
+
+CL_API_ENTRY void *CL_API_CALL clEnqueueMapImage(cl_command_queue command_queue, cl_mem image, cl_bool blocking_map,
+    cl_map_flags map_flags, size_t const *origin, size_t const *region, size_t *image_row_pitch,
+    size_t *image_slice_pitch, cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event,
+    cl_int *errcode_ret)
+{
+    (void)(image);
+    (void)(blocking_map);
+    (void)(map_flags);
+    (void)(origin);
+    (void)(region);
+    (void)(image_row_pitch);
+    (void)(image_slice_pitch);
+    (void)(num_events_in_wait_list);
+    (void)(event_wait_list);
+    (void)(event);
+    if (errcode_ret != NULL)
+    {
+        *errcode_ret = stubValidQueue(command_queue) ? CL_INVALID_MEM_OBJECT : CL_INVALID_COMMAND_QUEUE;
+    }
+    return NULL;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueUnmapMemObject(cl_command_queue command_queue, cl_mem memobj, void *mapped_ptr,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if (!stubValidMem(memobj))
+    {
+        return CL_INVALID_MEM_OBJECT;
+    }
+    if ((memobj->mapCount == 0) || ((unsigned char *)(mapped_ptr) < memobj->data) ||
+        ((unsigned char *)(mapped_ptr) >= memobj->data + memobj->size))
+    {
+        return CL_INVALID_VALUE;
+    }
+    __atomic_sub_fetch(&memobj->mapCount, 1, __ATOMIC_SEQ_CST);
+    command = stubNewCommand();
+    command->run = stubRunNothing;
+    return stubEnqueue(command_queue, command, CL_COMMAND_UNMAP_MEM_OBJECT,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueMigrateMemObjects(cl_command_queue command_queue, cl_uint num_mem_objects,
+    cl_mem const *mem_objects, cl_mem_migration_flags flags, cl_uint num_events_in_wait_list,
+    cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_uint i;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if ((num_mem_objects == 0) || (mem_objects == NULL) ||
+        ((flags & ~(cl_mem_migration_flags)(CL_MIGRATE_MEM_OBJECT_HOST | CL_MIGRATE_MEM_OBJECT_CONTENT_UNDEFINED)) != 0))
+    {
+        return CL_INVALID_VALUE;
+    }
+    for (i = 0; i < num_mem_objects; i++)
+    {
+        if (!stubValidMem(mem_objects[i]))
+        {
+            return CL_INVALID_MEM_OBJECT;
+        }
+    }
+    command = stubNewCommand();
+    command->run = stubRunNothing;
+    return stubEnqueue(command_queue, command, CL_COMMAND_MIGRATE_MEM_OBJECTS,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+static cl_int stubCheckKernelLaunch(cl_command_queue command_queue, cl_kernel kernel)
+{
+    cl_uint i;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if (!stubValidKernel(kernel))
+    {
+        return CL_INVALID_KERNEL;
+    }
+    if (kernel->program->context != command_queue->context)
+    {
+        return CL_INVALID_CONTEXT;
+    }
+    for (i = 0; i < kernel->def->argCount; i++)
+    {
+        if (!kernel->argSet[i])
+        {
+            return CL_INVALID_KERNEL_ARGS;
+        }
+    }
+    return CL_SUCCESS;
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueNDRangeKernel(cl_command_queue command_queue, cl_kernel kernel,
+    cl_uint work_dim, size_t const *global_work_offset, size_t const *global_work_size,
+    size_t const *local_work_size, cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_uint i;
+    cl_int status = stubCheckKernelLaunch(command_queue, kernel);
+    (void)(global_work_offset);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    if ((work_dim < 1) || (work_dim > 3))
+    {
+        return CL_INVALID_WORK_DIMENSION;
+    }
+    if (global_work_size == NULL)
+    {
+        return CL_INVALID_GLOBAL_WORK_SIZE;
+    }
+    for (i = 0; i < work_dim; i++)
+    {
+        if (global_work_size[i] == 0)
+        {
+            return CL_INVALID_GLOBAL_WORK_SIZE;
+        }
+        if ((local_work_size != NULL) && (local_work_size[i] != 0) && ((global_work_size[i] % local_work_size[i]) != 0))
+        {
+            return CL_INVALID_WORK_GROUP_SIZE;
+        }
+    }
+    command = stubNewCommand();
+    command->run = stubRunKernel;
+    command->kernel = kernel;
+    return stubEnqueue(command_queue, command, CL_COMMAND_NDRANGE_KERNEL,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueTask(cl_command_queue command_queue, cl_kernel kernel,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    cl_int status = stubCheckKernelLaunch(command_queue, kernel);
+    if (status != CL_SUCCESS)
+    {
+        return status;
+    }
+    command = stubNewCommand();
+    command->run = stubRunKernel;
+    command->kernel = kernel;
+    return stubEnqueue(command_queue, command, CL_COMMAND_TASK,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueNativeKernel(cl_command_queue command_queue,
+    void(CL_CALLBACK *user_func)(void *), void *args, size_t cb_args, cl_uint num_mem_objects, cl_mem const *mem_list,
+    void const **args_mem_loc, cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    unsigned char *copy = NULL;
+    cl_uint i;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    if ((user_func == NULL) || ((args == NULL) && (cb_args > 0 || num_mem_objects > 0)) ||
+        ((args != NULL) && (cb_args == 0)) || ((num_mem_objects > 0) && ((mem_list == NULL) || (args_mem_loc == NULL))) ||
+        ((num_mem_objects == 0) && ((mem_list != NULL) || (args_mem_loc != NULL))))
+    {
+        return CL_INVALID_VALUE;
+    }
+    for (i = 0; i < num_mem_objects; i++)
+    {
+        size_t offset = (size_t)((unsigned char const *)(args_mem_loc[i]) - (unsigned char const *)(args));
+        if (!stubValidMem(mem_list[i]))
+        {
+            return CL_INVALID_MEM_OBJECT;
+        }
+        if (((unsigned char const *)(args_mem_loc[i]) < (unsigned char const *)(args)) ||
+            (offset + sizeof(void *) > cb_args))
+        {
+            return CL_INVALID_VALUE;
+        }
+    }
+    if (args != NULL)
+    {
+        copy = malloc(cb_args);
+        memcpy(copy, args, cb_args);
+        for (i = 0; i < num_mem_objects; i++)
+        {
+            size_t offset = (size_t)((unsigned char const *)(args_mem_loc[i]) - (unsigned char const *)(args));
+            void *data = mem_list[i]->data;
+            memcpy(copy + offset, &data, sizeof(data));
+        }
+    }
+    command = stubNewCommand();
+    command->run = stubRunNativeKernel;
+    command->userFunc = user_func;
+    command->args = copy;
+    return stubEnqueue(command_queue, command, CL_COMMAND_NATIVE_KERNEL,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueMarkerWithWaitList(cl_command_queue command_queue,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    command = stubNewCommand();
+    command->run = stubRunNothing;
+    return stubEnqueue(command_queue, command, CL_COMMAND_MARKER,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
+
+CL_API_ENTRY cl_int CL_API_CALL clEnqueueBarrierWithWaitList(cl_command_queue command_queue,
+    cl_uint num_events_in_wait_list, cl_event const *event_wait_list, cl_event *event)
+{
+    stubCommand *command;
+    if (!stubValidQueue(command_queue))
+    {
+        return CL_INVALID_COMMAND_QUEUE;
+    }
+    command = stubNewCommand();
+    command->run = stubRunNothing;
+    return stubEnqueue(command_queue, command, CL_COMMAND_BARRIER,
+        num_events_in_wait_list, event_wait_list, event, CL_FALSE);
+}
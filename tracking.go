@@ -0,0 +1,181 @@
+package cl12
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// ReferenceTrackingEnvVar is the name of the environment variable that enables reference tracking if it is set to
+// a non-empty value at program start. Reference tracking is always enabled in builds with the tag "cl12debug".
+//
+// See LeakReport() for details.
+const ReferenceTrackingEnvVar = "CL12_TRACK_REFERENCES"
+
+// ReferenceCall is a recorded call that created, retained, or released an object.
+type ReferenceCall struct {
+	// Function is the name of the OpenCL API function, such as "clRetainKernel".
+	Function string
+	// Stack is the stack trace of the caller.
+	Stack string
+}
+
+// ReferenceLeak describes an object for which not all references were released.
+type ReferenceLeak struct {
+	// Object is the handle of the object, such as a Kernel or MemObject value.
+	Object any
+	// Count is the number of references that were created or retained, but not released, through this library.
+	Count int
+	// DriverCount is the reference count as reported by the driver, with the respective information value
+	// such as KernelReferenceCountInfo. The driver count can be higher than Count, as objects also keep references
+	// to each other. For example, a kernel holds a reference to its program.
+	DriverCount uint32
+	// DriverCountErr is set if the driver count could not be queried.
+	DriverCountErr error
+	// Calls lists all recorded calls for the object, in the order they were made.
+	Calls []ReferenceCall
+}
+
+// String returns a summary of the leak, followed by the recorded calls.
+func (leak ReferenceLeak) String() string {
+	var text strings.Builder
+	_, _ = fmt.Fprintf(&text, "%T %v: %d references", leak.Object, leak.Object, leak.Count)
+	if leak.DriverCountErr != nil {
+		_, _ = fmt.Fprintf(&text, " (driver count not available: %v)", leak.DriverCountErr)
+	} else {
+		_, _ = fmt.Fprintf(&text, " (driver count: %d)", leak.DriverCount)
+	}
+	for _, call := range leak.Calls {
+		_, _ = fmt.Fprintf(&text, "\n%s at:\n%s", call.Function, call.Stack)
+	}
+	return text.String()
+}
+
+// ReferenceTrackingEnabled returns true if calls are recorded for LeakReport().
+func ReferenceTrackingEnabled() bool {
+	return referenceTracker.enabled
+}
+
+// LeakReport returns all objects that have references which were created or retained, but not yet released.
+// This includes the events returned by enqueue functions.
+//
+// The report is only available if reference tracking is enabled, see ReferenceTrackingEnvVar. Otherwise, the
+// returned list is empty. Only calls made through this library are considered. Root-level devices are only listed
+// if they were retained explicitly.
+func LeakReport() []ReferenceLeak {
+	referenceTracker.mutex.Lock()
+	leaks := make([]ReferenceLeak, 0, len(referenceTracker.objects))
+	for _, refs := range referenceTracker.objects {
+		leaks = append(leaks, ReferenceLeak{
+			Object: refs.object,
+			Count:  refs.count,
+			Calls:  append([]ReferenceCall{}, refs.calls...),
+		})
+	}
+	referenceTracker.mutex.Unlock()
+	for i := range leaks {
+		leaks[i].DriverCount, leaks[i].DriverCountErr = driverReferenceCount(leaks[i].Object)
+	}
+	return leaks
+}
+
+func driverReferenceCount(object any) (uint32, error) {
+	switch handle := object.(type) {
+	case Context:
+		return InfoValue[uint32](ContextInfoQuery(handle, ContextReferenceCountInfo))
+	case CommandQueue:
+		return InfoValue[uint32](CommandQueueInfoQuery(handle, QueueReferenceCountInfo))
+	case MemObject:
+		return InfoValue[uint32](MemObjectInfoQuery(handle, MemReferenceCountInfo))
+	case Sampler:
+		return InfoValue[uint32](SamplerInfoQuery(handle, SamplerReferenceCountInfo))
+	case Program:
+		return InfoValue[uint32](ProgramInfoQuery(handle, ProgramReferenceCountInfo))
+	case Kernel:
+		return InfoValue[uint32](KernelInfoQuery(handle, KernelReferenceCountInfo))
+	case Event:
+		return InfoValue[uint32](EventInfoQuery(handle, EventReferenceCountInfo))
+	case DeviceID:
+		return InfoValue[uint32](DeviceInfoQuery(handle, DeviceReferenceCountInfo))
+	default:
+		return 0, nil
+	}
+}
+
+type trackedReferences struct {
+	object any
+	count  int
+	calls  []ReferenceCall
+}
+
+var referenceTracker = struct {
+	enabled bool
+	mutex   sync.Mutex
+	objects map[any]*trackedReferences
+}{
+	enabled: referenceTrackingBuild || (len(os.Getenv(ReferenceTrackingEnvVar)) > 0),
+	objects: make(map[any]*trackedReferences),
+}
+
+// trackCreated records the creation of an object, which starts with one reference.
+func trackCreated(function string, object any) {
+	trackReferences(function, object, 1)
+}
+
+// trackRetained records an additional reference of an object.
+func trackRetained(function string, object any) {
+	trackReferences(function, object, 1)
+}
+
+// trackReleased records the release of a reference. Objects without references are no longer tracked.
+func trackReleased(function string, object any) {
+	trackReferences(function, object, -1)
+}
+
+// trackEnqueuedEvent records the creation of the event that an enqueue function returned, if requested.
+func trackEnqueuedEvent(function string, event *Event) {
+	if event != nil {
+		trackReferences(function, *event, 1)
+	}
+}
+
+func trackReferences(function string, object any, delta int) {
+	if !referenceTracker.enabled {
+		return
+	}
+	call := ReferenceCall{Function: function, Stack: callerStack(3)}
+	referenceTracker.mutex.Lock()
+	defer referenceTracker.mutex.Unlock()
+	refs, known := referenceTracker.objects[object]
+	if !known {
+		if delta < 0 {
+			// The object was created before tracking, or is a root-level device. It cannot be balanced.
+			return
+		}
+		refs = &trackedReferences{object: object}
+		referenceTracker.objects[object] = refs
+	}
+	refs.count += delta
+	refs.calls = append(refs.calls, call)
+	if refs.count <= 0 {
+		delete(referenceTracker.objects, object)
+	}
+}
+
+// callerStack returns the stack trace of the calling goroutine, skipping the given number of frames.
+func callerStack(skip int) string {
+	pc := make([]uintptr, 32)
+	count := runtime.Callers(skip+1, pc)
+	frames := runtime.CallersFrames(pc[:count])
+	var text strings.Builder
+	for {
+		frame, more := frames.Next()
+		_, _ = fmt.Fprintf(&text, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,5 @@
+//go:build cl12debug
+
+package cl12
+
+const referenceTrackingBuild = true
@@ -0,0 +1,75 @@
+package cl12_test
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+// TestReferenceTrackingEnvVar runs TestLeakReport in a new process of the test binary, which enables reference
+// tracking through the environment variable.
+func TestReferenceTrackingEnvVar(t *testing.T) {
+	t.Parallel()
+	requireStub(t)
+	if len(os.Getenv(cl.ReferenceTrackingEnvVar)) > 0 {
+		t.Skipf("reference tracking is enabled by the environment already")
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestLeakReport$", "-test.v")
+	cmd.Env = append(os.Environ(), cl.ReferenceTrackingEnvVar+"=1")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("test process failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(string(output), "--- PASS: TestLeakReport") {
+		t.Errorf("TestLeakReport did not pass:\n%s", output)
+	}
+}
+
+func TestLeakReport(t *testing.T) {
+	t.Parallel()
+	if !cl.ReferenceTrackingEnabled() {
+		t.Skipf("reference tracking not enabled")
+	}
+	context, _ := stubContext(t)
+	buffer, err := cl.CreateBuffer(context, cl.MemReadWriteFlag, 64, nil)
+	if err != nil {
+		t.Fatalf("CreateBuffer() failed: %v", err)
+	}
+	if err = cl.RetainMemObject(buffer); err != nil {
+		t.Fatalf("RetainMemObject() failed: %v", err)
+	}
+	if err = cl.ReleaseMemObject(buffer); err != nil {
+		t.Fatalf("ReleaseMemObject() failed: %v", err)
+	}
+	leak, found := findLeak(buffer)
+	if !found {
+		t.Fatalf("buffer not reported")
+	}
+	if (leak.Count != 1) || (leak.DriverCount != 1) || (leak.DriverCountErr != nil) {
+		t.Errorf("unexpected counts: %v", leak)
+	}
+	if (len(leak.Calls) != 3) || (leak.Calls[0].Function != "clCreateBuffer") {
+		t.Errorf("unexpected calls: %v", leak.Calls)
+	}
+	if !strings.Contains(leak.Calls[0].Stack, "TestLeakReport") {
+		t.Errorf("stack does not contain the caller:\n%s", leak.Calls[0].Stack)
+	}
+	if err = cl.ReleaseMemObject(buffer); err != nil {
+		t.Fatalf("ReleaseMemObject() failed: %v", err)
+	}
+	if _, found = findLeak(buffer); found {
+		t.Errorf("released buffer still reported")
+	}
+}
+
+func findLeak(object any) (cl.ReferenceLeak, bool) {
+	for _, leak := range cl.LeakReport() {
+		if leak.Object == object {
+			return leak, true
+		}
+	}
+	return cl.ReferenceLeak{}, false
+}
@@ -0,0 +1,208 @@
+package cl12
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// BufferElement is the constraint for the element type of a Buffer.
+// The types have a fixed size and contain no pointers. Arrays correspond to the vector types of OpenCL C, such as
+// [4]float32 for float4. As the 3-component vectors of OpenCL C have the size of 4-component vectors, buffers of
+// such vectors are presented by arrays of length 4 as well.
+type BufferElement interface {
+	BufferScalar | BufferVector
+}
+
+// BufferScalar is the constraint for the scalar element types of a Buffer.
+type BufferScalar interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~int64 | ~uint64 | ~float32 | ~float64
+}
+
+// BufferVector is the constraint for the vector element types of a Buffer.
+type BufferVector interface {
+	~[2]int8 | ~[2]uint8 | ~[2]int16 | ~[2]uint16 | ~[2]int32 | ~[2]uint32 | ~[2]int64 | ~[2]uint64 | ~[2]float32 | ~[2]float64 |
+		~[4]int8 | ~[4]uint8 | ~[4]int16 | ~[4]uint16 | ~[4]int32 | ~[4]uint32 | ~[4]int64 | ~[4]uint64 | ~[4]float32 | ~[4]float64 |
+		~[8]int8 | ~[8]uint8 | ~[8]int16 | ~[8]uint16 | ~[8]int32 | ~[8]uint32 | ~[8]int64 | ~[8]uint64 | ~[8]float32 | ~[8]float64 |
+		~[16]int8 | ~[16]uint8 | ~[16]int16 | ~[16]uint16 | ~[16]int32 | ~[16]uint32 | ~[16]int64 | ~[16]uint64 | ~[16]float32 | ~[16]float64
+}
+
+// Buffer is a buffer object that holds a number of elements of type T.
+//
+// The methods check offsets and lengths before they call into the library, and fail with an error that wraps
+// ErrOutOfBounds for invalid ranges. All methods that enqueue commands block until the command completed,
+// as the commands may use Go memory that must not be accessed once the method returned.
+type Buffer[T BufferElement] struct {
+	mem    MemObject
+	length int
+}
+
+// CreateBufferOf creates a buffer object for the given number of elements.
+// See CreateBuffer() for the possible flags.
+// The size of the buffer in bytes must not exceed the range of int.
+func CreateBufferOf[T BufferElement](context Context, flags MemFlags, length int) (Buffer[T], error) {
+	var buf Buffer[T]
+	if (length <= 0) || (length > buf.maxLength()) {
+		return Buffer[T]{}, newOpError("clCreateBuffer", fmt.Errorf("%w: length %d", ErrOutOfBounds, length), context)
+	}
+	mem, err := CreateBuffer(context, flags, length*int(buf.elementSize()), nil)
+	if err != nil {
+		return Buffer[T]{}, err
+	}
+	return Buffer[T]{mem: mem, length: length}, nil
+}
+
+// CreateBufferFrom creates a buffer object with a copy of the given data.
+// The flag MemCopyHostPtrFlag is added to the given flags.
+func CreateBufferFrom[T BufferElement](context Context, flags MemFlags, data []T) (Buffer[T], error) {
+	var buf Buffer[T]
+	if (len(data) == 0) || (len(data) > buf.maxLength()) {
+		return Buffer[T]{}, newOpError("clCreateBuffer", fmt.Errorf("%w: length %d", ErrOutOfBounds, len(data)), context)
+	}
+	mem, err := CreateBuffer(context, flags|MemCopyHostPtrFlag, len(data)*int(buf.elementSize()), unsafe.Pointer(&data[0]))
+	if err != nil {
+		return Buffer[T]{}, err
+	}
+	return Buffer[T]{mem: mem, length: len(data)}, nil
+}
+
+// BufferOf returns a typed view on an existing buffer object that holds the given number of elements.
+// The buffer object is not retained.
+func BufferOf[T BufferElement](mem MemObject, length int) Buffer[T] {
+	return Buffer[T]{mem: mem, length: length}
+}
+
+// MemObject returns the underlying buffer object.
+func (buf Buffer[T]) MemObject() MemObject {
+	return buf.mem
+}
+
+// Len returns the number of elements in the buffer.
+func (buf Buffer[T]) Len() int {
+	return buf.length
+}
+
+// ByteSize returns the size of the buffer in bytes.
+func (buf Buffer[T]) ByteSize() uintptr {
+	return uintptr(buf.length) * buf.elementSize()
+}
+
+// Release decrements the reference count of the underlying buffer object. See ReleaseMemObject().
+func (buf Buffer[T]) Release() error {
+	return ReleaseMemObject(buf.mem)
+}
+
+// Write copies the data into the buffer, starting at the first element.
+func (buf Buffer[T]) Write(queue CommandQueue, data []T) error {
+	return buf.WriteAt(queue, 0, data)
+}
+
+// WriteAt copies the data into the buffer, starting at the element with the given offset.
+func (buf Buffer[T]) WriteAt(queue CommandQueue, offset int, data []T) error {
+	if err := buf.checkRange("clEnqueueWriteBuffer", queue, offset, len(data)); err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return EnqueueWriteBuffer(queue, buf.mem, true, uintptr(offset)*buf.elementSize(),
+		uintptr(len(data))*buf.elementSize(), unsafe.Pointer(&data[0]), nil, nil)
+}
+
+// Read copies elements of the buffer into data, starting at the first element.
+func (buf Buffer[T]) Read(queue CommandQueue, data []T) error {
+	return buf.ReadAt(queue, 0, data)
+}
+
+// ReadAt copies elements of the buffer into data, starting at the element with the given offset.
+func (buf Buffer[T]) ReadAt(queue CommandQueue, offset int, data []T) error {
+	if err := buf.checkRange("clEnqueueReadBuffer", queue, offset, len(data)); err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return EnqueueReadBuffer(queue, buf.mem, true, uintptr(offset)*buf.elementSize(),
+		uintptr(len(data))*buf.elementSize(), unsafe.Pointer(&data[0]), nil, nil)
+}
+
+// Fill sets all elements of the buffer to the given value.
+func (buf Buffer[T]) Fill(queue CommandQueue, value T) error {
+	if err := buf.checkRange("clEnqueueFillBuffer", queue, 0, buf.length); err != nil {
+		return err
+	}
+	return enqueueAndWait(func(event *Event) error {
+		return EnqueueFillBuffer(queue, buf.mem, unsafe.Pointer(&value), buf.elementSize(), 0, buf.ByteSize(), nil, event)
+	})
+}
+
+// CopyTo copies all elements of the buffer into the destination buffer, starting at its first element.
+// The destination must be at least as long as the buffer.
+func (buf Buffer[T]) CopyTo(queue CommandQueue, dst Buffer[T]) error {
+	if err := buf.checkRange("clEnqueueCopyBuffer", queue, 0, buf.length); err != nil {
+		return err
+	}
+	if err := dst.checkRange("clEnqueueCopyBuffer", queue, 0, buf.length); err != nil {
+		return err
+	}
+	return enqueueAndWait(func(event *Event) error {
+		return EnqueueCopyBuffer(queue, buf.mem, dst.mem, 0, 0, buf.ByteSize(), nil, event)
+	})
+}
+
+// Sub creates a sub-buffer for the given range of elements. See CreateSubBuffer() for the possible flags.
+//
+// The offset, in bytes, must be a multiple of the alignment reported by DeviceMemBaseAddrAlignInfo, otherwise the
+// call fails with ErrMisalignedSubBufferOffset. The sub-buffer must be released separately.
+func (buf Buffer[T]) Sub(flags MemFlags, offset, length int) (Buffer[T], error) {
+	if (length <= 0) || (offset < 0) || (offset > buf.length-length) || (offset > buf.maxLength()-length) {
+		return Buffer[T]{}, newOpError("clCreateSubBuffer",
+			fmt.Errorf("%w: range [%d, %d) of buffer with length %d", ErrOutOfBounds, offset, offset+length, buf.length),
+			buf.mem)
+	}
+	region := BufferRegion{
+		Origin: uintptr(offset) * buf.elementSize(),
+		Size:   uintptr(length) * buf.elementSize(),
+	}
+	mem, err := CreateSubBuffer(buf.mem, flags, BufferCreateTypeRegion, unsafe.Pointer(&region))
+	if err != nil {
+		return Buffer[T]{}, err
+	}
+	return Buffer[T]{mem: mem, length: length}, nil
+}
+
+func (buf Buffer[T]) elementSize() uintptr {
+	var element T
+	return unsafe.Sizeof(element)
+}
+
+// maxLength returns the highest number of elements whose size in bytes is within the range of int.
+func (buf Buffer[T]) maxLength() int {
+	return math.MaxInt / int(buf.elementSize())
+}
+
+func (buf Buffer[T]) elementType() reflect.Type {
+	var element T
+	return reflect.TypeOf(element)
+}
+
+func (buf Buffer[T]) checkRange(function string, queue CommandQueue, offset, length int) error {
+	if (offset < 0) || (length < 0) || (offset > buf.length-length) || (offset > buf.maxLength()-length) {
+		return newOpError(function,
+			fmt.Errorf("%w: range [%d, %d) of buffer with length %d", ErrOutOfBounds, offset, offset+length, buf.length),
+			queue, buf.mem)
+	}
+	return nil
+}
+
+// enqueueAndWait calls the enqueue function with a new event, and waits for the event to complete.
+func enqueueAndWait(enqueue func(event *Event) error) error {
+	var event Event
+	if err := enqueue(&event); err != nil {
+		return err
+	}
+	err := WaitForEvents([]Event{event})
+	_ = ReleaseEvent(event)
+	return err
+}
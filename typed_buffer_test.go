@@ -0,0 +1,126 @@
+package cl12_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	cl "github.com/opencl-go/cl12"
+)
+
+func TestBufferReadWrite(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	buffer, err := cl.CreateBufferOf[float32](context, cl.MemReadWriteFlag, 8)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = buffer.Release() }()
+	if (buffer.Len() != 8) || (buffer.ByteSize() != 32) {
+		t.Errorf("unexpected size: %d elements, %d bytes", buffer.Len(), buffer.ByteSize())
+	}
+	if err = buffer.Fill(queue, 1.5); err != nil {
+		t.Fatalf("Fill() failed: %v", err)
+	}
+	if err = buffer.WriteAt(queue, 6, []float32{2, 3}); err != nil {
+		t.Fatalf("WriteAt() failed: %v", err)
+	}
+	data := make([]float32, 8)
+	if err = buffer.Read(queue, data); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if (data[0] != 1.5) || (data[5] != 1.5) || (data[6] != 2) || (data[7] != 3) {
+		t.Errorf("unexpected data: %v", data)
+	}
+}
+
+func TestBufferOfVectors(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	buffer, err := cl.CreateBufferFrom(context, cl.MemReadWriteFlag, [][4]float32{{1, 2, 3, 4}, {5, 6, 7, 8}})
+	if err != nil {
+		t.Fatalf("CreateBufferFrom() failed: %v", err)
+	}
+	defer func() { _ = buffer.Release() }()
+	if buffer.ByteSize() != 32 {
+		t.Errorf("unexpected byte size: %d", buffer.ByteSize())
+	}
+	if err = buffer.Fill(queue, [4]float32{0, 0, 0, 1}); err != nil {
+		t.Fatalf("Fill() failed: %v", err)
+	}
+	data := make([][4]float32, 2)
+	if err = buffer.Read(queue, data); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	if data[1] != [4]float32{0, 0, 0, 1} {
+		t.Errorf("unexpected data: %v", data)
+	}
+}
+
+func TestBufferBounds(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	buffer, err := cl.CreateBufferOf[int32](context, cl.MemReadWriteFlag, 4)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = buffer.Release() }()
+	tt := []struct {
+		name string
+		call func() error
+	}{
+		{name: "write beyond end", call: func() error { return buffer.WriteAt(queue, 3, []int32{1, 2}) }},
+		{name: "negative offset", call: func() error { return buffer.ReadAt(queue, -1, []int32{0}) }},
+		{name: "read too long", call: func() error { return buffer.Read(queue, make([]int32, 5)) }},
+		{name: "sub beyond end", call: func() error { _, err := buffer.Sub(0, 2, 3); return err }},
+		{name: "size overflow", call: func() error {
+			_, err := cl.CreateBufferOf[[4]float64](context, cl.MemReadWriteFlag, math.MaxInt/16)
+			return err
+		}},
+		{name: "view size overflow", call: func() error {
+			return cl.BufferOf[int64](buffer.MemObject(), math.MaxInt).ReadAt(queue, math.MaxInt/8, []int64{0})
+		}},
+	}
+	for _, tc := range tt {
+		if err := tc.call(); !errors.Is(err, cl.ErrOutOfBounds) {
+			t.Errorf("%s: expected ErrOutOfBounds, got: %v", tc.name, err)
+		}
+	}
+}
+
+func TestBufferCopyAndSub(t *testing.T) {
+	t.Parallel()
+	context, device := stubContext(t)
+	queue := stubCommandQueue(t, context, device, 0)
+	source, err := cl.CreateBufferFrom(context, cl.MemReadOnlyFlag, []uint16{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	if err != nil {
+		t.Fatalf("CreateBufferFrom() failed: %v", err)
+	}
+	defer func() { _ = source.Release() }()
+	buffer, err := cl.CreateBufferOf[uint16](context, cl.MemReadWriteFlag, 128)
+	if err != nil {
+		t.Fatalf("CreateBufferOf() failed: %v", err)
+	}
+	defer func() { _ = buffer.Release() }()
+	sub, err := buffer.Sub(0, 64, 16)
+	if err != nil {
+		t.Fatalf("Sub() failed: %v", err)
+	}
+	defer func() { _ = sub.Release() }()
+	if err = source.CopyTo(queue, sub); err != nil {
+		t.Fatalf("CopyTo() failed: %v", err)
+	}
+	data := make([]uint16, 2)
+	if err = buffer.ReadAt(queue, 72, data); err != nil {
+		t.Fatalf("ReadAt() failed: %v", err)
+	}
+	if (data[0] != 8) || (data[1] != 9) {
+		t.Errorf("unexpected data: %v", data)
+	}
+	if err = sub.CopyTo(queue, source); !errors.Is(err, cl.ErrOutOfBounds) {
+		t.Errorf("expected ErrOutOfBounds for short destination, got: %v", err)
+	}
+}